@@ -0,0 +1,119 @@
+package pimit
+
+import "image"
+
+type (
+	AlphaReadDelegate               = func(x, y int, v uint8)
+	AlphaReadErrorableDelegate      = func(x, y int, v uint8) error
+	AlphaReadWriteDelegate          = func(x, y int, v uint8) uint8
+	AlphaReadWriteErrorableDelegate = func(x, y int, v uint8) (uint8, error)
+)
+
+// Perform a parallel iteration of the pixels of the provided Alpha image. For each pixel, execute the delegate function
+// allowing you to read the alpha (A as uint8) and coordinates. The rows are split into chunks processed by
+// a bounded number of worker goroutines.
+func ParallelAlphaRead(src *image.Alpha, d AlphaReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	parallelChannelRead("ParallelAlphaRead", newChannelImage[uint8](src.Pix, src.Stride, src.Rect), d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Alpha image. For each pixel, execute the delegate function
+// allowing you to read the alpha (A as uint8) and coordinates. The rows are split into chunks processed by
+// a bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned.
+func ParallelAlphaReadE(src *image.Alpha, d AlphaReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	return parallelChannelReadE("ParallelAlphaReadE", newChannelImage[uint8](src.Pix, src.Stride, src.Rect), d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Alpha image. For each pixel, execute the delegate function
+// allowing you to read the alpha (A as uint8) and coordinates, the delegate return alpha will be set at the
+// given coordinates. This changes will be applied to the passed image instance. Consider using ParallelReadWriteNew if
+// you want to avoid changes to the original image at the expense of additional allocations. The rows are split into
+// chunks processed by a bounded number of worker goroutines.
+func ParallelAlphaReadWrite(src *image.Alpha, d AlphaReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	img := newChannelImage[uint8](src.Pix, src.Stride, src.Rect)
+	parallelChannelReadWrite("ParallelAlphaReadWrite", img, img, d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Alpha image. For each pixel, execute the delegate function
+// allowing you to read the alpha (A as uint8) and coordinates, the delegate return alpha will be set at the
+// given coordinates. This changes will be applied to the passed image instance. Consider using ParallelReadWriteNewE if
+// you want to avoid changes to the original image at the expense of additional allocations. The rows are split into
+// chunks processed by a bounded number of worker goroutines. The iteration will break after the first error occurs and
+// the error will be returned.
+func ParallelAlphaReadWriteE(src *image.Alpha, d AlphaReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	img := newChannelImage[uint8](src.Pix, src.Stride, src.Rect)
+	return parallelChannelReadWriteE("ParallelAlphaReadWriteE", img, img, d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Alpha image. For each pixel, execute the delegate function
+// allowing you to read the alpha (A as uint8) and coordinates, the delegate return alpha will be set at the
+// given coordinates. This changes will be applied to a new image instance which internally uses the Alpha color space
+// and is returned by the function. The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelAlphaReadWriteNew(src *image.Alpha, d AlphaReadWriteDelegate, opts ...Option) *image.Alpha {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	dst := image.NewAlpha(src.Bounds())
+	parallelChannelReadWrite("ParallelAlphaReadWriteNew", newChannelImage[uint8](src.Pix, src.Stride, src.Rect), newChannelImage[uint8](dst.Pix, dst.Stride, dst.Rect), d, opts)
+
+	return dst
+}
+
+// Perform a parallel iteration of the pixels of the provided Alpha image. For each pixel, execute the delegate function
+// allowing you to read the alpha (A as uint8) and coordinates, the delegate return alpha will be set at the
+// given coordinates. This changes will be applied to a new image instance which internally uses the Alpha color space
+// and is returned by the function. The rows are split into chunks processed by a bounded number of worker goroutines.
+// The iteration will break after the first error occurs and the error will be returned.
+func ParallelAlphaReadWriteNewE(src *image.Alpha, d AlphaReadWriteErrorableDelegate, opts ...Option) (*image.Alpha, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	dst := image.NewAlpha(src.Bounds())
+	if err := parallelChannelReadWriteE("ParallelAlphaReadWriteNewE", newChannelImage[uint8](src.Pix, src.Stride, src.Rect), newChannelImage[uint8](dst.Pix, dst.Stride, dst.Rect), d, opts); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
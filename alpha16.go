@@ -0,0 +1,120 @@
+package pimit
+
+import "image"
+
+type (
+	Alpha16ReadDelegate               = func(x, y int, v uint16)
+	Alpha16ReadErrorableDelegate      = func(x, y int, v uint16) error
+	Alpha16ReadWriteDelegate          = func(x, y int, v uint16) uint16
+	Alpha16ReadWriteErrorableDelegate = func(x, y int, v uint16) (uint16, error)
+)
+
+// Perform a parallel iteration of the pixels of the provided Alpha16 image. For each pixel, execute the delegate
+// function allowing you to read the alpha (A as uint16) and coordinates. The rows are split into chunks processed
+// by a bounded number of worker goroutines.
+func ParallelAlpha16Read(src *image.Alpha16, d Alpha16ReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	parallelChannelRead("ParallelAlpha16Read", newChannelImage[uint16](src.Pix, src.Stride, src.Rect), d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Alpha16 image. For each pixel, execute the delegate
+// function allowing you to read the alpha (A as uint16) and coordinates. The rows are split into chunks processed
+// by a bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned.
+func ParallelAlpha16ReadE(src *image.Alpha16, d Alpha16ReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	return parallelChannelReadE("ParallelAlpha16ReadE", newChannelImage[uint16](src.Pix, src.Stride, src.Rect), d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Alpha16 image. For each pixel, execute the delegate
+// function allowing you to read the alpha (A as uint16) and coordinates, the delegate return alpha will be
+// set at the given coordinates. This changes will be applied to the passed image instance. Consider using
+// ParallelReadWriteNew if you want to avoid changes to the original image at the expense of additional allocations. The
+// rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelAlpha16ReadWrite(src *image.Alpha16, d Alpha16ReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	img := newChannelImage[uint16](src.Pix, src.Stride, src.Rect)
+	parallelChannelReadWrite("ParallelAlpha16ReadWrite", img, img, d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Alpha16 image. For each pixel, execute the delegate
+// function allowing you to read the alpha (A as uint16) and coordinates, the delegate return alpha will be
+// set at the given coordinates. This changes will be applied to the passed image instance. Consider using
+// ParallelReadWriteNewE if you want to avoid changes to the original image at the expense of additional allocations.
+// The rows are split into chunks processed by a bounded number of worker goroutines. The iteration will break after the
+// first error occurs and the error will be returned.
+func ParallelAlpha16ReadWriteE(src *image.Alpha16, d Alpha16ReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	img := newChannelImage[uint16](src.Pix, src.Stride, src.Rect)
+	return parallelChannelReadWriteE("ParallelAlpha16ReadWriteE", img, img, d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Alpha16 image. For each pixel, execute the delegate
+// function allowing you to read the alpha (A as uint16) and coordinates, the delegate return alpha will be
+// set at the given coordinates. This changes will be applied to a new image instance which internally uses the Alpha16
+// color space and is returned by the function. The rows are split into chunks processed by a bounded number of worker
+// goroutines.
+func ParallelAlpha16ReadWriteNew(src *image.Alpha16, d Alpha16ReadWriteDelegate, opts ...Option) *image.Alpha16 {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	dst := image.NewAlpha16(src.Bounds())
+	parallelChannelReadWrite("ParallelAlpha16ReadWriteNew", newChannelImage[uint16](src.Pix, src.Stride, src.Rect), newChannelImage[uint16](dst.Pix, dst.Stride, dst.Rect), d, opts)
+
+	return dst
+}
+
+// Perform a parallel iteration of the pixels of the provided Alpha16 image. For each pixel, execute the delegate
+// function allowing you to read the alpha (A as uint16) and coordinates, the delegate return alpha will be
+// set at the given coordinates. This changes will be applied to a new image instance which internally uses the Alpha16
+// color space and is returned by the function. The rows are split into chunks processed by a bounded number of worker
+// goroutines. The iteration will break after the first error occurs and the error will be returned.
+func ParallelAlpha16ReadWriteNewE(src *image.Alpha16, d Alpha16ReadWriteErrorableDelegate, opts ...Option) (*image.Alpha16, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	dst := image.NewAlpha16(src.Bounds())
+	if err := parallelChannelReadWriteE("ParallelAlpha16ReadWriteNewE", newChannelImage[uint16](src.Pix, src.Stride, src.Rect), newChannelImage[uint16](dst.Pix, dst.Stride, dst.Rect), d, opts); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
@@ -0,0 +1,374 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelAlpha16ReadShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlpha16Read(nil, func(x, y int, v uint16) {})
+	})
+}
+
+func TestParallelAlpha16ReadShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	assert.Panics(t, func() {
+		ParallelAlpha16Read(img, nil)
+	})
+}
+
+func TestParallelAlpha16ReadShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	exV := uint16(65535)
+
+	ParallelAlpha16Read(img, func(xIndex int, yIndex int, acV uint16) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+	})
+}
+
+func TestParallelAlpha16ReadEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlpha16ReadE(nil, func(x, y int, v uint16) error {
+			return nil
+		})
+	})
+}
+
+func TestParallelAlpha16ReadEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	assert.Panics(t, func() {
+		ParallelAlpha16ReadE(img, nil)
+	})
+}
+
+func TestParallelAlpha16ReadEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	err := ParallelAlpha16ReadE(img, func(x, y int, v uint16) error {
+		return errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelAlpha16ReadEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	exV := uint16(65535)
+
+	err := ParallelAlpha16ReadE(img, func(xIndex, yIndex int, acV uint16) error {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestParallelAlpha16ReadWriteShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlpha16ReadWrite(nil, func(x, y int, v uint16) uint16 {
+			return v
+		})
+	})
+}
+
+func TestParallelAlpha16ReadWriteShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	assert.Panics(t, func() {
+		ParallelAlpha16ReadWrite(img, nil)
+	})
+}
+
+func TestParallelAlpha16ReadWriteShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	exV := uint16(65535)
+
+	ParallelAlpha16ReadWrite(img, func(xIndex, yIndex int, acV uint16) uint16 {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0
+	})
+
+	expectedImage := mockTransparentImageAlpha16()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+	assert.Equal(t, expectedImage.Pix, img.Pix)
+}
+
+func TestParallelAlpha16ReadWriteEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlpha16ReadWriteE(nil, func(x, y int, v uint16) (uint16, error) {
+			return v, nil
+		})
+	})
+}
+
+func TestParallelAlpha16ReadWriteEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	assert.Panics(t, func() {
+		ParallelAlpha16ReadWriteE(img, nil)
+	})
+}
+
+func TestParallelAlpha16ReadWriteEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	err := ParallelAlpha16ReadWriteE(img, func(x, y int, v uint16) (uint16, error) {
+		return v, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelAlpha16ReadWriteEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	exV := uint16(65535)
+
+	err := ParallelAlpha16ReadWriteE(img, func(xIndex, yIndex int, acV uint16) (uint16, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockTransparentImageAlpha16()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+	assert.Equal(t, expectedImage.Pix, img.Pix)
+}
+
+func TestParallelAlpha16ReadWriteEShouldLeaveImageUnchangedOnFailureWhenAtomic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	err := ParallelAlpha16ReadWriteE(img, func(x, y int, v uint16) (uint16, error) {
+		if x == 3 && y == 4 {
+			return v, errors.New("pimit-test: test errror")
+		}
+
+		return 0, nil
+	}, WithAtomic(), WithWorkers(2), WithChunkSize(1))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, mockOpaqueImageAlpha16().Pix, img.Pix)
+}
+
+func TestParallelAlpha16ReadWriteNewShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlpha16ReadWriteNew(nil, func(x, y int, v uint16) uint16 {
+			return v
+		})
+	})
+}
+
+func TestParallelAlpha16ReadWriteNewShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	assert.Panics(t, func() {
+		ParallelAlpha16ReadWriteNew(img, nil)
+	})
+}
+
+func TestParallelAlpha16ReadWriteNewShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	exV := uint16(65535)
+
+	actualImage := ParallelAlpha16ReadWriteNew(img, func(xIndex, yIndex int, acV uint16) uint16 {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0
+	})
+
+	expectedImage := mockTransparentImageAlpha16()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+	assert.Equal(t, expectedImage.Pix, actualImage.Pix)
+	assert.Equal(t, mockOpaqueImageAlpha16().Pix, img.Pix)
+}
+
+func TestParallelAlpha16ReadWriteNewEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlpha16ReadWriteNewE(nil, func(x, y int, v uint16) (uint16, error) {
+			return v, nil
+		})
+	})
+}
+
+func TestParallelAlpha16ReadWriteNewEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	assert.Panics(t, func() {
+		ParallelAlpha16ReadWriteNewE(img, nil)
+	})
+}
+
+func TestParallelAlpha16ReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	modifiedImg, err := ParallelAlpha16ReadWriteNewE(img, func(x, y int, v uint16) (uint16, error) {
+		return v, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, modifiedImg)
+}
+
+func TestParallelAlpha16ReadWriteNewEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha16()
+
+	exV := uint16(65535)
+
+	actualImage, err := ParallelAlpha16ReadWriteNewE(img, func(xIndex, yIndex int, acV uint16) (uint16, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockTransparentImageAlpha16()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+	assert.Equal(t, expectedImage.Pix, actualImage.Pix)
+}
+
+func TestParallelAlpha16FunctionsShouldMatchColorModel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 2, 9, 11)
+	img := mockCoordinateImageAlpha16(bounds)
+
+	ParallelAlpha16Read(img, func(x, y int, v uint16) {
+		assert.Equal(t, img.Alpha16At(x, y).A, v)
+	})
+
+	dst := ParallelAlpha16ReadWriteNew(img, func(x, y int, v uint16) uint16 {
+		return ^v
+	})
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			assert.Equal(t, color.Alpha16{^img.Alpha16At(x, y).A}, dst.Alpha16At(x, y))
+		}
+	}
+}
+
+func mockOpaqueImageAlpha16() *image.Alpha16 {
+	width, height := 5, 6
+
+	img := image.NewAlpha16(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Opaque)
+		}
+	}
+
+	return img
+}
+
+func mockTransparentImageAlpha16() *image.Alpha16 {
+	width, height := 5, 6
+
+	img := image.NewAlpha16(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Transparent)
+		}
+	}
+
+	return img
+}
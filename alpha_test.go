@@ -0,0 +1,374 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelAlphaReadShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlphaRead(nil, func(x, y int, v uint8) {})
+	})
+}
+
+func TestParallelAlphaReadShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	assert.Panics(t, func() {
+		ParallelAlphaRead(img, nil)
+	})
+}
+
+func TestParallelAlphaReadShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	exV := uint8(255)
+
+	ParallelAlphaRead(img, func(xIndex int, yIndex int, acV uint8) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+	})
+}
+
+func TestParallelAlphaReadEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlphaReadE(nil, func(x, y int, v uint8) error {
+			return nil
+		})
+	})
+}
+
+func TestParallelAlphaReadEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	assert.Panics(t, func() {
+		ParallelAlphaReadE(img, nil)
+	})
+}
+
+func TestParallelAlphaReadEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	err := ParallelAlphaReadE(img, func(x, y int, v uint8) error {
+		return errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelAlphaReadEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	exV := uint8(255)
+
+	err := ParallelAlphaReadE(img, func(xIndex, yIndex int, acV uint8) error {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestParallelAlphaReadWriteShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlphaReadWrite(nil, func(x, y int, v uint8) uint8 {
+			return v
+		})
+	})
+}
+
+func TestParallelAlphaReadWriteShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	assert.Panics(t, func() {
+		ParallelAlphaReadWrite(img, nil)
+	})
+}
+
+func TestParallelAlphaReadWriteShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	exV := uint8(255)
+
+	ParallelAlphaReadWrite(img, func(xIndex, yIndex int, acV uint8) uint8 {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0
+	})
+
+	expectedImage := mockTransparentImageAlpha()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+	assert.Equal(t, expectedImage.Pix, img.Pix)
+}
+
+func TestParallelAlphaReadWriteEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlphaReadWriteE(nil, func(x, y int, v uint8) (uint8, error) {
+			return v, nil
+		})
+	})
+}
+
+func TestParallelAlphaReadWriteEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	assert.Panics(t, func() {
+		ParallelAlphaReadWriteE(img, nil)
+	})
+}
+
+func TestParallelAlphaReadWriteEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	err := ParallelAlphaReadWriteE(img, func(x, y int, v uint8) (uint8, error) {
+		return v, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelAlphaReadWriteEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	exV := uint8(255)
+
+	err := ParallelAlphaReadWriteE(img, func(xIndex, yIndex int, acV uint8) (uint8, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockTransparentImageAlpha()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+	assert.Equal(t, expectedImage.Pix, img.Pix)
+}
+
+func TestParallelAlphaReadWriteEShouldLeaveImageUnchangedOnFailureWhenAtomic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	err := ParallelAlphaReadWriteE(img, func(x, y int, v uint8) (uint8, error) {
+		if x == 3 && y == 4 {
+			return v, errors.New("pimit-test: test errror")
+		}
+
+		return 0, nil
+	}, WithAtomic(), WithWorkers(2), WithChunkSize(1))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, mockOpaqueImageAlpha().Pix, img.Pix)
+}
+
+func TestParallelAlphaReadWriteNewShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlphaReadWriteNew(nil, func(x, y int, v uint8) uint8 {
+			return v
+		})
+	})
+}
+
+func TestParallelAlphaReadWriteNewShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	assert.Panics(t, func() {
+		ParallelAlphaReadWriteNew(img, nil)
+	})
+}
+
+func TestParallelAlphaReadWriteNewShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	exV := uint8(255)
+
+	actualImage := ParallelAlphaReadWriteNew(img, func(xIndex, yIndex int, acV uint8) uint8 {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0
+	})
+
+	expectedImage := mockTransparentImageAlpha()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+	assert.Equal(t, expectedImage.Pix, actualImage.Pix)
+	assert.Equal(t, mockOpaqueImageAlpha().Pix, img.Pix)
+}
+
+func TestParallelAlphaReadWriteNewEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelAlphaReadWriteNewE(nil, func(x, y int, v uint8) (uint8, error) {
+			return v, nil
+		})
+	})
+}
+
+func TestParallelAlphaReadWriteNewEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	assert.Panics(t, func() {
+		ParallelAlphaReadWriteNewE(img, nil)
+	})
+}
+
+func TestParallelAlphaReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	modifiedImg, err := ParallelAlphaReadWriteNewE(img, func(x, y int, v uint8) (uint8, error) {
+		return v, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, modifiedImg)
+}
+
+func TestParallelAlphaReadWriteNewEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockOpaqueImageAlpha()
+
+	exV := uint8(255)
+
+	actualImage, err := ParallelAlphaReadWriteNewE(img, func(xIndex, yIndex int, acV uint8) (uint8, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockTransparentImageAlpha()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+	assert.Equal(t, expectedImage.Pix, actualImage.Pix)
+}
+
+func TestParallelAlphaFunctionsShouldMatchColorModel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 2, 9, 11)
+	img := mockCoordinateImageAlpha(bounds)
+
+	ParallelAlphaRead(img, func(x, y int, v uint8) {
+		assert.Equal(t, img.AlphaAt(x, y).A, v)
+	})
+
+	dst := ParallelAlphaReadWriteNew(img, func(x, y int, v uint8) uint8 {
+		return ^v
+	})
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			assert.Equal(t, color.Alpha{^img.AlphaAt(x, y).A}, dst.AlphaAt(x, y))
+		}
+	}
+}
+
+func mockOpaqueImageAlpha() *image.Alpha {
+	width, height := 5, 6
+
+	img := image.NewAlpha(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Opaque)
+		}
+	}
+
+	return img
+}
+
+func mockTransparentImageAlpha() *image.Alpha {
+	width, height := 5, 6
+
+	img := image.NewAlpha(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Transparent)
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,215 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestAtomicFunctionsShouldLeaveImageUnchangedOnFailure(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-7, 3, 25, 29)
+	random := rand.New(rand.NewSource(2024))
+
+	for name, iterate := range mockAtomicImageIterations(bounds) {
+		for _, policy := range []ErrorPolicy{FailFast, CollectAll, DeterministicFirst} {
+			for run := 0; run < 10; run += 1 {
+				failX := bounds.Min.X + random.Intn(bounds.Dx())
+				failY := bounds.Min.Y + random.Intn(bounds.Dy())
+
+				img, err := iterate(func(x, y int) error {
+					if x == failX && y == failY {
+						return errors.New("pimit-test: test error")
+					}
+
+					return nil
+				}, WithAtomic(), WithErrorPolicy(policy), WithWorkers(4), WithChunkSize(1))
+
+				assert.NotNil(t, err, name)
+				assertUntouchedCoordinateImage(t, img, image.Rectangle{})
+			}
+		}
+	}
+}
+
+func TestAtomicFunctionsShouldLeaveImageUnchangedOnErrStop(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-4, 1, 21, 23)
+
+	for name, iterate := range mockAtomicImageIterations(bounds) {
+		for _, policy := range []ErrorPolicy{FailFast, CollectAll, DeterministicFirst} {
+			var visits int32 = 0
+
+			img, err := iterate(func(x, y int) error {
+				if atomic.AddInt32(&visits, 1) == 200 {
+					return ErrStop
+				}
+
+				return nil
+			}, WithAtomic(), WithErrorPolicy(policy), WithWorkers(3), WithChunkSize(2))
+
+			assert.Nil(t, err, name)
+			assertUntouchedCoordinateImage(t, img, image.Rectangle{})
+		}
+	}
+}
+
+func TestAtomicFunctionsShouldLeaveImageUnchangedOnPanic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(2, 2, 20, 20)
+
+	for name, iterate := range mockAtomicImageIterations(bounds) {
+		img, err := iterate(func(x, y int) error {
+			if x == 11 && y == 13 {
+				panic("pimit-test: test panic")
+			}
+
+			return nil
+		}, WithAtomic(), WithWorkers(3))
+
+		var pe *PanicError
+
+		assert.ErrorAs(t, err, &pe, name)
+		assertUntouchedCoordinateImage(t, img, image.Rectangle{})
+	}
+}
+
+func TestAtomicFunctionsShouldFullyUpdateImageOnSuccess(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, -3, 17, 11)
+
+	for name, iterate := range mockAtomicImageIterations(bounds) {
+		img, err := iterate(func(_, _ int) error {
+			return nil
+		}, WithAtomic(), WithWorkers(4))
+
+		assert.Nil(t, err, name)
+		assertInvertedCoordinateImage(t, img, bounds)
+	}
+}
+
+func TestAtomicMatrixFunctionShouldLeaveMatrixUnchangedOnFailure(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	width, height := 31, 17
+	random := rand.New(rand.NewSource(2024))
+
+	for run := 0; run < 20; run += 1 {
+		failX, failY := random.Intn(width), random.Intn(height)
+
+		m := mockCustomMatrix(width, height, 0)
+		for x := 0; x < width; x += 1 {
+			for y := 0; y < height; y += 1 {
+				m[x][y] = x*height + y
+			}
+		}
+
+		err := ParallelMatrixReadWriteE(m, func(x, y, value int) (int, error) {
+			if x == failX && y == failY {
+				return 0, errors.New("pimit-test: test error")
+			}
+
+			return -value, nil
+		}, WithAtomic(), WithErrorPolicy(CollectAll), WithWorkers(4), WithChunkSize(1))
+
+		assert.NotNil(t, err)
+		for x := 0; x < width; x += 1 {
+			for y := 0; y < height; y += 1 {
+				assert.Equal(t, x*height+y, m[x][y])
+			}
+		}
+	}
+}
+
+func TestFunctionsShouldKeepProcessedPixelsWithoutAtomicOption(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 8, 8)
+	img := mockCoordinateImageRgba(bounds)
+
+	err := ParallelRgbaReadWriteE(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+		if y == 7 {
+			return 0, 0, 0, 0, errors.New("pimit-test: test error")
+		}
+
+		return ^r, ^g, ^b, a, nil
+	}, WithWorkers(1))
+
+	assert.NotNil(t, err)
+	assertInvertedCoordinateImage(t, img, image.Rect(0, 0, 8, 7))
+}
+
+// Create a set of iterations using every errorable read-write function modifying the passed image instance. The
+// delegates invert the colors of the mock coordinate image with the provided bounds and return the error of the
+// fail function. The modified image is returned together with the error of the iteration.
+func mockAtomicImageIterations(bounds image.Rectangle) map[string]func(fail func(x, y int) error, opts ...Option) (image.Image, error) {
+	invert := func(c color.Color) color.Color {
+		rgba := c.(color.RGBA)
+		return color.RGBA{^rgba.R, ^rgba.G, ^rgba.B, rgba.A}
+	}
+
+	return map[string]func(fail func(x, y int) error, opts ...Option) (image.Image, error){
+		"ParallelReadWriteE": func(fail func(x, y int) error, opts ...Option) (image.Image, error) {
+			img := mockCoordinateImageRgba(bounds)
+			return img, ParallelReadWriteE(img, func(x, y int, c color.Color) (color.Color, error) {
+				return invert(c), fail(x, y)
+			}, opts...)
+		},
+		"ParallelDistributedReadWriteE": func(fail func(x, y int) error, opts ...Option) (image.Image, error) {
+			img := mockCoordinateImageRgba(bounds)
+			return img, ParallelDistributedReadWriteE(img, 9, func(x, y int, c color.Color) (color.Color, error) {
+				return invert(c), fail(x, y)
+			}, opts...)
+		},
+		"ParallelRgbaReadWriteE": func(fail func(x, y int) error, opts ...Option) (image.Image, error) {
+			img := mockCoordinateImageRgba(bounds)
+			return img, ParallelRgbaReadWriteE(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+				return ^r, ^g, ^b, a, fail(x, y)
+			}, opts...)
+		},
+		"ParallelNrgbaReadWriteE": func(fail func(x, y int) error, opts ...Option) (image.Image, error) {
+			img := mockCoordinateImageNrgba(bounds)
+			return img, ParallelNrgbaReadWriteE(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+				return ^r, ^g, ^b, a, fail(x, y)
+			}, opts...)
+		},
+		"ParallelRgba64ReadWriteE": func(fail func(x, y int) error, opts ...Option) (image.Image, error) {
+			img := mockCoordinateImageRgba64(bounds)
+			return img, ParallelRgba64ReadWriteE(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+				return ^r, ^g, ^b, a, fail(x, y)
+			}, opts...)
+		},
+		"ParallelNrgba64ReadWriteE": func(fail func(x, y int) error, opts ...Option) (image.Image, error) {
+			img := mockCoordinateImageNrgba64(bounds)
+			return img, ParallelNrgba64ReadWriteE(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+				return ^r, ^g, ^b, a, fail(x, y)
+			}, opts...)
+		},
+		"ParallelFloatReadWriteE": func(fail func(x, y int) error, opts ...Option) (image.Image, error) {
+			img := mockCoordinateImageFloat(bounds)
+			return img, ParallelFloatReadWriteE(img, func(x, y int, r, g, b, a float32) (float32, float32, float32, float32, error) {
+				return 1 - r, 1 - g, 1 - b, a, fail(x, y)
+			}, opts...)
+		},
+		"ParallelColorSpaceReadWriteE": func(fail func(x, y int) error, opts ...Option) (image.Image, error) {
+			img := mockCoordinateImageRgba(bounds)
+			return img, ParallelColorSpaceReadWriteE(img, ColorSpaceHsv, func(x, y int, h, s, v, a float32) (float32, float32, float32, float32, error) {
+				if inverted := 1 - v*(1-s); inverted > 0 {
+					return h + 180, v * s / inverted, inverted, a, fail(x, y)
+				}
+
+				return h, 0, 0, a, fail(x, y)
+			}, opts...)
+		},
+	}
+}
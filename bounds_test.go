@@ -0,0 +1,607 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+var boundsTestCases = []struct {
+	name     string
+	bounds   image.Rectangle
+	relative bool
+}{
+	{"zero origin", image.Rect(0, 0, 5, 6), false},
+	{"positive origin", image.Rect(3, 7, 8, 11), false},
+	{"negative origin", image.Rect(-4, -2, 1, 3), false},
+	{"positive origin relative", image.Rect(3, 7, 8, 11), true},
+	{"negative origin relative", image.Rect(-4, -2, 1, 3), true},
+}
+
+func TestGeneralFunctionsShouldHonorBoundsOrigin(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, c := range boundsTestCases {
+		t.Run(c.name, func(t *testing.T) {
+			opts := boundsTestOptions(c.relative)
+
+			img := mockCoordinateImageRgba(c.bounds)
+			v := newVisitTracker(t, c.bounds, c.relative)
+			ParallelRead(img, func(x, y int, col color.Color) {
+				v.visit(x, y, col)
+			}, opts...)
+			v.assertVisitedOnce()
+
+			v = newVisitTracker(t, c.bounds, c.relative)
+			err := ParallelReadE(img, func(x, y int, col color.Color) error {
+				v.visit(x, y, col)
+				return nil
+			}, opts...)
+			assert.Nil(t, err)
+			v.assertVisitedOnce()
+
+			img = mockCoordinateImageRgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			ParallelReadWrite(img, func(x, y int, col color.Color) color.Color {
+				return v.visitAndInvert(x, y, col)
+			}, opts...)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, img, c.bounds)
+
+			img = mockCoordinateImageRgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			err = ParallelReadWriteE(img, func(x, y int, col color.Color) (color.Color, error) {
+				return v.visitAndInvert(x, y, col), nil
+			}, opts...)
+			assert.Nil(t, err)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, img, c.bounds)
+
+			for _, clusters := range []int{1, 3, 7} {
+				img = mockCoordinateImageRgba(c.bounds)
+				v = newVisitTracker(t, c.bounds, c.relative)
+				ParallelDistributedReadWrite(img, clusters, func(x, y int, col color.Color) color.Color {
+					return v.visitAndInvert(x, y, col)
+				}, opts...)
+				v.assertVisitedOnce()
+				assertInvertedCoordinateImage(t, img, c.bounds)
+
+				img = mockCoordinateImageRgba(c.bounds)
+				v = newVisitTracker(t, c.bounds, c.relative)
+				err = ParallelDistributedReadWriteE(img, clusters, func(x, y int, col color.Color) (color.Color, error) {
+					return v.visitAndInvert(x, y, col), nil
+				}, opts...)
+				assert.Nil(t, err)
+				v.assertVisitedOnce()
+				assertInvertedCoordinateImage(t, img, c.bounds)
+			}
+
+			img = mockCoordinateImageRgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			dst := ParallelReadWriteNew(img, func(x, y int, col color.Color) color.Color {
+				return v.visitAndInvert(x, y, col)
+			}, opts...)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, dst, c.bounds)
+
+			img = mockCoordinateImageRgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			dst, err = ParallelReadWriteNewE(img, func(x, y int, col color.Color) (color.Color, error) {
+				return v.visitAndInvert(x, y, col), nil
+			}, opts...)
+			assert.Nil(t, err)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, dst, c.bounds)
+		})
+	}
+}
+
+func TestGeneralFunctionsShouldHonorSubImageBounds(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	parent := mockCoordinateImageRgba(image.Rect(0, 0, 12, 10))
+	bounds := image.Rect(3, 2, 9, 7)
+
+	sub := parent.SubImage(bounds).(draw.Image)
+	v := newVisitTracker(t, bounds, false)
+	ParallelReadWrite(sub, func(x, y int, col color.Color) color.Color {
+		return v.visitAndInvert(x, y, col)
+	})
+	v.assertVisitedOnce()
+	assertInvertedCoordinateImage(t, parent, bounds)
+
+	for y := 0; y < 10; y += 1 {
+		for x := 0; x < 12; x += 1 {
+			if !image.Pt(x, y).In(bounds) {
+				assert.Equal(t, mockCoordinateColor(x, y), parent.RGBAAt(x, y))
+			}
+		}
+	}
+}
+
+func TestRgbaFunctionsShouldHonorBoundsOrigin(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, c := range boundsTestCases {
+		t.Run(c.name, func(t *testing.T) {
+			opts := boundsTestOptions(c.relative)
+
+			img := mockCoordinateImageRgba(c.bounds)
+			v := newVisitTracker(t, c.bounds, c.relative)
+			ParallelRgbaRead(img, func(x, y int, r, g, b, a uint8) {
+				v.visit(x, y, color.RGBA{r, g, b, a})
+			}, opts...)
+			v.assertVisitedOnce()
+
+			v = newVisitTracker(t, c.bounds, c.relative)
+			err := ParallelRgbaReadE(img, func(x, y int, r, g, b, a uint8) error {
+				v.visit(x, y, color.RGBA{r, g, b, a})
+				return nil
+			}, opts...)
+			assert.Nil(t, err)
+			v.assertVisitedOnce()
+
+			img = mockCoordinateImageRgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			ParallelRgbaReadWrite(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				return rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+			}, opts...)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, img, c.bounds)
+
+			img = mockCoordinateImageRgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			err = ParallelRgbaReadWriteE(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+				r, g, b, a = rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+				return r, g, b, a, nil
+			}, opts...)
+			assert.Nil(t, err)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, img, c.bounds)
+
+			img = mockCoordinateImageRgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			dst := ParallelRgbaReadWriteNew(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				return rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+			}, opts...)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, dst, c.bounds)
+
+			img = mockCoordinateImageRgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			dst, err = ParallelRgbaReadWriteNewE(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+				r, g, b, a = rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+				return r, g, b, a, nil
+			}, opts...)
+			assert.Nil(t, err)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, dst, c.bounds)
+		})
+	}
+}
+
+func TestNrgbaFunctionsShouldHonorBoundsOrigin(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, c := range boundsTestCases {
+		t.Run(c.name, func(t *testing.T) {
+			opts := boundsTestOptions(c.relative)
+
+			img := mockCoordinateImageNrgba(c.bounds)
+			v := newVisitTracker(t, c.bounds, c.relative)
+			ParallelNrgbaRead(img, func(x, y int, r, g, b, a uint8) {
+				v.visit(x, y, color.RGBA{r, g, b, a})
+			}, opts...)
+			v.assertVisitedOnce()
+
+			v = newVisitTracker(t, c.bounds, c.relative)
+			err := ParallelNrgbaReadE(img, func(x, y int, r, g, b, a uint8) error {
+				v.visit(x, y, color.RGBA{r, g, b, a})
+				return nil
+			}, opts...)
+			assert.Nil(t, err)
+			v.assertVisitedOnce()
+
+			img = mockCoordinateImageNrgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			ParallelNrgbaReadWrite(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				return rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+			}, opts...)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, img, c.bounds)
+
+			img = mockCoordinateImageNrgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			err = ParallelNrgbaReadWriteE(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+				r, g, b, a = rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+				return r, g, b, a, nil
+			}, opts...)
+			assert.Nil(t, err)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, img, c.bounds)
+
+			img = mockCoordinateImageNrgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			dst := ParallelNrgbaReadWriteNew(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				return rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+			}, opts...)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, dst, c.bounds)
+
+			img = mockCoordinateImageNrgba(c.bounds)
+			v = newVisitTracker(t, c.bounds, c.relative)
+			dst, err = ParallelNrgbaReadWriteNewE(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+				r, g, b, a = rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+				return r, g, b, a, nil
+			}, opts...)
+			assert.Nil(t, err)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, dst, c.bounds)
+		})
+	}
+}
+
+func TestRgbaFunctionsShouldHonorSubImageStride(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	parentBounds := image.Rect(-2, -1, 11, 9)
+	bounds := image.Rect(1, 2, 7, 6)
+
+	mockSub := func() (*image.RGBA, *image.RGBA) {
+		parent := mockCoordinateImageRgba(parentBounds)
+		return parent, parent.SubImage(bounds).(*image.RGBA)
+	}
+
+	parent, sub := mockSub()
+	v := newVisitTracker(t, bounds, false)
+	ParallelRgbaRead(sub, func(x, y int, r, g, b, a uint8) {
+		v.visit(x, y, color.RGBA{r, g, b, a})
+	})
+	v.assertVisitedOnce()
+
+	v = newVisitTracker(t, bounds, false)
+	err := ParallelRgbaReadE(sub, func(x, y int, r, g, b, a uint8) error {
+		v.visit(x, y, color.RGBA{r, g, b, a})
+		return nil
+	})
+	assert.Nil(t, err)
+	v.assertVisitedOnce()
+
+	parent, sub = mockSub()
+	v = newVisitTracker(t, bounds, false)
+	ParallelRgbaReadWrite(sub, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		return rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+	})
+	v.assertVisitedOnce()
+	assertInvertedCoordinateImage(t, parent, bounds)
+	assertUntouchedCoordinateImage(t, parent, bounds)
+
+	parent, sub = mockSub()
+	v = newVisitTracker(t, bounds, false)
+	err = ParallelRgbaReadWriteE(sub, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+		r, g, b, a = rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+		return r, g, b, a, nil
+	})
+	assert.Nil(t, err)
+	v.assertVisitedOnce()
+	assertInvertedCoordinateImage(t, parent, bounds)
+	assertUntouchedCoordinateImage(t, parent, bounds)
+
+	parent, sub = mockSub()
+	v = newVisitTracker(t, bounds, false)
+	dst := ParallelRgbaReadWriteNew(sub, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		return rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+	})
+	v.assertVisitedOnce()
+	assert.Equal(t, bounds, dst.Bounds())
+	assert.Equal(t, 4*bounds.Dx(), dst.Stride)
+	assertInvertedCoordinateImage(t, dst, bounds)
+	assertUntouchedCoordinateImage(t, parent, image.Rectangle{})
+
+	parent, sub = mockSub()
+	v = newVisitTracker(t, bounds, false)
+	dst, err = ParallelRgbaReadWriteNewE(sub, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+		r, g, b, a = rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+		return r, g, b, a, nil
+	})
+	assert.Nil(t, err)
+	v.assertVisitedOnce()
+	assert.Equal(t, bounds, dst.Bounds())
+	assert.Equal(t, 4*bounds.Dx(), dst.Stride)
+	assertInvertedCoordinateImage(t, dst, bounds)
+	assertUntouchedCoordinateImage(t, parent, image.Rectangle{})
+}
+
+func TestNrgbaFunctionsShouldHonorSubImageStride(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	parentBounds := image.Rect(-2, -1, 11, 9)
+	bounds := image.Rect(1, 2, 7, 6)
+
+	mockSub := func() (*image.NRGBA, *image.NRGBA) {
+		parent := mockCoordinateImageNrgba(parentBounds)
+		return parent, parent.SubImage(bounds).(*image.NRGBA)
+	}
+
+	parent, sub := mockSub()
+	v := newVisitTracker(t, bounds, false)
+	ParallelNrgbaRead(sub, func(x, y int, r, g, b, a uint8) {
+		v.visit(x, y, color.RGBA{r, g, b, a})
+	})
+	v.assertVisitedOnce()
+
+	v = newVisitTracker(t, bounds, false)
+	err := ParallelNrgbaReadE(sub, func(x, y int, r, g, b, a uint8) error {
+		v.visit(x, y, color.RGBA{r, g, b, a})
+		return nil
+	})
+	assert.Nil(t, err)
+	v.assertVisitedOnce()
+
+	parent, sub = mockSub()
+	v = newVisitTracker(t, bounds, false)
+	ParallelNrgbaReadWrite(sub, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		return rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+	})
+	v.assertVisitedOnce()
+	assertInvertedCoordinateImage(t, parent, bounds)
+	assertUntouchedCoordinateImage(t, parent, bounds)
+
+	parent, sub = mockSub()
+	v = newVisitTracker(t, bounds, false)
+	err = ParallelNrgbaReadWriteE(sub, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+		r, g, b, a = rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+		return r, g, b, a, nil
+	})
+	assert.Nil(t, err)
+	v.assertVisitedOnce()
+	assertInvertedCoordinateImage(t, parent, bounds)
+	assertUntouchedCoordinateImage(t, parent, bounds)
+
+	parent, sub = mockSub()
+	v = newVisitTracker(t, bounds, false)
+	dst := ParallelNrgbaReadWriteNew(sub, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		return rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+	})
+	v.assertVisitedOnce()
+	assert.Equal(t, bounds, dst.Bounds())
+	assert.Equal(t, 4*bounds.Dx(), dst.Stride)
+	assertInvertedCoordinateImage(t, dst, bounds)
+	assertUntouchedCoordinateImage(t, parent, image.Rectangle{})
+
+	parent, sub = mockSub()
+	v = newVisitTracker(t, bounds, false)
+	dst, err = ParallelNrgbaReadWriteNewE(sub, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+		r, g, b, a = rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+		return r, g, b, a, nil
+	})
+	assert.Nil(t, err)
+	v.assertVisitedOnce()
+	assert.Equal(t, bounds, dst.Bounds())
+	assert.Equal(t, 4*bounds.Dx(), dst.Stride)
+	assertInvertedCoordinateImage(t, dst, bounds)
+	assertUntouchedCoordinateImage(t, parent, image.Rectangle{})
+}
+
+// The visit tracker is used to verify that the delegate received every coordinate of the expected rectangle exactly
+// once, together with the color stored at the given coordinates of the mock coordinate image.
+type visitTracker struct {
+	t        *testing.T
+	bounds   image.Rectangle
+	relative bool
+	visits   map[image.Point]int
+	mu       sync.Mutex
+}
+
+func newVisitTracker(t *testing.T, bounds image.Rectangle, relative bool) *visitTracker {
+	return &visitTracker{
+		t:        t,
+		bounds:   bounds,
+		relative: relative,
+		visits:   make(map[image.Point]int),
+		mu:       sync.Mutex{},
+	}
+}
+
+func (v *visitTracker) visit(x, y int, c color.Color) {
+	if v.relative {
+		x += v.bounds.Min.X
+		y += v.bounds.Min.Y
+	}
+
+	assert.True(v.t, image.Pt(x, y).In(v.bounds), "the coordinates x=%d y=%d are out of bounds", x, y)
+
+	er, eg, eb, ea := mockCoordinateColor(x, y).RGBA()
+	ar, ag, ab, aa := c.RGBA()
+
+	assert.Equal(v.t, []uint32{er, eg, eb, ea}, []uint32{ar, ag, ab, aa})
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.visits[image.Pt(x, y)] += 1
+}
+
+func (v *visitTracker) visitAndInvert(x, y int, c color.Color) color.Color {
+	v.visit(x, y, c)
+
+	r, g, b, a := c.RGBA()
+	return color.RGBA{uint8(^r >> 8), uint8(^g >> 8), uint8(^b >> 8), uint8(a >> 8)}
+}
+
+func (v *visitTracker) assertVisitedOnce() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	assert.Len(v.t, v.visits, v.bounds.Dx()*v.bounds.Dy())
+
+	for y := v.bounds.Min.Y; y < v.bounds.Max.Y; y += 1 {
+		for x := v.bounds.Min.X; x < v.bounds.Max.X; x += 1 {
+			assert.Equal(v.t, 1, v.visits[image.Pt(x, y)], "the coordinates x=%d y=%d were not visited once", x, y)
+		}
+	}
+}
+
+func boundsTestOptions(relative bool) []Option {
+	if relative {
+		return []Option{WithRelativeCoordinates()}
+	}
+
+	return nil
+}
+
+func rgbaComponents(c color.Color) (uint8, uint8, uint8, uint8) {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return rgba.R, rgba.G, rgba.B, rgba.A
+}
+
+func mockCoordinateColor(x, y int) color.RGBA {
+	return color.RGBA{uint8(x), uint8(y), uint8(x + y), 255}
+}
+
+func mockCoordinateImageRgba(bounds image.Rectangle) *image.RGBA {
+	img := image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.SetRGBA(x, y, mockCoordinateColor(x, y))
+		}
+	}
+
+	return img
+}
+
+func mockCoordinateImageNrgba(bounds image.Rectangle) *image.NRGBA {
+	img := image.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			c := mockCoordinateColor(x, y)
+			img.SetNRGBA(x, y, color.NRGBA{c.R, c.G, c.B, c.A})
+		}
+	}
+
+	return img
+}
+
+func mockCoordinateImageRgba64(bounds image.Rectangle) *image.RGBA64 {
+	img := image.NewRGBA64(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.Set(x, y, mockCoordinateColor(x, y))
+		}
+	}
+
+	return img
+}
+
+func mockCoordinateImageNrgba64(bounds image.Rectangle) *image.NRGBA64 {
+	img := image.NewNRGBA64(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.Set(x, y, mockCoordinateColor(x, y))
+		}
+	}
+
+	return img
+}
+
+func mockCoordinateImageGray(bounds image.Rectangle) *image.Gray {
+	img := image.NewGray(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.Set(x, y, mockCoordinateColor(x, y))
+		}
+	}
+
+	return img
+}
+
+func mockCoordinateImageGray16(bounds image.Rectangle) *image.Gray16 {
+	img := image.NewGray16(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.Set(x, y, mockCoordinateColor(x, y))
+		}
+	}
+
+	return img
+}
+
+func mockCoordinateImagePaletted(bounds image.Rectangle) *image.Paletted {
+	img := image.NewPaletted(bounds, palette.Plan9)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.Set(x, y, mockCoordinateColor(x, y))
+		}
+	}
+
+	return img
+}
+
+func mockCoordinateImageAlpha(bounds image.Rectangle) *image.Alpha {
+	img := image.NewAlpha(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.Set(x, y, color.Alpha{uint8(x + 3*y)})
+		}
+	}
+
+	return img
+}
+
+func mockCoordinateImageAlpha16(bounds image.Rectangle) *image.Alpha16 {
+	img := image.NewAlpha16(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.Set(x, y, color.Alpha{uint8(x + 3*y)})
+		}
+	}
+
+	return img
+}
+
+func mockCoordinateImageFloat(bounds image.Rectangle) *FloatImage {
+	img := NewFloatImage(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.Set(x, y, mockCoordinateColor(x, y))
+		}
+	}
+
+	return img
+}
+
+func assertInvertedCoordinateImage(t *testing.T, img image.Image, bounds image.Rectangle) {
+	assert.True(t, bounds.In(img.Bounds()))
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			c := mockCoordinateColor(x, y)
+			er, eg, eb, ea := color.RGBA{^c.R, ^c.G, ^c.B, c.A}.RGBA()
+			ar, ag, ab, aa := img.At(x, y).RGBA()
+
+			assert.Equal(t, []uint32{er, eg, eb, ea}, []uint32{ar, ag, ab, aa}, "unexpected color at x=%d y=%d", x, y)
+		}
+	}
+}
+
+func assertUntouchedCoordinateImage(t *testing.T, img image.Image, modified image.Rectangle) {
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			if image.Pt(x, y).In(modified) {
+				continue
+			}
+
+			er, eg, eb, ea := mockCoordinateColor(x, y).RGBA()
+			ar, ag, ab, aa := img.At(x, y).RGBA()
+
+			assert.Equal(t, []uint32{er, eg, eb, ea}, []uint32{ar, ag, ab, aa}, "unexpected modification at x=%d y=%d", x, y)
+		}
+	}
+}
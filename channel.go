@@ -0,0 +1,205 @@
+package pimit
+
+import "image"
+
+// The channel image is the common view of the images storing a single 8-bit or 16-bit (big-endian) channel per pixel,
+// such as the Gray, Gray16, Alpha and Alpha16 images. The iteration functions of these images differ only in the type
+// of the image, so the rows are processed by the shared functions operating on this view.
+type channelImage[T uint8 | uint16] struct {
+	pix    []uint8
+	stride int
+	rect   image.Rectangle
+}
+
+// Create a new view of the pixels of a single channel image.
+func newChannelImage[T uint8 | uint16](pix []uint8, stride int, rect image.Rectangle) *channelImage[T] {
+	return &channelImage[T]{
+		pix:    pix,
+		stride: stride,
+		rect:   rect,
+	}
+}
+
+// Return the number of bytes storing the channel of a single pixel. The functions operating on the channel values
+// depend only on the channel type, which is known at compile time for each instantiation, so the checks of the channel
+// size are resolved by the compiler and not for every pixel.
+func channelSize[T uint8 | uint16]() int {
+	if ^T(0) == 0xff {
+		return 1
+	}
+
+	return 2
+}
+
+// Return the index of the first byte of the pixel at the provided coordinates.
+func (c *channelImage[T]) offset(x, y int) int {
+	return (y-c.rect.Min.Y)*c.stride + (x-c.rect.Min.X)*channelSize[T]()
+}
+
+// Return the value of the channel of the pixel starting at the provided index.
+func (c *channelImage[T]) at(index int) T {
+	if channelSize[T]() == 1 {
+		return T(c.pix[index])
+	}
+
+	return T(uint16(c.pix[index+0])<<8 | uint16(c.pix[index+1]))
+}
+
+// Set the value of the channel of the pixel starting at the provided index.
+func (c *channelImage[T]) set(index int, v T) {
+	if channelSize[T]() == 1 {
+		c.pix[index] = uint8(v)
+		return
+	}
+
+	c.pix[index+0], c.pix[index+1] = uint8(uint16(v)>>8), uint8(v)
+}
+
+// Perform a parallel iteration of the pixels of the provided single channel image. For each pixel, execute the
+// delegate function allowing you to read the value of the channel and coordinates. The provided name of the operation
+// is attached to the reported panics.
+func parallelChannelRead[T uint8 | uint16](op string, src *channelImage[T], d func(x, y int, v T), opts []Option) {
+	o := newOptions(opts)
+	bounds, size := src.rect, channelSize[T]()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration(op, false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int = bounds.Min.Y + i
+			baseIndex int = src.offset(bounds.Min.X, yIndex)
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			d(xIndex-originX, yIndex-originY, src.at(baseIndex))
+			baseIndex += size
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided single channel image. For each pixel, execute the
+// delegate function allowing you to read the value of the channel and coordinates. The errors of the delegate function
+// are handled according to the error policy and returned. The provided name of the operation is attached to the
+// reported errors.
+func parallelChannelReadE[T uint8 | uint16](op string, src *channelImage[T], d func(x, y int, v T) error, opts []Option) error {
+	o := newOptions(opts)
+	bounds, size := src.rect, channelSize[T]()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration(op, true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int   = bounds.Min.Y + i
+			baseIndex int   = src.offset(bounds.Min.X, yIndex)
+			err       error = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			if err = d(xIndex-originX, yIndex-originY, src.at(baseIndex)); err != nil && it.fail(p, err) {
+				return
+			}
+
+			baseIndex += size
+		}
+	})
+
+	return it.err()
+}
+
+// Perform a parallel iteration of the pixels of the provided single channel image. For each pixel, execute the
+// delegate function allowing you to read the value of the channel and coordinates, the delegate return value will be
+// set at the given coordinates of the destination image, which can be the source image itself. The provided name of
+// the operation is attached to the reported panics.
+func parallelChannelReadWrite[T uint8 | uint16](op string, src, dst *channelImage[T], d func(x, y int, v T) T, opts []Option) {
+	o := newOptions(opts)
+	bounds, size := src.rect, channelSize[T]()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration(op, false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int = bounds.Min.Y + i
+			srcIndex int = src.offset(bounds.Min.X, yIndex)
+			dstIndex int = dst.offset(bounds.Min.X, yIndex)
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			dst.set(dstIndex, d(xIndex-originX, yIndex-originY, src.at(srcIndex)))
+
+			srcIndex += size
+			dstIndex += size
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided single channel image. For each pixel, execute the
+// delegate function allowing you to read the value of the channel and coordinates, the delegate return value will be
+// set at the given coordinates of the destination image, which can be the source image itself. The pixels for which
+// the delegate function fails are not modified. Using the WithAtomic option, the rows of the source image modified in
+// place are restored if the iteration fails. The provided name of the operation is attached to the reported errors.
+func parallelChannelReadWriteE[T uint8 | uint16](op string, src, dst *channelImage[T], d func(x, y int, v T) (T, error), opts []Option) error {
+	o := newOptions(opts)
+	bounds, size := src.rect, channelSize[T]()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration(op, true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int   = bounds.Min.Y + i
+			srcIndex int   = src.offset(bounds.Min.X, yIndex)
+			dstIndex int   = dst.offset(bounds.Min.X, yIndex)
+			v        T     = 0
+			err      error = nil
+		)
+
+		if o.atomic && dst == src {
+			rowIndex, saved := dstIndex, append([]uint8(nil), dst.pix[dstIndex:dstIndex+bounds.Dx()*size]...)
+			it.record(func() {
+				copy(dst.pix[rowIndex:], saved)
+			})
+		}
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			if v, err = d(xIndex-originX, yIndex-originY, src.at(srcIndex)); err == nil {
+				dst.set(dstIndex, v)
+			} else if it.fail(p, err) {
+				return
+			}
+
+			srcIndex += size
+			dstIndex += size
+		}
+	})
+
+	return it.commit()
+}
@@ -0,0 +1,440 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+)
+
+type (
+	CmykReadDelegate               = func(x, y int, cyan, magenta, yellow, black uint8)
+	CmykReadErrorableDelegate      = func(x, y int, cyan, magenta, yellow, black uint8) error
+	CmykReadWriteDelegate          = func(x, y int, cyan, magenta, yellow, black uint8) (uint8, uint8, uint8, uint8)
+	CmykReadWriteErrorableDelegate = func(x, y int, cyan, magenta, yellow, black uint8) (uint8, uint8, uint8, uint8, error)
+)
+
+// Perform a parallel iteration of the pixels of the provided CMYK image. For each pixel, execute the delegate function
+// allowing you to read the color (C, M, Y and K as uint8) and coordinates. The rows are split into chunks processed by
+// a bounded number of worker goroutines.
+func ParallelCmykRead(src *image.CMYK, d CmykReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelCmykRead", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex                       int   = bounds.Min.Y + i
+			baseIndex                    int   = src.PixOffset(bounds.Min.X, yIndex)
+			cyan, magenta, yellow, black uint8 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			cyan = src.Pix[baseIndex+0]
+			magenta = src.Pix[baseIndex+1]
+			yellow = src.Pix[baseIndex+2]
+			black = src.Pix[baseIndex+3]
+
+			d(xIndex-originX, yIndex-originY, cyan, magenta, yellow, black)
+			baseIndex += 4
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided CMYK image. For each pixel, execute the delegate function
+// allowing you to read the color (C, M, Y and K as uint8) and coordinates. The rows are split into chunks processed by
+// a bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned.
+func ParallelCmykReadE(src *image.CMYK, d CmykReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelCmykReadE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex                       int   = bounds.Min.Y + i
+			baseIndex                    int   = src.PixOffset(bounds.Min.X, yIndex)
+			cyan, magenta, yellow, black uint8 = 0, 0, 0, 0
+			err                          error = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			cyan = src.Pix[baseIndex+0]
+			magenta = src.Pix[baseIndex+1]
+			yellow = src.Pix[baseIndex+2]
+			black = src.Pix[baseIndex+3]
+
+			if err = d(xIndex-originX, yIndex-originY, cyan, magenta, yellow, black); err != nil && it.fail(p, err) {
+				return
+			}
+
+			baseIndex += 4
+		}
+	})
+
+	return it.err()
+}
+
+// Perform a parallel iteration of the pixels of the provided CMYK image. For each pixel, execute the delegate function
+// allowing you to read the color (C, M, Y and K as uint8) and coordinates, the delegate return color will be set at the
+// given coordinates. This changes will be applied to the passed image instance. Consider using ParallelReadWriteNew if
+// you want to avoid changes to the original image at the expense of additional allocations. The rows are split into
+// chunks processed by a bounded number of worker goroutines.
+func ParallelCmykReadWrite(src *image.CMYK, d CmykReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelCmykReadWrite", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex                       int   = bounds.Min.Y + i
+			baseIndex                    int   = src.PixOffset(bounds.Min.X, yIndex)
+			cyan, magenta, yellow, black uint8 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			cyan = src.Pix[baseIndex+0]
+			magenta = src.Pix[baseIndex+1]
+			yellow = src.Pix[baseIndex+2]
+			black = src.Pix[baseIndex+3]
+
+			cyan, magenta, yellow, black = d(xIndex-originX, yIndex-originY, cyan, magenta, yellow, black)
+
+			src.Pix[baseIndex+0] = cyan
+			src.Pix[baseIndex+1] = magenta
+			src.Pix[baseIndex+2] = yellow
+			src.Pix[baseIndex+3] = black
+
+			baseIndex += 4
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided CMYK image. For each pixel, execute the delegate function
+// allowing you to read the color (C, M, Y and K as uint8) and coordinates, the delegate return color will be set at the
+// given coordinates. This changes will be applied to the passed image instance. Consider using ParallelReadWriteNewE if
+// you want to avoid changes to the original image at the expense of additional allocations. The rows are split into
+// chunks processed by a bounded number of worker goroutines. The iteration will break after the first error occurs and
+// the error will be returned.
+func ParallelCmykReadWriteE(src *image.CMYK, d CmykReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelCmykReadWriteE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex                       int   = bounds.Min.Y + i
+			baseIndex                    int   = src.PixOffset(bounds.Min.X, yIndex)
+			cyan, magenta, yellow, black uint8 = 0, 0, 0, 0
+			err                          error = nil
+		)
+
+		if o.atomic {
+			rowIndex, saved := baseIndex, append([]uint8(nil), src.Pix[baseIndex:baseIndex+4*bounds.Dx()]...)
+			it.record(func() {
+				copy(src.Pix[rowIndex:], saved)
+			})
+		}
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			cyan = src.Pix[baseIndex+0]
+			magenta = src.Pix[baseIndex+1]
+			yellow = src.Pix[baseIndex+2]
+			black = src.Pix[baseIndex+3]
+
+			cyan, magenta, yellow, black, err = d(xIndex-originX, yIndex-originY, cyan, magenta, yellow, black)
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				baseIndex += 4
+				continue
+			}
+
+			src.Pix[baseIndex+0] = cyan
+			src.Pix[baseIndex+1] = magenta
+			src.Pix[baseIndex+2] = yellow
+			src.Pix[baseIndex+3] = black
+
+			baseIndex += 4
+		}
+	})
+
+	return it.commit()
+}
+
+// Perform a parallel iteration of the pixels of the provided CMYK image. For each pixel, execute the delegate function
+// allowing you to read the color (C, M, Y and K as uint8) and coordinates, the delegate return color will be set at the
+// given coordinates. This changes will be applied to a new image instance which internaly uses the CMYK color space
+// and is returned by the function. The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelCmykReadWriteNew(src *image.CMYK, d CmykReadWriteDelegate, opts ...Option) *image.CMYK {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewCMYK(bounds)
+
+	it := o.newIteration("ParallelCmykReadWriteNew", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex                       int   = bounds.Min.Y + i
+			srcIndex                     int   = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex                     int   = dst.PixOffset(bounds.Min.X, yIndex)
+			cyan, magenta, yellow, black uint8 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			cyan = src.Pix[srcIndex+0]
+			magenta = src.Pix[srcIndex+1]
+			yellow = src.Pix[srcIndex+2]
+			black = src.Pix[srcIndex+3]
+
+			cyan, magenta, yellow, black = d(xIndex-originX, yIndex-originY, cyan, magenta, yellow, black)
+
+			dst.Pix[dstIndex+0] = cyan
+			dst.Pix[dstIndex+1] = magenta
+			dst.Pix[dstIndex+2] = yellow
+			dst.Pix[dstIndex+3] = black
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel iteration of the pixels of the provided CMYK image. For each pixel, execute the delegate function
+// allowing you to read the color (C, M, Y and K as uint8) and coordinates, the delegate return color will be set at the
+// given coordinates. This changes will be applied to a new image instance which internaly uses the CMYK color space
+// and is returned by the function. The rows are split into chunks processed by a bounded number of worker goroutines.
+// The iteration will break after the first error occurs and the error will be returned.
+func ParallelCmykReadWriteNewE(src *image.CMYK, d CmykReadWriteErrorableDelegate, opts ...Option) (*image.CMYK, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewCMYK(bounds)
+
+	it := o.newIteration("ParallelCmykReadWriteNewE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex                       int   = bounds.Min.Y + i
+			srcIndex                     int   = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex                     int   = dst.PixOffset(bounds.Min.X, yIndex)
+			cyan, magenta, yellow, black uint8 = 0, 0, 0, 0
+			err                          error = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			cyan = src.Pix[srcIndex+0]
+			magenta = src.Pix[srcIndex+1]
+			yellow = src.Pix[srcIndex+2]
+			black = src.Pix[srcIndex+3]
+
+			cyan, magenta, yellow, black, err = d(xIndex-originX, yIndex-originY, cyan, magenta, yellow, black)
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				srcIndex += 4
+				dstIndex += 4
+				continue
+			}
+
+			dst.Pix[dstIndex+0] = cyan
+			dst.Pix[dstIndex+1] = magenta
+			dst.Pix[dstIndex+2] = yellow
+			dst.Pix[dstIndex+3] = black
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	if err := it.err(); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
+
+// Perform a parallel conversion of the provided CMYK image to a new RGBA image. The colors are converted in the same
+// way as by the color.CMYKToRGB function. The rows are split into chunks processed by a bounded number of worker
+// goroutines.
+func ParallelCmykToRgba(src *image.CMYK, opts ...Option) *image.RGBA {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewRGBA(bounds)
+
+	it := o.newIteration("ParallelCmykToRgba", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int = bounds.Min.Y + i
+			srcIndex int = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int = dst.PixOffset(bounds.Min.X, yIndex)
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			dst.Pix[dstIndex+0], dst.Pix[dstIndex+1], dst.Pix[dstIndex+2] = color.CMYKToRGB(
+				src.Pix[srcIndex+0],
+				src.Pix[srcIndex+1],
+				src.Pix[srcIndex+2],
+				src.Pix[srcIndex+3])
+
+			dst.Pix[dstIndex+3] = 0xff
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel conversion of the provided RGBA image to a new CMYK image. The colors are converted in the same
+// way as by the color.RGBToCMYK function, which means that the alpha channel is discarded and the premultiplied colors
+// are converted. The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelRgbaToCmyk(src *image.RGBA, opts ...Option) *image.CMYK {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewCMYK(bounds)
+
+	it := o.newIteration("ParallelRgbaToCmyk", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int = bounds.Min.Y + i
+			srcIndex int = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int = dst.PixOffset(bounds.Min.X, yIndex)
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			dst.Pix[dstIndex+0], dst.Pix[dstIndex+1], dst.Pix[dstIndex+2], dst.Pix[dstIndex+3] = color.RGBToCMYK(
+				src.Pix[srcIndex+0],
+				src.Pix[srcIndex+1],
+				src.Pix[srcIndex+2])
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
@@ -0,0 +1,581 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelCmykReadShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelCmykRead(nil, func(x, y int, c, m, ye, k uint8) {})
+	})
+}
+
+func TestParallelCmykReadShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	assert.Panics(t, func() {
+		ParallelCmykRead(img, nil)
+	})
+}
+
+func TestParallelCmykReadShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	exC, exM, exY, exK := uint8(0), uint8(0), uint8(0), uint8(0)
+
+	ParallelCmykRead(img, func(xIndex int, yIndex int, acC, acM, acY, acK uint8) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exC, acC)
+		assert.Equal(t, exM, acM)
+		assert.Equal(t, exY, acY)
+		assert.Equal(t, exK, acK)
+	})
+}
+
+func TestParallelCmykReadEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelCmykReadE(nil, func(x, y int, c, m, ye, k uint8) error {
+			return nil
+		})
+	})
+}
+
+func TestParallelCmykReadEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	assert.Panics(t, func() {
+		ParallelCmykReadE(img, nil)
+	})
+}
+
+func TestParallelCmykReadEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	err := ParallelCmykReadE(img, func(x, y int, c, m, ye, k uint8) error {
+		return errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelCmykReadEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	exC, exM, exY, exK := uint8(0), uint8(0), uint8(0), uint8(0)
+
+	err := ParallelCmykReadE(img, func(xIndex, yIndex int, acC, acM, acY, acK uint8) error {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exC, acC)
+		assert.Equal(t, exM, acM)
+		assert.Equal(t, exY, acY)
+		assert.Equal(t, exK, acK)
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestParallelCmykReadWriteShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelCmykReadWrite(nil, func(x int, y int, c uint8, m uint8, ye uint8, k uint8) (uint8, uint8, uint8, uint8) {
+			return c, m, ye, k
+		})
+	})
+}
+
+func TestParallelCmykReadWriteShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	assert.Panics(t, func() {
+		ParallelCmykReadWrite(img, nil)
+	})
+}
+
+func TestParallelCmykReadWriteShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	exC, exM, exY, exK := uint8(0), uint8(0), uint8(0), uint8(0)
+
+	ParallelCmykReadWrite(img, func(xIndex, yIndex int, acC, acM, acY, acK uint8) (uint8, uint8, uint8, uint8) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exC, acC)
+		assert.Equal(t, exM, acM)
+		assert.Equal(t, exY, acY)
+		assert.Equal(t, exK, acK)
+
+		return 0, 0, 0, 255
+	})
+
+	expectedImage := mockBlackImageCmyk()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.CMYKAt(x, y), img.CMYKAt(x, y))
+		}
+	}
+}
+
+func TestParallelCmykReadWriteShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageCmyk()
+
+	cBlack, mBlack, yBlack, kBlack := uint8(0), uint8(0), uint8(0), uint8(255)
+	cWhite, mWhite, yWhite, kWhite := uint8(0), uint8(0), uint8(0), uint8(0)
+
+	ParallelCmykReadWrite(image, func(x, y int, cCurrent, mCurrent, yCurrent, kCurrent uint8) (uint8, uint8, uint8, uint8) {
+		if cCurrent == cBlack && mCurrent == mBlack && yCurrent == yBlack && kCurrent == kBlack {
+			return 0, 0, 0, 0
+		}
+
+		if cCurrent == cWhite && mCurrent == mWhite && yCurrent == yWhite && kCurrent == kWhite {
+			return 0, 0, 0, 255
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return cCurrent, mCurrent, yCurrent, kCurrent
+	})
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := image.CMYKAt(x, y)
+
+			assert.Equal(t, cBlack, c.C)
+			assert.Equal(t, mBlack, c.M)
+			assert.Equal(t, yBlack, c.Y)
+			assert.Equal(t, kBlack, c.K)
+		}
+	}
+}
+
+func TestParallelCmykReadWriteEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelCmykReadWriteE(nil, func(x, y int, c, m, ye, k uint8) (uint8, uint8, uint8, uint8, error) {
+			return c, m, ye, k, nil
+		})
+	})
+}
+
+func TestParallelCmykReadWriteEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	assert.Panics(t, func() {
+		ParallelCmykReadWriteE(img, nil)
+	})
+}
+
+func TestParallelCmykReadWriteEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	err := ParallelCmykReadWriteE(img, func(x, y int, c, m, ye, k uint8) (uint8, uint8, uint8, uint8, error) {
+		return c, m, ye, k, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelCmykReadWriteEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	exC, exM, exY, exK := uint8(0), uint8(0), uint8(0), uint8(0)
+
+	ParallelCmykReadWriteE(img, func(xIndex, yIndex int, acC, acM, acY, acK uint8) (uint8, uint8, uint8, uint8, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exC, acC)
+		assert.Equal(t, exM, acM)
+		assert.Equal(t, exY, acY)
+		assert.Equal(t, exK, acK)
+
+		return 0, 0, 0, 255, nil
+	})
+
+	expectedImage := mockBlackImageCmyk()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.CMYKAt(x, y), img.CMYKAt(x, y))
+		}
+	}
+}
+
+func TestParallelCmykReadWriteEShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageCmyk()
+
+	cBlack, mBlack, yBlack, kBlack := uint8(0), uint8(0), uint8(0), uint8(255)
+	cWhite, mWhite, yWhite, kWhite := uint8(0), uint8(0), uint8(0), uint8(0)
+
+	err := ParallelCmykReadWriteE(image, func(xIndex, yIndex int, cCurrent, mCurrent, yCurrent, kCurrent uint8) (uint8, uint8, uint8, uint8, error) {
+		if cCurrent == cBlack && mCurrent == mBlack && yCurrent == yBlack && kCurrent == kBlack {
+			return 0, 0, 0, 0, nil
+		}
+
+		if cCurrent == cWhite && mCurrent == mWhite && yCurrent == yWhite && kCurrent == kWhite {
+			return 0, 0, 0, 255, nil
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return cCurrent, mCurrent, yCurrent, kCurrent, nil
+
+	})
+
+	assert.Nil(t, err)
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := image.CMYKAt(x, y)
+
+			assert.Equal(t, cBlack, c.C)
+			assert.Equal(t, mBlack, c.M)
+			assert.Equal(t, yBlack, c.Y)
+			assert.Equal(t, kBlack, c.K)
+		}
+	}
+}
+
+func TestParallelCmykReadWriteNewShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelCmykReadWriteNew(nil, func(x int, y int, c uint8, m uint8, ye uint8, k uint8) (uint8, uint8, uint8, uint8) {
+			return c, m, ye, k
+		})
+	})
+}
+
+func TestParallelCmykReadWriteNewShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	assert.Panics(t, func() {
+		ParallelCmykReadWriteNew(img, nil)
+	})
+}
+
+func TestParallelCmykReadWriteNewShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	exC, exM, exY, exK := uint8(0), uint8(0), uint8(0), uint8(0)
+
+	actualImage := ParallelCmykReadWriteNew(img, func(xIndex, yIndex int, acC, acM, acY, acK uint8) (uint8, uint8, uint8, uint8) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exC, acC)
+		assert.Equal(t, exM, acM)
+		assert.Equal(t, exY, acY)
+		assert.Equal(t, exK, acK)
+
+		return 0, 0, 0, 255
+	})
+
+	expectedImage := mockBlackImageCmyk()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.CMYKAt(x, y), actualImage.CMYKAt(x, y))
+		}
+	}
+}
+
+func TestParallelCmykReadWriteNewShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageCmyk()
+
+	cBlack, mBlack, yBlack, kBlack := uint8(0), uint8(0), uint8(0), uint8(255)
+	cWhite, mWhite, yWhite, kWhite := uint8(0), uint8(0), uint8(0), uint8(0)
+
+	actualImage := ParallelCmykReadWriteNew(image, func(xIndex, yIndex int, cCurrent, mCurrent, yCurrent, kCurrent uint8) (uint8, uint8, uint8, uint8) {
+		if cCurrent == cBlack && mCurrent == mBlack && yCurrent == yBlack && kCurrent == kBlack {
+			return 0, 0, 0, 0
+		}
+
+		if cCurrent == cWhite && mCurrent == mWhite && yCurrent == yWhite && kCurrent == kWhite {
+			return 0, 0, 0, 255
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return cCurrent, mCurrent, yCurrent, kCurrent
+	})
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := actualImage.CMYKAt(x, y)
+
+			assert.Equal(t, cBlack, c.C)
+			assert.Equal(t, mBlack, c.M)
+			assert.Equal(t, yBlack, c.Y)
+			assert.Equal(t, kBlack, c.K)
+		}
+	}
+}
+
+func TestParallelCmykReadWriteNewEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelCmykReadWriteNewE(nil, func(x, y int, c, m, ye, k uint8) (uint8, uint8, uint8, uint8, error) {
+			return c, m, ye, k, nil
+		})
+	})
+}
+
+func TestParallelCmykReadWriteNewEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	assert.Panics(t, func() {
+		ParallelCmykReadWriteNewE(img, nil)
+	})
+}
+
+func TestParallelCmykReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	modifiedImg, err := ParallelCmykReadWriteNewE(img, func(x, y int, c, m, ye, k uint8) (uint8, uint8, uint8, uint8, error) {
+		return c, m, ye, k, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, modifiedImg)
+}
+
+func TestParallelCmykReadWriteNewEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageCmyk()
+
+	exC, exM, exY, exK := uint8(0), uint8(0), uint8(0), uint8(0)
+
+	actualImage, err := ParallelCmykReadWriteNewE(img, func(xIndex, yIndex int, acC, acM, acY, acK uint8) (uint8, uint8, uint8, uint8, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exC, acC)
+		assert.Equal(t, exM, acM)
+		assert.Equal(t, exY, acY)
+		assert.Equal(t, exK, acK)
+
+		return 0, 0, 0, 255, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockBlackImageCmyk()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.CMYKAt(x, y), actualImage.CMYKAt(x, y))
+		}
+	}
+}
+
+func TestParallelCmykReadWriteENewShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageCmyk()
+
+	cBlack, mBlack, yBlack, kBlack := uint8(0), uint8(0), uint8(0), uint8(255)
+	cWhite, mWhite, yWhite, kWhite := uint8(0), uint8(0), uint8(0), uint8(0)
+
+	actualImage, err := ParallelCmykReadWriteNewE(image, func(xIndex, yIndex int, cCurrent, mCurrent, yCurrent, kCurrent uint8) (uint8, uint8, uint8, uint8, error) {
+		if cCurrent == cBlack && mCurrent == mBlack && yCurrent == yBlack && kCurrent == kBlack {
+			return 0, 0, 0, 0, nil
+		}
+
+		if cCurrent == cWhite && mCurrent == mWhite && yCurrent == yWhite && kCurrent == kWhite {
+			return 0, 0, 0, 255, nil
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return cCurrent, mCurrent, yCurrent, kCurrent, nil
+	})
+
+	assert.Nil(t, err)
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := actualImage.CMYKAt(x, y)
+
+			assert.Equal(t, cBlack, c.C)
+			assert.Equal(t, mBlack, c.M)
+			assert.Equal(t, yBlack, c.Y)
+			assert.Equal(t, kBlack, c.K)
+		}
+	}
+}
+
+func TestParallelCmykReadWriteEShouldLeaveImageUnchangedOnFailureWhenAtomic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockCoordinateImageCmyk(image.Rect(-2, 3, 14, 17))
+
+	err := ParallelCmykReadWriteE(img, func(x, y int, c, m, ye, k uint8) (uint8, uint8, uint8, uint8, error) {
+		if x == 6 && y == 9 {
+			return c, m, ye, k, errors.New("pimit-test: test errror")
+		}
+
+		return ^c, ^m, ^ye, ^k, nil
+	}, WithAtomic(), WithWorkers(4), WithChunkSize(1))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, mockCoordinateImageCmyk(image.Rect(-2, 3, 14, 17)), img)
+}
+
+func TestParallelCmykConversionsShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelCmykToRgba(nil)
+	})
+
+	assert.Panics(t, func() {
+		ParallelRgbaToCmyk(nil)
+	})
+}
+
+func TestParallelCmykConversionsShouldMatchColorModels(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-4, 7, 29, 33)
+	random := rand.New(rand.NewSource(2024))
+
+	cmyk := image.NewCMYK(bounds)
+	random.Read(cmyk.Pix)
+
+	rgba := image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			rgba.Set(x, y, color.NRGBA{uint8(random.Intn(256)), uint8(random.Intn(256)), uint8(random.Intn(256)), uint8(random.Intn(256))})
+		}
+	}
+
+	for _, workers := range []int{1, 4} {
+		actualRgba := ParallelCmykToRgba(cmyk, WithWorkers(workers))
+		actualCmyk := ParallelRgbaToCmyk(rgba, WithWorkers(workers))
+
+		assert.Equal(t, bounds, actualRgba.Bounds())
+		assert.Equal(t, bounds, actualCmyk.Bounds())
+
+		for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+			for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+				assert.Equal(t, color.RGBAModel.Convert(cmyk.At(x, y)), actualRgba.RGBAAt(x, y))
+				assert.Equal(t, color.CMYKModel.Convert(rgba.At(x, y)), actualCmyk.CMYKAt(x, y))
+			}
+		}
+	}
+}
+
+func mockCoordinateImageCmyk(bounds image.Rectangle) *image.CMYK {
+	img := image.NewCMYK(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.SetCMYK(x, y, color.CMYK{uint8(x), uint8(y), uint8(x + y), uint8(x * y)})
+		}
+	}
+
+	return img
+}
+
+func mockWhiteImageCmyk() *image.CMYK {
+	width, height := 5, 6
+
+	img := image.NewCMYK(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	return img
+}
+
+func mockBlackImageCmyk() *image.CMYK {
+	width, height := 5, 6
+
+	img := image.NewCMYK(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Black)
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,554 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// ColorSpace selects the color space of the components passed to the delegate functions of the ParallelColorSpace
+// functions. The components are calculated from the non-premultiplied sRGB channels of the pixels, the alpha is passed
+// to the delegate functions as a value in range [0, 1] and is not affected by the conversion. The components returned
+// by the delegate functions, which are out of the sRGB gamut, are clamped to the nearest representable color.
+type ColorSpace int
+
+const (
+	// The hue (H in degrees in range [0, 360)), saturation (S in range [0, 1]) and value (V in range [0, 1]) of the
+	// sRGB encoded channels. The hue returned by the delegate functions is wrapped around, so it can be shifted freely.
+	ColorSpaceHsv ColorSpace = iota
+	// The hue (H in degrees in range [0, 360)), saturation (S in range [0, 1]) and lightness (L in range [0, 1]) of
+	// the sRGB encoded channels. The hue returned by the delegate functions is wrapped around, so it can be shifted
+	// freely.
+	ColorSpaceHsl
+	// The CIE L*a*b* components (L in range [0, 100], a and b approximately in range [-128, 128]) relative to the D65
+	// white point of the sRGB color space. The channels are linearized before the conversion.
+	ColorSpaceLab
+	// The OKLab components (L in range [0, 1], a and b approximately in range [-0.5, 0.5]). The channels are
+	// linearized before the conversion.
+	ColorSpaceOklab
+)
+
+type (
+	ColorSpaceReadDelegate               = func(x, y int, c1, c2, c3, a float32)
+	ColorSpaceReadErrorableDelegate      = func(x, y int, c1, c2, c3, a float32) error
+	ColorSpaceReadWriteDelegate          = func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32)
+	ColorSpaceReadWriteErrorableDelegate = func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32, error)
+)
+
+// Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
+// allowing you to read the components of the color in the provided color space, the alpha (in range [0, 1]) and the
+// coordinates. The rows are split into chunks processed by a bounded number of worker goroutines. The *image.RGBA and
+// *image.NRGBA images are accessed directly, without the image.At calls.
+func ParallelColorSpaceRead(src image.Image, s ColorSpace, d ColorSpaceReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	s.validate()
+	opts = appendOptions(opts, withOperation("ParallelColorSpaceRead"))
+
+	switch img := src.(type) {
+	case *image.RGBA:
+		ParallelRgbaRead(img, func(x, y int, r, g, b, a uint8) {
+			c1, c2, c3, ca := s.fromRgba(r, g, b, a)
+			d(x, y, c1, c2, c3, ca)
+		}, opts...)
+	case *image.NRGBA:
+		ParallelNrgbaRead(img, func(x, y int, r, g, b, a uint8) {
+			c1, c2, c3, ca := s.fromNrgba(r, g, b, a)
+			d(x, y, c1, c2, c3, ca)
+		}, opts...)
+	default:
+		ParallelRead(src, func(x, y int, c color.Color) {
+			c1, c2, c3, ca := s.fromColor(c)
+			d(x, y, c1, c2, c3, ca)
+		}, opts...)
+	}
+}
+
+// Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
+// allowing you to read the components of the color in the provided color space, the alpha (in range [0, 1]) and the
+// coordinates. The rows are split into chunks processed by a bounded number of worker goroutines. The iteration will
+// break after the first error occurs and the error will be returned. The *image.RGBA and *image.NRGBA images are
+// accessed directly, without the image.At calls.
+func ParallelColorSpaceReadE(src image.Image, s ColorSpace, d ColorSpaceReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	s.validate()
+	opts = appendOptions(opts, withOperation("ParallelColorSpaceReadE"))
+
+	switch img := src.(type) {
+	case *image.RGBA:
+		return ParallelRgbaReadE(img, func(x, y int, r, g, b, a uint8) error {
+			c1, c2, c3, ca := s.fromRgba(r, g, b, a)
+			return d(x, y, c1, c2, c3, ca)
+		}, opts...)
+	case *image.NRGBA:
+		return ParallelNrgbaReadE(img, func(x, y int, r, g, b, a uint8) error {
+			c1, c2, c3, ca := s.fromNrgba(r, g, b, a)
+			return d(x, y, c1, c2, c3, ca)
+		}, opts...)
+	default:
+		return ParallelReadE(src, func(x, y int, c color.Color) error {
+			c1, c2, c3, ca := s.fromColor(c)
+			return d(x, y, c1, c2, c3, ca)
+		}, opts...)
+	}
+}
+
+// Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
+// allowing you to read the components of the color in the provided color space, the alpha (in range [0, 1]) and the
+// coordinates, the delegate return components and alpha will be converted back and set at the given coordinates. This
+// changes will be applied to the passed image instance. Consider using ParallelColorSpaceReadWriteNew if you want to
+// avoid changes to the original image at the expense of additional allocations. The rows are split into chunks
+// processed by a bounded number of worker goroutines. The *image.RGBA and *image.NRGBA images are accessed directly,
+// without the image.At and draw.Image.Set calls.
+func ParallelColorSpaceReadWrite(src draw.Image, s ColorSpace, d ColorSpaceReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	s.validate()
+	opts = appendOptions(opts, withOperation("ParallelColorSpaceReadWrite"))
+
+	switch img := src.(type) {
+	case *image.RGBA:
+		ParallelRgbaReadWrite(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+			c1, c2, c3, ca := s.fromRgba(r, g, b, a)
+			return s.toRgba(d(x, y, c1, c2, c3, ca))
+		}, opts...)
+	case *image.NRGBA:
+		ParallelNrgbaReadWrite(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+			c1, c2, c3, ca := s.fromNrgba(r, g, b, a)
+			return s.toNrgba(d(x, y, c1, c2, c3, ca))
+		}, opts...)
+	default:
+		ParallelReadWrite(src, func(x, y int, c color.Color) color.Color {
+			c1, c2, c3, ca := s.fromColor(c)
+			c1, c2, c3, ca = d(x, y, c1, c2, c3, ca)
+			return s.toColor(c, c1, c2, c3, ca)
+		}, opts...)
+	}
+}
+
+// Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
+// allowing you to read the components of the color in the provided color space, the alpha (in range [0, 1]) and the
+// coordinates, the delegate return components and alpha will be converted back and set at the given coordinates. This
+// changes will be applied to the passed image instance. Consider using ParallelColorSpaceReadWriteNewE if you want to
+// avoid changes to the original image at the expense of additional allocations. The rows are split into chunks
+// processed by a bounded number of worker goroutines. The iteration will break after the first error occurs and the
+// error will be returned. The pixels for which the delegate returns an error are left unchanged. The *image.RGBA and
+// *image.NRGBA images are accessed directly, without the image.At and draw.Image.Set calls.
+func ParallelColorSpaceReadWriteE(src draw.Image, s ColorSpace, d ColorSpaceReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	s.validate()
+	opts = appendOptions(opts, withOperation("ParallelColorSpaceReadWriteE"))
+
+	switch img := src.(type) {
+	case *image.RGBA:
+		return ParallelRgbaReadWriteE(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+			c1, c2, c3, ca := s.fromRgba(r, g, b, a)
+			c1, c2, c3, ca, err := d(x, y, c1, c2, c3, ca)
+			if err != nil {
+				return r, g, b, a, err
+			}
+
+			r, g, b, a = s.toRgba(c1, c2, c3, ca)
+			return r, g, b, a, nil
+		}, opts...)
+	case *image.NRGBA:
+		return ParallelNrgbaReadWriteE(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+			c1, c2, c3, ca := s.fromNrgba(r, g, b, a)
+			c1, c2, c3, ca, err := d(x, y, c1, c2, c3, ca)
+			if err != nil {
+				return r, g, b, a, err
+			}
+
+			r, g, b, a = s.toNrgba(c1, c2, c3, ca)
+			return r, g, b, a, nil
+		}, opts...)
+	default:
+		return ParallelReadWriteE(src, func(x, y int, c color.Color) (color.Color, error) {
+			c1, c2, c3, ca := s.fromColor(c)
+			c1, c2, c3, ca, err := d(x, y, c1, c2, c3, ca)
+			if err != nil {
+				return c, err
+			}
+
+			return s.toColor(c, c1, c2, c3, ca), nil
+		}, opts...)
+	}
+}
+
+// Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
+// allowing you to read the components of the color in the provided color space, the alpha (in range [0, 1]) and the
+// coordinates, the delegate return components and alpha will be converted back and set at the given coordinates. The
+// changes will be applied to a new image instance which is returned by the function. The destination image is selected
+// and modified in the same way as by the ParallelReadWriteNew function. The rows are split into chunks processed by a
+// bounded number of worker goroutines. The *image.RGBA and *image.NRGBA source images are read directly, without the
+// image.At calls.
+func ParallelColorSpaceReadWriteNew(src image.Image, s ColorSpace, d ColorSpaceReadWriteDelegate, opts ...Option) draw.Image {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	s.validate()
+
+	return ParallelReadWriteNew(src, func(x, y int, c color.Color) color.Color {
+		c1, c2, c3, ca := s.fromColor(c)
+		c1, c2, c3, ca = d(x, y, c1, c2, c3, ca)
+		return s.toColor(c, c1, c2, c3, ca)
+	}, appendOptions(opts, withOperation("ParallelColorSpaceReadWriteNew"))...)
+}
+
+// Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
+// allowing you to read the components of the color in the provided color space, the alpha (in range [0, 1]) and the
+// coordinates, the delegate return components and alpha will be converted back and set at the given coordinates. The
+// changes will be applied to a new image instance which is returned by the function. The destination image is selected
+// and modified in the same way as by the ParallelReadWriteNewE function. The rows are split into chunks processed by a
+// bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned. The *image.RGBA and *image.NRGBA source images are read directly, without the image.At calls.
+func ParallelColorSpaceReadWriteNewE(src image.Image, s ColorSpace, d ColorSpaceReadWriteErrorableDelegate, opts ...Option) (draw.Image, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	s.validate()
+
+	return ParallelReadWriteNewE(src, func(x, y int, c color.Color) (color.Color, error) {
+		c1, c2, c3, ca := s.fromColor(c)
+		c1, c2, c3, ca, err := d(x, y, c1, c2, c3, ca)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.toColor(c, c1, c2, c3, ca), nil
+	}, appendOptions(opts, withOperation("ParallelColorSpaceReadWriteNewE"))...)
+}
+
+func (s ColorSpace) validate() {
+	if s < ColorSpaceHsv || s > ColorSpaceOklab {
+		panic("pimit: the provided color space is invalid")
+	}
+}
+
+// Convert the alpha-premultiplied 8-bit channels to the components of the color space and the alpha in range [0, 1].
+func (s ColorSpace) fromRgba(r, g, b, a uint8) (float32, float32, float32, float32) {
+	if a == 0 {
+		c1, c2, c3 := s.fromRgb(0, 0, 0)
+		return c1, c2, c3, 0
+	}
+
+	alpha := float32(a)
+	c1, c2, c3 := s.fromRgb(float32(r)/alpha, float32(g)/alpha, float32(b)/alpha)
+	return c1, c2, c3, alpha / 0xff
+}
+
+// Convert the non-premultiplied 8-bit channels to the components of the color space and the alpha in range [0, 1].
+func (s ColorSpace) fromNrgba(r, g, b, a uint8) (float32, float32, float32, float32) {
+	c1, c2, c3 := s.fromRgb(float32(r)/0xff, float32(g)/0xff, float32(b)/0xff)
+	return c1, c2, c3, float32(a) / 0xff
+}
+
+// Convert the provided color to the components of the color space and the alpha in range [0, 1]. The 8-bit colors are
+// converted in the same way as by the fast paths, the remaining colors are converted using the 16-bit channels.
+func (s ColorSpace) fromColor(c color.Color) (float32, float32, float32, float32) {
+	switch c := c.(type) {
+	case color.RGBA:
+		return s.fromRgba(c.R, c.G, c.B, c.A)
+	case color.NRGBA:
+		return s.fromNrgba(c.R, c.G, c.B, c.A)
+	case color.NRGBA64:
+		c1, c2, c3 := s.fromRgb(float32(c.R)/0xffff, float32(c.G)/0xffff, float32(c.B)/0xffff)
+		return c1, c2, c3, float32(c.A) / 0xffff
+	}
+
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		c1, c2, c3 := s.fromRgb(0, 0, 0)
+		return c1, c2, c3, 0
+	}
+
+	alpha := float32(a)
+	c1, c2, c3 := s.fromRgb(float32(r)/alpha, float32(g)/alpha, float32(b)/alpha)
+	return c1, c2, c3, alpha / 0xffff
+}
+
+// Convert the components of the color space and the alpha to the alpha-premultiplied 8-bit channels.
+func (s ColorSpace) toRgba(c1, c2, c3, a float32) (uint8, uint8, uint8, uint8) {
+	r, g, b := s.toRgb(c1, c2, c3)
+	a = clampFloat(a) * 0xff
+
+	return uint8(r*a + 0.5), uint8(g*a + 0.5), uint8(b*a + 0.5), uint8(a + 0.5)
+}
+
+// Convert the components of the color space and the alpha to the non-premultiplied 8-bit channels.
+func (s ColorSpace) toNrgba(c1, c2, c3, a float32) (uint8, uint8, uint8, uint8) {
+	r, g, b := s.toRgb(c1, c2, c3)
+
+	return uint8(r*0xff + 0.5), uint8(g*0xff + 0.5), uint8(b*0xff + 0.5), uint8(clampFloat(a)*0xff + 0.5)
+}
+
+// Convert the components of the color space and the alpha to a non-premultiplied color with the precision of the
+// provided source color, which is color.NRGBA for the 8-bit colors and color.NRGBA64 for the remaining colors.
+func (s ColorSpace) toColor(src color.Color, c1, c2, c3, a float32) color.Color {
+	switch src.(type) {
+	case color.RGBA, color.NRGBA:
+		r, g, b, alpha := s.toNrgba(c1, c2, c3, a)
+		return color.NRGBA{r, g, b, alpha}
+	}
+
+	r, g, b := s.toRgb(c1, c2, c3)
+
+	return color.NRGBA64{
+		R: uint16(r*0xffff + 0.5),
+		G: uint16(g*0xffff + 0.5),
+		B: uint16(b*0xffff + 0.5),
+		A: uint16(clampFloat(a)*0xffff + 0.5),
+	}
+}
+
+// Convert the non-premultiplied sRGB encoded channels in range [0, 1] to the components of the color space.
+func (s ColorSpace) fromRgb(r, g, b float32) (float32, float32, float32) {
+	switch s {
+	case ColorSpaceHsv:
+		return rgbToHsv(r, g, b)
+	case ColorSpaceHsl:
+		return rgbToHsl(r, g, b)
+	case ColorSpaceLab:
+		return linearRgbToLab(srgbToLinear(r), srgbToLinear(g), srgbToLinear(b))
+	default:
+		return linearRgbToOklab(srgbToLinear(r), srgbToLinear(g), srgbToLinear(b))
+	}
+}
+
+// Convert the components of the color space to the non-premultiplied sRGB encoded channels clamped to range [0, 1].
+func (s ColorSpace) toRgb(c1, c2, c3 float32) (float32, float32, float32) {
+	var r, g, b float32
+
+	switch s {
+	case ColorSpaceHsv:
+		r, g, b = hsvToRgb(c1, c2, c3)
+	case ColorSpaceHsl:
+		r, g, b = hslToRgb(c1, c2, c3)
+	case ColorSpaceLab:
+		r, g, b = labToLinearRgb(c1, c2, c3)
+		return encodeChannel(r, true), encodeChannel(g, true), encodeChannel(b, true)
+	default:
+		r, g, b = oklabToLinearRgb(c1, c2, c3)
+		return encodeChannel(r, true), encodeChannel(g, true), encodeChannel(b, true)
+	}
+
+	return clampFloat(r), clampFloat(g), clampFloat(b)
+}
+
+func rgbToHsv(r, g, b float32) (float32, float32, float32) {
+	hi := float32(math.Max(float64(r), math.Max(float64(g), float64(b))))
+	lo := float32(math.Min(float64(r), math.Min(float64(g), float64(b))))
+
+	s := float32(0)
+	if hi > 0 {
+		s = (hi - lo) / hi
+	}
+
+	return rgbHue(r, g, b, hi, hi-lo), s, hi
+}
+
+func hsvToRgb(h, s, v float32) (float32, float32, float32) {
+	s, v = clampFloat(s), clampFloat(v)
+
+	c := v * s
+	r, g, b := hueToRgb(h, c)
+	m := v - c
+
+	return r + m, g + m, b + m
+}
+
+func rgbToHsl(r, g, b float32) (float32, float32, float32) {
+	hi := float32(math.Max(float64(r), math.Max(float64(g), float64(b))))
+	lo := float32(math.Min(float64(r), math.Min(float64(g), float64(b))))
+
+	l := (hi + lo) / 2
+	s := float32(0)
+	if hi > lo {
+		s = clampFloat((hi - lo) / (1 - float32(math.Abs(float64(2*l-1)))))
+	}
+
+	return rgbHue(r, g, b, hi, hi-lo), s, l
+}
+
+func hslToRgb(h, s, l float32) (float32, float32, float32) {
+	s, l = clampFloat(s), clampFloat(l)
+
+	c := (1 - float32(math.Abs(float64(2*l-1)))) * s
+	r, g, b := hueToRgb(h, c)
+	m := l - c/2
+
+	return r + m, g + m, b + m
+}
+
+// Return the hue in degrees in range [0, 360) of the color with the provided channels, the largest channel and the
+// chroma. The hue of the achromatic colors is zero.
+func rgbHue(r, g, b, hi, chroma float32) float32 {
+	if chroma == 0 {
+		return 0
+	}
+
+	var h float32
+	switch hi {
+	case r:
+		h = (g - b) / chroma
+		if h < 0 {
+			h += 6
+		}
+	case g:
+		h = (b-r)/chroma + 2
+	default:
+		h = (r-g)/chroma + 4
+	}
+
+	if h *= 60; h >= 360 {
+		return 0
+	}
+
+	return h
+}
+
+// Return the channels of the color with the provided hue in degrees and chroma, which has the smallest channel equal
+// to zero. The hue is wrapped around to range [0, 360) and the NaN and infinite hues are mapped to zero.
+func hueToRgb(h, c float32) (float32, float32, float32) {
+	if h = float32(math.Mod(float64(h), 360)); h < 0 {
+		h += 360
+	}
+
+	if !(h >= 0 && h < 360) {
+		h = 0
+	}
+
+	h /= 60
+	x := c * (1 - float32(math.Abs(math.Mod(float64(h), 2)-1)))
+
+	switch int(h) {
+	case 0:
+		return c, x, 0
+	case 1:
+		return x, c, 0
+	case 2:
+		return 0, c, x
+	case 3:
+		return 0, x, c
+	case 4:
+		return x, 0, c
+	default:
+		return c, 0, x
+	}
+}
+
+const (
+	// The X and Z coordinates of the D65 white point with the Y coordinate normalized to one.
+	labWhiteX = 0.95047
+	labWhiteZ = 1.08883
+
+	labEpsilon = 216.0 / 24389.0
+	labKappa   = 24389.0 / 27.0
+)
+
+func linearRgbToLab(r, g, b float32) (float32, float32, float32) {
+	rf, gf, bf := float64(r), float64(g), float64(b)
+
+	x := (0.4124564*rf + 0.3575761*gf + 0.1804375*bf) / labWhiteX
+	y := 0.2126729*rf + 0.7151522*gf + 0.0721750*bf
+	z := (0.0193339*rf + 0.1191920*gf + 0.9503041*bf) / labWhiteZ
+
+	fx, fy, fz := labCompand(x), labCompand(y), labCompand(z)
+
+	return float32(116*fy - 16), float32(500 * (fx - fy)), float32(200 * (fy - fz))
+}
+
+func labToLinearRgb(l, a, b float32) (float32, float32, float32) {
+	fy := (float64(l) + 16) / 116
+	fx := fy + float64(a)/500
+	fz := fy - float64(b)/200
+
+	x := labExpand(fx) * labWhiteX
+	y := labExpand(fy)
+	z := labExpand(fz) * labWhiteZ
+
+	return float32(3.2404542*x - 1.5371385*y - 0.4985314*z),
+		float32(-0.9692660*x + 1.8760108*y + 0.0415560*z),
+		float32(0.0556434*x - 0.2040259*y + 1.0572252*z)
+}
+
+// Apply the nonlinear compression of the CIE L*a*b* color space to the relative XYZ coordinate.
+func labCompand(t float64) float64 {
+	if t > labEpsilon {
+		return math.Cbrt(t)
+	}
+
+	return (labKappa*t + 16) / 116
+}
+
+// Reverse the nonlinear compression of the CIE L*a*b* color space.
+func labExpand(f float64) float64 {
+	if t := f * f * f; t > labEpsilon {
+		return t
+	}
+
+	return (116*f - 16) / labKappa
+}
+
+func linearRgbToOklab(r, g, b float32) (float32, float32, float32) {
+	rf, gf, bf := float64(r), float64(g), float64(b)
+
+	l := math.Cbrt(0.4122214708*rf + 0.5363325363*gf + 0.0514459929*bf)
+	m := math.Cbrt(0.2119034982*rf + 0.6806995451*gf + 0.1073969566*bf)
+	s := math.Cbrt(0.0883024619*rf + 0.2817188376*gf + 0.6299787005*bf)
+
+	return float32(0.2104542553*l + 0.7936177850*m - 0.0040720468*s),
+		float32(1.9779984951*l - 2.4285922050*m + 0.4505937099*s),
+		float32(0.0259040371*l + 0.7827717662*m - 0.8086757660*s)
+}
+
+func oklabToLinearRgb(lightness, a, b float32) (float32, float32, float32) {
+	lf, af, bf := float64(lightness), float64(a), float64(b)
+
+	l := lf + 0.3963377774*af + 0.2158037573*bf
+	m := lf - 0.1055613458*af - 0.0638541728*bf
+	s := lf - 0.0894841775*af - 1.2914855480*bf
+
+	l, m, s = l*l*l, m*m*m, s*s*s
+
+	return float32(4.0767416621*l - 3.3077115913*m + 0.2309699292*s),
+		float32(-1.2684380046*l + 2.6097574011*m - 0.3413193965*s),
+		float32(-0.0041960863*l - 0.7034186147*m + 1.7076147010*s)
+}
@@ -0,0 +1,347 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelColorSpaceFunctionsShouldPanicOnInvalidArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+
+	for name, iterate := range mockColorSpaceIterations() {
+		assert.Panics(t, func() { iterate(nil, ColorSpaceHsv, true) }, name)
+		assert.Panics(t, func() { iterate(img, ColorSpaceHsv, false) }, name)
+		assert.Panics(t, func() { iterate(img, ColorSpace(-1), true) }, name)
+		assert.Panics(t, func() { iterate(img, ColorSpaceOklab+1, true) }, name)
+		assert.NotPanics(t, func() { iterate(img, ColorSpaceOklab, true) }, name)
+	}
+}
+
+func TestParallelColorSpaceReadShouldConvertKnownColors(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	colors := []color.NRGBA{
+		{255, 0, 0, 255},
+		{0, 255, 0, 128},
+		{255, 255, 255, 255},
+		{0, 0, 0, 0},
+		{128, 64, 192, 255},
+	}
+
+	expected := map[ColorSpace][][4]float32{
+		ColorSpaceHsv: {
+			{0, 1, 1, 1},
+			{120, 1, 1, 128.0 / 255},
+			{0, 0, 1, 1},
+			{0, 0, 0, 0},
+			{270, 0.6666667, 0.7529412, 1},
+		},
+		ColorSpaceHsl: {
+			{0, 1, 0.5, 1},
+			{120, 1, 0.5, 128.0 / 255},
+			{0, 0, 1, 1},
+			{0, 0, 0, 0},
+			{270, 0.5039370, 0.5019608, 1},
+		},
+		ColorSpaceLab: {
+			{53.2408, 80.0925, 67.2032, 1},
+			{87.7347, -86.1827, 83.1793, 128.0 / 255},
+			{100, 0, 0, 1},
+			{0, 0, 0, 0},
+			{41.3141, 51.5743, -56.6268, 1},
+		},
+		ColorSpaceOklab: {
+			{0.6279554, 0.2248631, 0.1258463, 1},
+			{0.8664396, -0.2338876, 0.1794985, 128.0 / 255},
+			{1, 0, 0, 1},
+			{0, 0, 0, 0},
+			{0.5166656, 0.1052501, -0.1608886, 1},
+		},
+	}
+
+	img := image.NewNRGBA(image.Rect(10, 3, 10+len(colors), 4))
+	for x, c := range colors {
+		img.SetNRGBA(10+x, 3, c)
+	}
+
+	for space, components := range expected {
+		for _, src := range []image.Image{img, mockColorSpaceRgbaImage(img), mockColorSpaceNrgba64Image(img)} {
+			ParallelColorSpaceRead(src, space, func(x, y int, c1, c2, c3, a float32) {
+				assert.Equal(t, 3, y)
+				assert.InDelta(t, components[x-10][0], c1, 1e-3, "unexpected component of space %d at x=%d", space, x)
+				assert.InDelta(t, components[x-10][1], c2, 1e-3, "unexpected component of space %d at x=%d", space, x)
+				assert.InDelta(t, components[x-10][2], c3, 1e-3, "unexpected component of space %d at x=%d", space, x)
+				assert.InDelta(t, components[x-10][3], a, 1e-6, "unexpected alpha of space %d at x=%d", space, x)
+			})
+		}
+	}
+}
+
+func TestParallelColorSpaceReadWriteShouldPreserveColorsOnIdentity(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	identity := func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32) {
+		return c1, c2, c3, a
+	}
+
+	for space := ColorSpaceHsv; space <= ColorSpaceOklab; space += 1 {
+		nrgba := mockColorSpaceNrgbaImage(image.Rect(-3, 2, 61, 66))
+		rgba := mockColorSpaceRgbaImage(nrgba)
+		nrgba64 := mockColorSpaceNrgba64Image(nrgba)
+
+		expectedNrgba := image.NewNRGBA(nrgba.Bounds())
+		draw.Draw(expectedNrgba, nrgba.Bounds(), nrgba, nrgba.Bounds().Min, draw.Src)
+
+		expectedRgba := mockColorSpaceRgbaImage(nrgba)
+		expectedNrgba64 := mockColorSpaceNrgba64Image(nrgba)
+
+		ParallelColorSpaceReadWrite(nrgba, space, identity, WithChunkSize(3))
+		ParallelColorSpaceReadWrite(rgba, space, identity, WithWorkers(3))
+		ParallelColorSpaceReadWrite(nrgba64, space, identity)
+
+		assert.Equal(t, expectedNrgba.Pix, nrgba.Pix, "unexpected nrgba change of space %d", space)
+		assert.Equal(t, expectedRgba.Pix, rgba.Pix, "unexpected rgba change of space %d", space)
+		assert.Equal(t, expectedNrgba64.Pix, nrgba64.Pix, "unexpected nrgba64 change of space %d", space)
+	}
+}
+
+func TestParallelColorSpaceReadWriteShouldWrapHue(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, space := range []ColorSpace{ColorSpaceHsv, ColorSpaceHsl} {
+		img := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+		img.SetNRGBA(0, 0, color.NRGBA{255, 0, 0, 255})
+		img.SetNRGBA(1, 0, color.NRGBA{255, 0, 0, 255})
+		img.SetNRGBA(2, 0, color.NRGBA{255, 0, 0, 255})
+
+		ParallelColorSpaceReadWrite(img, space, func(x, y int, h, s, v, a float32) (float32, float32, float32, float32) {
+			return h + []float32{120, -120, 3600}[x], s, v, a
+		})
+
+		assert.Equal(t, color.NRGBA{0, 255, 0, 255}, img.NRGBAAt(0, 0))
+		assert.Equal(t, color.NRGBA{0, 0, 255, 255}, img.NRGBAAt(1, 0))
+		assert.Equal(t, color.NRGBA{255, 0, 0, 255}, img.NRGBAAt(2, 0))
+	}
+}
+
+func TestParallelColorSpaceReadWriteShouldClampOutOfGamutColors(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	cases := map[ColorSpace][3][4]float32{
+		ColorSpaceHsv:   {{0, 2, 2, 2}, {0, 0, -1, 1}, {60, -1, 0.5, -1}},
+		ColorSpaceHsl:   {{0, 2, 0.5, 2}, {0, 1, -1, 1}, {60, -1, 0.5, -1}},
+		ColorSpaceLab:   {{200, 0, 0, 2}, {-10, 0, 0, 1}, {50, 0, 0, -1}},
+		ColorSpaceOklab: {{2, 0, 0, 2}, {-1, 0, 0, 1}, {0.6, 0, 0, -1}},
+	}
+
+	expected := map[ColorSpace]color.RGBA{
+		ColorSpaceHsv:   {255, 0, 0, 255},
+		ColorSpaceHsl:   {255, 0, 0, 255},
+		ColorSpaceLab:   {255, 255, 255, 255},
+		ColorSpaceOklab: {255, 255, 255, 255},
+	}
+
+	for space, components := range cases {
+		img := image.NewRGBA(image.Rect(0, 0, 3, 1))
+
+		ParallelColorSpaceReadWrite(img, space, func(x, y int, _, _, _, _ float32) (float32, float32, float32, float32) {
+			return components[x][0], components[x][1], components[x][2], components[x][3]
+		})
+
+		assert.Equal(t, expected[space], img.RGBAAt(0, 0), "unexpected color of space %d", space)
+		assert.Equal(t, color.RGBA{0, 0, 0, 255}, img.RGBAAt(1, 0), "unexpected color of space %d", space)
+		assert.Equal(t, color.RGBA{0, 0, 0, 0}, img.RGBAAt(2, 0), "unexpected color of space %d", space)
+	}
+}
+
+func TestParallelColorSpaceReadWriteShouldPassAlphaThrough(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	nrgba := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	nrgba.SetNRGBA(0, 0, color.NRGBA{200, 100, 50, 200})
+
+	rgba := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	rgba.SetRGBA(0, 0, color.RGBA{160, 80, 40, 200})
+
+	halve := func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32) {
+		assert.InDelta(t, 200.0/255, a, 1e-6)
+		return c1, c2, c3, a / 2
+	}
+
+	ParallelColorSpaceReadWrite(nrgba, ColorSpaceLab, halve)
+	ParallelColorSpaceReadWrite(rgba, ColorSpaceHsv, halve)
+
+	assert.Equal(t, color.NRGBA{200, 100, 50, 100}, nrgba.NRGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{80, 40, 20, 100}, rgba.RGBAAt(0, 0))
+}
+
+func TestParallelColorSpaceReadWriteEShouldLeaveFailedPixelsUnchanged(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, img := range []draw.Image{mockCoordinateImageRgba(image.Rect(2, 3, 9, 8)), mockCoordinateImageRgba64(image.Rect(2, 3, 9, 8))} {
+		err := ParallelColorSpaceReadWriteE(img, ColorSpaceHsl, func(x, y int, h, s, l, a float32) (float32, float32, float32, float32, error) {
+			if x != 5 || y != 4 {
+				return h, s, l, a, nil
+			}
+
+			return 0, 0, 1, 1, errors.New("pimit-test: test error")
+		}, WithErrorPolicy(CollectAll))
+
+		var pe *PixelError
+
+		assert.ErrorAs(t, err, &pe)
+		assert.Equal(t, "ParallelColorSpaceReadWriteE", pe.Op)
+		assert.Equal(t, 5, pe.X)
+		assert.Equal(t, 4, pe.Y)
+		assertUntouchedCoordinateImage(t, img, image.Rectangle{})
+	}
+}
+
+func TestParallelColorSpaceReadWriteNewShouldNotModifySourceImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(4, -2, 40, 31)
+	desaturate := func(x, y int, l, a, b, alpha float32) (float32, float32, float32, float32) {
+		return l, 0, 0, alpha
+	}
+
+	for _, src := range []draw.Image{mockCoordinateImageRgba(bounds), mockCoordinateImageNrgba(bounds), mockCoordinateImageRgba64(bounds)} {
+		dst := ParallelColorSpaceReadWriteNew(src, ColorSpaceOklab, desaturate)
+
+		assertUntouchedCoordinateImage(t, src, image.Rectangle{})
+
+		ParallelColorSpaceReadWrite(src, ColorSpaceOklab, desaturate)
+		assert.Equal(t, bounds, dst.Bounds())
+
+		for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+			for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+				er, eg, eb, ea := src.At(x, y).RGBA()
+				ar, ag, ab, aa := dst.At(x, y).RGBA()
+
+				assert.InDelta(t, er, ar, 0x101)
+				assert.InDelta(t, eg, ag, 0x101)
+				assert.InDelta(t, eb, ab, 0x101)
+				assert.Equal(t, ea, aa)
+				assert.InDelta(t, ar, ag, 0x202)
+				assert.InDelta(t, ag, ab, 0x202)
+			}
+		}
+	}
+}
+
+func TestParallelColorSpaceReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	src := mockCoordinateImageNrgba(image.Rect(0, 0, 6, 6))
+
+	dst, err := ParallelColorSpaceReadWriteNewE(src, ColorSpaceLab, func(x, y int, l, a, b, alpha float32) (float32, float32, float32, float32, error) {
+		return l, a, b, alpha, errors.New("pimit-test: test error")
+	})
+
+	assert.Nil(t, dst)
+	assert.NotNil(t, err)
+	assertUntouchedCoordinateImage(t, src, image.Rectangle{})
+}
+
+func mockColorSpaceIterations() map[string]func(src draw.Image, s ColorSpace, valid bool) {
+	return map[string]func(src draw.Image, s ColorSpace, valid bool){
+		"ParallelColorSpaceRead": func(src draw.Image, s ColorSpace, valid bool) {
+			var d ColorSpaceReadDelegate = nil
+			if valid {
+				d = func(x, y int, c1, c2, c3, a float32) {}
+			}
+
+			ParallelColorSpaceRead(src, s, d)
+		},
+		"ParallelColorSpaceReadE": func(src draw.Image, s ColorSpace, valid bool) {
+			var d ColorSpaceReadErrorableDelegate = nil
+			if valid {
+				d = func(x, y int, c1, c2, c3, a float32) error {
+					return nil
+				}
+			}
+
+			ParallelColorSpaceReadE(src, s, d)
+		},
+		"ParallelColorSpaceReadWrite": func(src draw.Image, s ColorSpace, valid bool) {
+			var d ColorSpaceReadWriteDelegate = nil
+			if valid {
+				d = func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32) {
+					return c1, c2, c3, a
+				}
+			}
+
+			ParallelColorSpaceReadWrite(src, s, d)
+		},
+		"ParallelColorSpaceReadWriteE": func(src draw.Image, s ColorSpace, valid bool) {
+			var d ColorSpaceReadWriteErrorableDelegate = nil
+			if valid {
+				d = func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32, error) {
+					return c1, c2, c3, a, nil
+				}
+			}
+
+			ParallelColorSpaceReadWriteE(src, s, d)
+		},
+		"ParallelColorSpaceReadWriteNew": func(src draw.Image, s ColorSpace, valid bool) {
+			var d ColorSpaceReadWriteDelegate = nil
+			if valid {
+				d = func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32) {
+					return c1, c2, c3, a
+				}
+			}
+
+			ParallelColorSpaceReadWriteNew(src, s, d)
+		},
+		"ParallelColorSpaceReadWriteNewE": func(src draw.Image, s ColorSpace, valid bool) {
+			var d ColorSpaceReadWriteErrorableDelegate = nil
+			if valid {
+				d = func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32, error) {
+					return c1, c2, c3, a, nil
+				}
+			}
+
+			ParallelColorSpaceReadWriteNewE(src, s, d)
+		},
+	}
+}
+
+// Return an image which contains a wide range of the 8-bit colors with different alpha values, including the fully
+// transparent pixels.
+func mockColorSpaceNrgbaImage(bounds image.Rectangle) *image.NRGBA {
+	img := image.NewNRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.SetNRGBA(x, y, color.NRGBA{uint8(x * 4), uint8(y * 4), uint8(x*y + 7*x), uint8(255 - (x^y)&0xf*17)})
+		}
+	}
+
+	return img
+}
+
+func mockColorSpaceRgbaImage(src *image.NRGBA) *image.RGBA {
+	img := image.NewRGBA(src.Bounds())
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	return img
+}
+
+func mockColorSpaceNrgba64Image(src *image.NRGBA) *image.NRGBA64 {
+	img := image.NewNRGBA64(src.Bounds())
+	for y := src.Bounds().Min.Y; y < src.Bounds().Max.Y; y += 1 {
+		for x := src.Bounds().Min.X; x < src.Bounds().Max.X; x += 1 {
+			c := src.NRGBAAt(x, y)
+			img.SetNRGBA64(x, y, color.NRGBA64{uint16(c.R) * 0x101, uint16(c.G) * 0x101, uint16(c.B) * 0x101, uint16(c.A) * 0x101})
+		}
+	}
+
+	return img
+}
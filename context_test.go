@@ -0,0 +1,455 @@
+package pimit
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestWithContextShouldPanicOnNilContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var ctx context.Context = nil
+
+	assert.Panics(t, func() {
+		WithContext(ctx)
+	})
+}
+
+func TestErrorableFunctionsShouldReturnCancellationErrorOnCancelledContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(3, 4, 13, 24)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for name, iterate := range mockErrorableIterations(bounds) {
+		err := iterate(nil, WithContext(ctx))
+
+		assert.NotNil(t, err, name)
+		assert.ErrorIs(t, err, context.Canceled, name)
+		assert.True(t, strings.Contains(err.Error(), "x="), name)
+		assert.True(t, strings.Contains(err.Error(), "y="), name)
+	}
+}
+
+func TestErrorableFunctionsShouldStopOnCancellationDuringIteration(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-2, 1, 30, 41)
+	total := int32(bounds.Dx() * bounds.Dy())
+
+	for name, iterate := range mockErrorableIterations(bounds) {
+		for _, workers := range []int{1, 4} {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			var visits int32 = 0
+			err := iterate(func(_, _ int) error {
+				if atomic.AddInt32(&visits, 1) == 10 {
+					cancel()
+				}
+
+				return nil
+			}, WithContext(ctx), WithWorkers(workers))
+
+			assert.ErrorIs(t, err, context.Canceled, name)
+			assert.Less(t, atomic.LoadInt32(&visits), total, name)
+
+			cancel()
+		}
+	}
+}
+
+func TestErrorableFunctionsShouldReturnDeadlineError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 8, 8)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	<-ctx.Done()
+
+	for name, iterate := range mockErrorableIterations(bounds) {
+		err := iterate(nil, WithContext(ctx))
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded, name)
+	}
+}
+
+func TestErrorableFunctionsShouldNotReturnErrorOnNotCancelledContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 8, 8)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for name, iterate := range mockErrorableIterations(bounds) {
+		err := iterate(nil, WithContext(ctx))
+
+		assert.Nil(t, err, name)
+	}
+}
+
+func TestErrorableFunctionsShouldPreferDelegateErrorOverCancellation(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	delegateErr := errors.New("pimit-test: test error")
+
+	err := ParallelReadE(mockWhiteImageImage(), func(_, _ int, _ color.Color) error {
+		return delegateErr
+	}, WithContext(ctx), WithWorkers(1))
+
+	assert.ErrorIs(t, err, delegateErr)
+	assert.NotErrorIs(t, err, context.Canceled)
+}
+
+func TestFunctionsShouldStopOnCancelledContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var visits int32 = 0
+	count := func() {
+		atomic.AddInt32(&visits, 1)
+	}
+
+	ParallelIndices(5, 5, func(_, _ int) { count() }, WithContext(ctx))
+	ParallelRead(mockWhiteImageImage(), func(_, _ int, _ color.Color) { count() }, WithContext(ctx))
+	ParallelReadWrite(mockWhiteDrawImage(), func(_, _ int, c color.Color) color.Color { count(); return c }, WithContext(ctx))
+	ParallelDistributedReadWrite(mockWhiteDrawImage(), 3, func(_, _ int, c color.Color) color.Color { count(); return c }, WithContext(ctx))
+	ParallelReadWriteNew(mockWhiteDrawImage(), func(_, _ int, c color.Color) color.Color { count(); return c }, WithContext(ctx))
+	ParallelRgbaRead(mockWhiteImageRgba(), func(_, _ int, _, _, _, _ uint8) { count() }, WithContext(ctx))
+	ParallelRgbaReadWrite(mockWhiteImageRgba(), func(_, _ int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		count()
+		return r, g, b, a
+	}, WithContext(ctx))
+	ParallelRgbaReadWriteNew(mockWhiteImageRgba(), func(_, _ int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		count()
+		return r, g, b, a
+	}, WithContext(ctx))
+	ParallelNrgbaRead(mockWhiteImageNrgba(), func(_, _ int, _, _, _, _ uint8) { count() }, WithContext(ctx))
+	ParallelNrgbaReadWrite(mockWhiteImageNrgba(), func(_, _ int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		count()
+		return r, g, b, a
+	}, WithContext(ctx))
+	ParallelNrgbaReadWriteNew(mockWhiteImageNrgba(), func(_, _ int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		count()
+		return r, g, b, a
+	}, WithContext(ctx))
+	ParallelMatrixReadWrite(mockCustomMatrix(4, 4, 0), func(_, _ int, v int) int { count(); return v }, WithContext(ctx))
+
+	assert.Equal(t, int32(0), visits)
+}
+
+// Create a set of iterations using every errorable function on a mock image (or matrix) with the provided bounds. The
+// visit function, if not nil, is executed by the delegate on every visited pixel (or matrix entry) and its error is
+// returned by the delegate.
+func mockErrorableIterations(bounds image.Rectangle) map[string]func(visit func(x, y int) error, opts ...Option) error {
+	call := func(visit func(x, y int) error, x, y int) error {
+		if visit != nil {
+			return visit(x, y)
+		}
+
+		return nil
+	}
+
+	return map[string]func(visit func(x, y int) error, opts ...Option) error{
+		"ParallelReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelReadE(mockCoordinateImageRgba(bounds), func(x, y int, _ color.Color) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelReadWriteE(mockCoordinateImageRgba(bounds), func(x, y int, c color.Color) (color.Color, error) {
+				err := call(visit, x, y)
+				return c, err
+			}, opts...)
+		},
+		"ParallelDistributedReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelDistributedReadWriteE(mockCoordinateImageRgba(bounds), 7, func(x, y int, c color.Color) (color.Color, error) {
+				err := call(visit, x, y)
+				return c, err
+			}, opts...)
+		},
+		"ParallelReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelReadWriteNewE(mockCoordinateImageRgba(bounds), func(x, y int, c color.Color) (color.Color, error) {
+				err := call(visit, x, y)
+				return c, err
+			}, opts...)
+			return err
+		},
+		"ParallelRgbaReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelRgbaReadE(mockCoordinateImageRgba(bounds), func(x, y int, _, _, _, _ uint8) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelRgbaReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelRgbaReadWriteE(mockCoordinateImageRgba(bounds), func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+				err := call(visit, x, y)
+				return r, g, b, a, err
+			}, opts...)
+		},
+		"ParallelRgbaReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelRgbaReadWriteNewE(mockCoordinateImageRgba(bounds), func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+				err := call(visit, x, y)
+				return r, g, b, a, err
+			}, opts...)
+			return err
+		},
+		"ParallelNrgbaReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelNrgbaReadE(mockCoordinateImageNrgba(bounds), func(x, y int, _, _, _, _ uint8) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelNrgbaReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelNrgbaReadWriteE(mockCoordinateImageNrgba(bounds), func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+				err := call(visit, x, y)
+				return r, g, b, a, err
+			}, opts...)
+		},
+		"ParallelNrgbaReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelNrgbaReadWriteNewE(mockCoordinateImageNrgba(bounds), func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+				err := call(visit, x, y)
+				return r, g, b, a, err
+			}, opts...)
+			return err
+		},
+		"ParallelRgba64ReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelRgba64ReadE(mockCoordinateImageRgba64(bounds), func(x, y int, _, _, _, _ uint16) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelRgba64ReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelRgba64ReadWriteE(mockCoordinateImageRgba64(bounds), func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+				err := call(visit, x, y)
+				return r, g, b, a, err
+			}, opts...)
+		},
+		"ParallelRgba64ReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelRgba64ReadWriteNewE(mockCoordinateImageRgba64(bounds), func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+				err := call(visit, x, y)
+				return r, g, b, a, err
+			}, opts...)
+			return err
+		},
+		"ParallelNrgba64ReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelNrgba64ReadE(mockCoordinateImageNrgba64(bounds), func(x, y int, _, _, _, _ uint16) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelNrgba64ReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelNrgba64ReadWriteE(mockCoordinateImageNrgba64(bounds), func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+				err := call(visit, x, y)
+				return r, g, b, a, err
+			}, opts...)
+		},
+		"ParallelNrgba64ReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelNrgba64ReadWriteNewE(mockCoordinateImageNrgba64(bounds), func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+				err := call(visit, x, y)
+				return r, g, b, a, err
+			}, opts...)
+			return err
+		},
+		"ParallelGrayReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelGrayReadE(mockCoordinateImageGray(bounds), func(x, y int, _ uint8) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelGrayReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelGrayReadWriteE(mockCoordinateImageGray(bounds), func(x, y int, v uint8) (uint8, error) {
+				err := call(visit, x, y)
+				return v, err
+			}, opts...)
+		},
+		"ParallelGrayReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelGrayReadWriteNewE(mockCoordinateImageGray(bounds), func(x, y int, v uint8) (uint8, error) {
+				err := call(visit, x, y)
+				return v, err
+			}, opts...)
+			return err
+		},
+		"ParallelGray16ReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelGray16ReadE(mockCoordinateImageGray16(bounds), func(x, y int, _ uint16) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelGray16ReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelGray16ReadWriteE(mockCoordinateImageGray16(bounds), func(x, y int, v uint16) (uint16, error) {
+				err := call(visit, x, y)
+				return v, err
+			}, opts...)
+		},
+		"ParallelGray16ReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelGray16ReadWriteNewE(mockCoordinateImageGray16(bounds), func(x, y int, v uint16) (uint16, error) {
+				err := call(visit, x, y)
+				return v, err
+			}, opts...)
+			return err
+		},
+		"ParallelYCbCrReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelYCbCrReadE(mockCoordinateImageYCbCr(bounds, image.YCbCrSubsampleRatio420), func(x, y int, _, _, _ uint8) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelYCbCrReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelYCbCrReadWriteE(mockCoordinateImageYCbCr(bounds, image.YCbCrSubsampleRatio420), func(x, y int, luma, cb, cr uint8) (uint8, uint8, uint8, error) {
+				err := call(visit, x, y)
+				return luma, cb, cr, err
+			}, opts...)
+		},
+		"ParallelYCbCrReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelYCbCrReadWriteNewE(mockCoordinateImageYCbCr(bounds, image.YCbCrSubsampleRatio420), func(x, y int, luma, cb, cr uint8) (uint8, uint8, uint8, error) {
+				err := call(visit, x, y)
+				return luma, cb, cr, err
+			}, opts...)
+			return err
+		},
+		"ParallelNYCbCrAReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelNYCbCrAReadE(mockCoordinateImageNYCbCrA(bounds, image.YCbCrSubsampleRatio422), func(x, y int, _, _, _, _ uint8) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelNYCbCrAReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelNYCbCrAReadWriteE(mockCoordinateImageNYCbCrA(bounds, image.YCbCrSubsampleRatio422), func(x, y int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error) {
+				err := call(visit, x, y)
+				return luma, cb, cr, a, err
+			}, opts...)
+		},
+		"ParallelNYCbCrAReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelNYCbCrAReadWriteNewE(mockCoordinateImageNYCbCrA(bounds, image.YCbCrSubsampleRatio422), func(x, y int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error) {
+				err := call(visit, x, y)
+				return luma, cb, cr, a, err
+			}, opts...)
+			return err
+		},
+		"ParallelPalettedReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelPalettedReadE(mockCoordinateImagePaletted(bounds), func(x, y int, _ uint8) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelPalettedReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelPalettedReadWriteE(mockCoordinateImagePaletted(bounds), func(x, y int, index uint8) (uint8, error) {
+				err := call(visit, x, y)
+				return index, err
+			}, opts...)
+		},
+		"ParallelPalettedReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelPalettedReadWriteNewE(mockCoordinateImagePaletted(bounds), func(x, y int, index uint8) (uint8, error) {
+				err := call(visit, x, y)
+				return index, err
+			}, opts...)
+			return err
+		},
+		"ParallelCmykReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelCmykReadE(mockCoordinateImageCmyk(bounds), func(x, y int, _, _, _, _ uint8) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelCmykReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelCmykReadWriteE(mockCoordinateImageCmyk(bounds), func(x, y int, c, m, ye, k uint8) (uint8, uint8, uint8, uint8, error) {
+				err := call(visit, x, y)
+				return c, m, ye, k, err
+			}, opts...)
+		},
+		"ParallelCmykReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelCmykReadWriteNewE(mockCoordinateImageCmyk(bounds), func(x, y int, c, m, ye, k uint8) (uint8, uint8, uint8, uint8, error) {
+				err := call(visit, x, y)
+				return c, m, ye, k, err
+			}, opts...)
+			return err
+		},
+		"ParallelAlphaReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelAlphaReadE(mockCoordinateImageAlpha(bounds), func(x, y int, _ uint8) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelAlphaReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelAlphaReadWriteE(mockCoordinateImageAlpha(bounds), func(x, y int, v uint8) (uint8, error) {
+				err := call(visit, x, y)
+				return v, err
+			}, opts...)
+		},
+		"ParallelAlphaReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelAlphaReadWriteNewE(mockCoordinateImageAlpha(bounds), func(x, y int, v uint8) (uint8, error) {
+				err := call(visit, x, y)
+				return v, err
+			}, opts...)
+			return err
+		},
+		"ParallelAlpha16ReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelAlpha16ReadE(mockCoordinateImageAlpha16(bounds), func(x, y int, _ uint16) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelAlpha16ReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelAlpha16ReadWriteE(mockCoordinateImageAlpha16(bounds), func(x, y int, v uint16) (uint16, error) {
+				err := call(visit, x, y)
+				return v, err
+			}, opts...)
+		},
+		"ParallelAlpha16ReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelAlpha16ReadWriteNewE(mockCoordinateImageAlpha16(bounds), func(x, y int, v uint16) (uint16, error) {
+				err := call(visit, x, y)
+				return v, err
+			}, opts...)
+			return err
+		},
+		"ParallelFloatReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelFloatReadE(mockCoordinateImageFloat(bounds), func(x, y int, _, _, _, _ float32) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelFloatReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelFloatReadWriteE(mockCoordinateImageFloat(bounds), func(x, y int, r, g, b, a float32) (float32, float32, float32, float32, error) {
+				err := call(visit, x, y)
+				return r, g, b, a, err
+			}, opts...)
+		},
+		"ParallelFloatReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelFloatReadWriteNewE(mockCoordinateImageFloat(bounds), func(x, y int, r, g, b, a float32) (float32, float32, float32, float32, error) {
+				err := call(visit, x, y)
+				return r, g, b, a, err
+			}, opts...)
+			return err
+		},
+		"ParallelColorSpaceReadE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelColorSpaceReadE(mockCoordinateImageGray(bounds), ColorSpaceHsl, func(x, y int, _, _, _, _ float32) error {
+				return call(visit, x, y)
+			}, opts...)
+		},
+		"ParallelColorSpaceReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelColorSpaceReadWriteE(mockCoordinateImageRgba(bounds), ColorSpaceOklab, func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32, error) {
+				err := call(visit, x, y)
+				return c1, c2, c3, a, err
+			}, opts...)
+		},
+		"ParallelColorSpaceReadWriteNewE": func(visit func(x, y int) error, opts ...Option) error {
+			_, err := ParallelColorSpaceReadWriteNewE(mockCoordinateImageNrgba(bounds), ColorSpaceHsv, func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32, error) {
+				err := call(visit, x, y)
+				return c1, c2, c3, a, err
+			}, opts...)
+			return err
+		},
+		"ParallelMatrixReadWriteE": func(visit func(x, y int) error, opts ...Option) error {
+			return ParallelMatrixReadWriteE(mockCustomMatrix(bounds.Dx(), bounds.Dy(), 0), func(x, y int, v int) (int, error) {
+				err := call(visit, x, y)
+				return v, err
+			}, opts...)
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Allocate the destination image of the ParallelReadWriteNew and ParallelReadWriteNewE functions with the same
+// concrete type and color model as the source image. The supported types are *image.RGBA, *image.RGBA64, *image.NRGBA,
+// *image.NRGBA64, *image.Gray, *image.Gray16, *image.Alpha, *image.Alpha16, *image.CMYK and *image.Paletted, for the
+// remaining types (e.g. *image.YCbCr, which can not be modified) an *image.RGBA64 image is allocated, which is able to
+// represent any color without a loss of precision.
+func WithSourceColorModel() Option {
+	return func(o *options) {
+		o.allocator = newImageLike
+	}
+}
+
+// Allocate the destination image of the ParallelReadWriteNew and ParallelReadWriteNewE functions using the provided
+// allocator function. The allocator receives the bounds of the source image and must return an image with the same
+// bounds.
+func WithAllocator(alloc func(bounds image.Rectangle) draw.Image) Option {
+	if alloc == nil {
+		panic("pimit: the provided allocator function is nil")
+	}
+
+	return func(o *options) {
+		o.allocator = func(src image.Image) draw.Image {
+			return alloc(src.Bounds())
+		}
+	}
+}
+
+// Use the provided image as the destination image of the ParallelReadWriteNew and ParallelReadWriteNewE functions
+// instead of allocating a new one. The destination image must have the same bounds as the source image. The pixels of
+// the destination image may be partially modified if the iteration fails.
+func WithDestination(dst draw.Image) Option {
+	if dst == nil {
+		panic("pimit: the provided destination image reference is nil")
+	}
+
+	return func(o *options) {
+		o.allocator = func(_ image.Image) draw.Image {
+			return dst
+		}
+	}
+}
+
+// Return the destination image for the provided source image. By default a new NRGBA image is allocated. The bounds of
+// the destination image are validated against the bounds of the source image.
+func (o *options) destination(src image.Image) draw.Image {
+	if o.allocator == nil {
+		return image.NewNRGBA(src.Bounds())
+	}
+
+	dst := o.allocator(src)
+	if dst == nil {
+		panic("pimit: the provided destination image reference is nil")
+	}
+
+	if !dst.Bounds().Eq(src.Bounds()) {
+		panic("pimit: the provided destination image bounds are invalid")
+	}
+
+	return dst
+}
+
+// Allocate a new image with the same bounds, concrete type and color model as the provided image.
+func newImageLike(src image.Image) draw.Image {
+	bounds := src.Bounds()
+
+	switch img := src.(type) {
+	case *image.RGBA:
+		return image.NewRGBA(bounds)
+	case *image.RGBA64:
+		return image.NewRGBA64(bounds)
+	case *image.NRGBA:
+		return image.NewNRGBA(bounds)
+	case *image.NRGBA64:
+		return image.NewNRGBA64(bounds)
+	case *image.Gray:
+		return image.NewGray(bounds)
+	case *image.Gray16:
+		return image.NewGray16(bounds)
+	case *image.Alpha:
+		return image.NewAlpha(bounds)
+	case *image.Alpha16:
+		return image.NewAlpha16(bounds)
+	case *image.CMYK:
+		return image.NewCMYK(bounds)
+	case *image.Paletted:
+		return image.NewPaletted(bounds, append(color.Palette(nil), img.Palette...))
+	case *FloatImage:
+		return NewFloatImage(bounds)
+	default:
+		return image.NewRGBA64(bounds)
+	}
+}
+
+// Return the function setting the color of the pixel of the provided image. The *image.RGBA and *image.NRGBA images
+// are accessed without the color model conversion through the color.Color interface.
+func colorSetter(dst draw.Image) func(x, y int, c color.Color) {
+	switch img := dst.(type) {
+	case *image.RGBA:
+		return func(x, y int, c color.Color) {
+			img.SetRGBA(x, y, rgbaColor(c))
+		}
+	case *image.NRGBA:
+		return func(x, y int, c color.Color) {
+			img.SetNRGBA(x, y, nrgbaColor(c))
+		}
+	default:
+		return dst.Set
+	}
+}
@@ -0,0 +1,156 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestDestinationOptionsShouldPanicOnNilArguments(t *testing.T) {
+	assert.Panics(t, func() {
+		WithAllocator(nil)
+	})
+
+	assert.Panics(t, func() {
+		WithDestination(nil)
+	})
+}
+
+func TestParallelReadWriteNewShouldPreserveSourceColorModel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-2, 3, 6, 9)
+	sources := []image.Image{
+		image.NewRGBA(bounds),
+		image.NewRGBA64(bounds),
+		image.NewNRGBA(bounds),
+		image.NewNRGBA64(bounds),
+		image.NewGray(bounds),
+		image.NewGray16(bounds),
+		image.NewAlpha(bounds),
+		image.NewAlpha16(bounds),
+		image.NewCMYK(bounds),
+		image.NewPaletted(bounds, color.Palette{color.Black, color.White}),
+		NewFloatImage(bounds),
+	}
+
+	for _, src := range sources {
+		dst := ParallelReadWriteNew(src, func(_, _ int, c color.Color) color.Color {
+			return c
+		}, WithSourceColorModel())
+
+		assert.IsType(t, src, dst)
+		assert.Equal(t, src.ColorModel(), dst.ColorModel())
+		assert.Equal(t, bounds, dst.Bounds())
+
+		dst, err := ParallelReadWriteNewE(src, func(_, _ int, c color.Color) (color.Color, error) {
+			return c, nil
+		}, WithSourceColorModel())
+
+		assert.Nil(t, err)
+		assert.IsType(t, src, dst)
+		assert.Equal(t, src.ColorModel(), dst.ColorModel())
+	}
+}
+
+func TestParallelReadWriteNewShouldFallbackToRgba64ForUnsupportedSourceColorModel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	src := image.NewYCbCr(image.Rect(0, 0, 4, 4), image.YCbCrSubsampleRatio444)
+
+	dst := ParallelReadWriteNew(src, func(_, _ int, c color.Color) color.Color {
+		return c
+	}, WithSourceColorModel())
+
+	assert.IsType(t, &image.RGBA64{}, dst)
+	assertEqualImages(t, src, dst)
+}
+
+func TestParallelReadWriteNewShouldCopyPaletteOfSourceImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	src := image.NewPaletted(image.Rect(0, 0, 4, 4), color.Palette{color.Black, color.White})
+
+	dst := ParallelReadWriteNew(src, func(_, _ int, _ color.Color) color.Color {
+		return color.White
+	}, WithSourceColorModel())
+
+	src.Palette[1] = color.Black
+
+	assert.Equal(t, color.Palette{color.Black, color.White}, dst.(*image.Paletted).Palette)
+	assert.Equal(t, uint8(1), dst.(*image.Paletted).ColorIndexAt(2, 2))
+}
+
+func TestParallelReadWriteNewShouldUseProvidedAllocator(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, src := range []image.Image{mockWhiteImageRgba(), mockWhiteImageNrgba(), mockWhiteImageImage()} {
+		calls := 0
+		alloc := func(bounds image.Rectangle) draw.Image {
+			calls += 1
+			return image.NewGray16(bounds)
+		}
+
+		dst := ParallelReadWriteNew(src, func(_, _ int, c color.Color) color.Color {
+			return c
+		}, WithAllocator(alloc))
+
+		assert.Equal(t, 1, calls)
+		assert.IsType(t, &image.Gray16{}, dst)
+		assertEqualImages(t, src, dst)
+	}
+}
+
+func TestParallelReadWriteNewShouldWriteToProvidedDestination(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, src := range []image.Image{mockWhiteImageRgba(), mockWhiteImageNrgba(), mockWhiteImageImage()} {
+		expected := image.NewRGBA(src.Bounds())
+
+		dst := ParallelReadWriteNew(src, func(_, _ int, _ color.Color) color.Color {
+			return color.Black
+		}, WithDestination(expected))
+
+		assert.Same(t, expected, dst)
+		assertEqualImages(t, mockBlackImageRgba(), dst)
+
+		expectedE := image.NewNRGBA(src.Bounds())
+
+		dstE, err := ParallelReadWriteNewE(src, func(_, _ int, _ color.Color) (color.Color, error) {
+			return color.Black, nil
+		}, WithDestination(expectedE))
+
+		assert.Nil(t, err)
+		assert.Same(t, expectedE, dstE)
+		assertEqualImages(t, mockBlackImageRgba(), dstE)
+	}
+}
+
+func TestParallelReadWriteNewShouldPanicOnInvalidDestination(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	src := mockWhiteImageRgba()
+	d := func(_, _ int, c color.Color) color.Color {
+		return c
+	}
+
+	assert.Panics(t, func() {
+		ParallelReadWriteNew(src, d, WithDestination(image.NewRGBA(image.Rect(0, 0, 2, 2))))
+	})
+
+	assert.Panics(t, func() {
+		ParallelReadWriteNew(src, d, WithAllocator(func(bounds image.Rectangle) draw.Image {
+			return nil
+		}))
+	})
+
+	assert.Panics(t, func() {
+		ParallelReadWriteNew(src, d, WithAllocator(func(bounds image.Rectangle) draw.Image {
+			return image.NewRGBA(bounds.Add(image.Pt(1, 1)))
+		}))
+	})
+}
@@ -1,6 +1,26 @@
 package pimit
 
-import "sync"
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrStop can be returned by the delegate function of the errorable (E) variants of the functions in order to stop the
+// iteration without reporting a failure. The remaining work is cancelled regardless of the error policy and the
+// function returns the errors which occurred before (nil if none). The values returned together with ErrStop by the
+// read-write delegates are discarded. Using the WithAtomic option, the image stopped with ErrStop is restored to its
+// original state, because it is not fully updated.
+var ErrStop = errors.New("pimit: iteration stopped")
+
+// ErrInvalidFormat is returned (wrapped with the details) by the decoding functions when the provided data is not a
+// valid encoded image of the expected format or uses a variant of the format which is not supported. It is also
+// returned by the errorable Paletted functions when the delegate function returns an index outside of the palette.
+var ErrInvalidFormat = errors.New("pimit: the image data format is invalid")
+
+// ErrExecutorClosed is returned by the errorable (E) variants of the functions when the provided executor has been
+// closed before the iteration could be processed. The remaining functions panic with this error instead.
+var ErrExecutorClosed = errors.New("pimit: the provided executor is closed")
 
 type errorTrap struct {
 	err error
@@ -29,3 +49,49 @@ func (et *errorTrap) Err() error {
 
 	return et.err
 }
+
+// PixelError describes a failure of the processing of a single pixel returned by the errorable (E) variants of the
+// functions. It contains the name of the function, the coordinates of the pixel (relative to the origin of the image
+// bounds when the WithRelativeCoordinates option is used) and the cause, which is either the error returned by the
+// delegate function or the error of the cancelled context. In case of the matrix functions, the X and Y coordinates
+// are the indices of the entry, with X being the index of the outer slice and Y the index of the inner slice.
+type PixelError struct {
+	Op  string
+	X   int
+	Y   int
+	Err error
+}
+
+func (pe *PixelError) Error() string {
+	return fmt.Sprintf("pimit: %s failed on x=%d y=%d with: %v", pe.Op, pe.X, pe.Y, pe.Err)
+}
+
+// Return the cause of the failure, which allows to inspect it using errors.Is and errors.As.
+func (pe *PixelError) Unwrap() error {
+	return pe.Err
+}
+
+// PanicError describes a panic which occurred inside of a delegate function. The errorable (E) variants of the
+// functions return it as the error, the remaining functions panic with it on the calling goroutine. It contains the
+// name of the function, the coordinates of the element which was processed, the recovered value and the stack trace
+// of the panicking goroutine.
+type PanicError struct {
+	Op    string
+	X     int
+	Y     int
+	Value any
+	Stack []byte
+}
+
+func (pe *PanicError) Error() string {
+	return fmt.Sprintf("pimit: %s panicked on x=%d y=%d with: %v", pe.Op, pe.X, pe.Y, pe.Value)
+}
+
+// Return the recovered value if it is an error, which allows to inspect it using errors.Is and errors.As.
+func (pe *PanicError) Unwrap() error {
+	if err, ok := pe.Value.(error); ok {
+		return err
+	}
+
+	return nil
+}
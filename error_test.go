@@ -1,10 +1,14 @@
 package pimit
 
 import (
+	"context"
 	"errors"
+	"image"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
 )
 
 func TestNewErrorTrapShouldCreateNewInstane(t *testing.T) {
@@ -29,3 +33,125 @@ func TestNewErrorTrapShouldTrapFirstErrorOccurence(t *testing.T) {
 
 	assert.Equal(t, err1, errt.Err())
 }
+
+func TestErrorableFunctionsShouldReturnPixelErrorOnDelegateError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-4, 3, 10, 17)
+	failX, failY := 5, 11
+	delegateErr := errors.New("pimit-test: test error")
+
+	for name, iterate := range mockErrorableIterations(bounds) {
+		for _, workers := range []int{1, 3} {
+			err := iterate(func(x, y int) error {
+				if x == failX && y == failY {
+					return delegateErr
+				}
+
+				return nil
+			}, WithWorkers(workers))
+
+			var pe *PixelError
+
+			assert.ErrorAs(t, err, &pe, name)
+			assert.ErrorIs(t, err, delegateErr, name)
+			assert.Equal(t, name, pe.Op, name)
+			assert.Equal(t, failX, pe.X, name)
+			assert.Equal(t, failY, pe.Y, name)
+			assert.Equal(t, delegateErr, pe.Err, name)
+		}
+	}
+}
+
+func TestErrorableFunctionsShouldReturnPixelErrorOnCancelledContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for name, iterate := range mockErrorableIterations(image.Rect(0, 0, 6, 6)) {
+		err := iterate(nil, WithContext(ctx))
+
+		var pe *PixelError
+
+		assert.ErrorAs(t, err, &pe, name)
+		assert.Equal(t, name, pe.Op, name)
+		assert.ErrorIs(t, pe.Err, context.Canceled, name)
+	}
+}
+
+func TestPixelErrorShouldDescribeFailure(t *testing.T) {
+	err := &PixelError{
+		Op:  "ParallelReadE",
+		X:   3,
+		Y:   7,
+		Err: errors.New("pimit-test: test error"),
+	}
+
+	assert.Equal(t, "pimit: ParallelReadE failed on x=3 y=7 with: pimit-test: test error", err.Error())
+}
+
+func TestErrorableFunctionsShouldStopWithoutErrorOnErrStop(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-2, 5, 20, 27)
+	total := int32(bounds.Dx() * bounds.Dy())
+
+	for name, iterate := range mockErrorableIterations(bounds) {
+		for _, policy := range []ErrorPolicy{FailFast, CollectAll, DeterministicFirst} {
+			var visits int32 = 0
+
+			err := iterate(func(_, _ int) error {
+				if atomic.AddInt32(&visits, 1) == 20 {
+					return ErrStop
+				}
+
+				return nil
+			}, WithErrorPolicy(policy), WithWorkers(1))
+
+			assert.Nil(t, err, name)
+			assert.Equal(t, int32(20), atomic.LoadInt32(&visits), name)
+			assert.Less(t, atomic.LoadInt32(&visits), total, name)
+		}
+	}
+}
+
+func TestErrorableFunctionsShouldReturnCollectedErrorsOnErrStop(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	delegateErr := errors.New("pimit-test: test error")
+
+	err := ParallelRgbaReadE(mockWhiteImageRgba(), func(x, y int, _, _, _, _ uint8) error {
+		if y == 0 && x < 3 {
+			return delegateErr
+		}
+
+		if y == 1 {
+			return ErrStop
+		}
+
+		return nil
+	}, WithErrorPolicy(CollectAll), WithWorkers(1))
+
+	assert.ErrorIs(t, err, delegateErr)
+	assert.NotErrorIs(t, err, ErrStop)
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 3)
+}
+
+func TestAtomicFunctionsShouldRestoreImageOnErrStop(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 8, 8)
+	img := mockCoordinateImageRgba(bounds)
+
+	err := ParallelRgbaReadWriteE(img, func(_, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+		if y == 4 {
+			return 0, 0, 0, 0, ErrStop
+		}
+
+		return ^r, ^g, ^b, a, nil
+	}, WithAtomic(), WithWorkers(1))
+
+	assert.Nil(t, err)
+	assertUntouchedCoordinateImage(t, img, image.Rectangle{})
+}
@@ -0,0 +1,92 @@
+package pimit
+
+import "sync"
+
+// Executor is a pool of worker goroutines which can be shared between the iteration functions. All iterations using
+// the same executor are processed by its workers, which caps the total concurrency across concurrent calls, e.g. from
+// different request handlers. The executor must be closed using the Close method when it is no longer needed. The
+// delegates of an iteration processed by an executor must not start another iteration using the same executor.
+type Executor struct {
+	workers int
+	tasks   chan func()
+	closed  bool
+	mu      sync.RWMutex
+	wg      sync.WaitGroup
+}
+
+// Create a new executor with the provided number of worker goroutines. The goroutines are started immediately and
+// remain alive until the executor is closed.
+func NewExecutor(workers int) *Executor {
+	if workers <= 0 {
+		panic("pimit: the provided negative or zero worker count is invalid")
+	}
+
+	e := &Executor{
+		workers: workers,
+		tasks:   make(chan func()),
+		closed:  false,
+		mu:      sync.RWMutex{},
+		wg:      sync.WaitGroup{},
+	}
+
+	for w := 0; w < workers; w += 1 {
+		e.wg.Add(1)
+		go func() {
+			defer e.wg.Done()
+
+			for task := range e.tasks {
+				task()
+			}
+		}()
+	}
+
+	return e
+}
+
+// Return the number of worker goroutines of the executor.
+func (e *Executor) Workers() int {
+	return e.workers
+}
+
+// Stop the executor. The function waits for the currently processed tasks to finish and for all worker goroutines to
+// exit. The errorable (E) variants of the functions using the executor after it has been closed return the
+// ErrExecutorClosed error, while the remaining functions panic. Closing an already closed executor is a no-op.
+func (e *Executor) Close() {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return
+	}
+
+	e.closed = true
+	close(e.tasks)
+	e.mu.Unlock()
+
+	e.wg.Wait()
+}
+
+// Pass the task to one of the worker goroutines. The function blocks until a worker is available. Return false if
+// the task has been rejected because the executor is closed.
+func (e *Executor) submit(task func()) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.closed {
+		return false
+	}
+
+	e.tasks <- task
+	return true
+}
+
+// Process the iteration using the worker goroutines of the provided executor instead of starting new goroutines for
+// every call. By default the iteration uses as many workers of the executor as possible.
+func WithExecutor(e *Executor) Option {
+	if e == nil {
+		panic("pimit: the provided executor reference is nil")
+	}
+
+	return func(o *options) {
+		o.executor = e
+	}
+}
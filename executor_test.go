@@ -0,0 +1,188 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestNewExecutorShouldPanicOnInvalidWorkerCount(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		NewExecutor(0)
+	})
+
+	assert.Panics(t, func() {
+		NewExecutor(-2)
+	})
+}
+
+func TestNewExecutorShouldCreateNewInstance(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := NewExecutor(3)
+	defer e.Close()
+
+	assert.NotNil(t, e)
+	assert.Equal(t, 3, e.Workers())
+}
+
+func TestWithExecutorShouldPanicOnNilExecutor(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		WithExecutor(nil)
+	})
+}
+
+func TestExecutorCloseShouldStopWorkersAndBeIdempotent(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := NewExecutor(4)
+
+	assert.NotPanics(t, func() {
+		e.Close()
+		e.Close()
+	})
+}
+
+func TestExecutorShouldPanicWhenUsedAfterClose(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := NewExecutor(2)
+	e.Close()
+
+	for name, iterate := range mockIterations(image.Rect(0, 0, 6, 6)) {
+		assert.PanicsWithValue(t, ErrExecutorClosed, func() {
+			iterate(func(_, _ int) {}, WithExecutor(e))
+		}, name)
+	}
+
+	assert.PanicsWithValue(t, ErrExecutorClosed, func() {
+		ParallelFindFirst(mockWhiteImageImage(), func(_, _ int, _ color.Color) bool { return false }, WithExecutor(e))
+	})
+}
+
+func TestErrorableFunctionsShouldReturnErrExecutorClosedWhenUsedAfterClose(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := NewExecutor(2)
+	e.Close()
+
+	for name, iterate := range mockErrorableIterations(image.Rect(0, 0, 6, 6)) {
+		var visits int32 = 0
+
+		assert.NotPanics(t, func() {
+			err := iterate(func(_, _ int) error {
+				atomic.AddInt32(&visits, 1)
+				return nil
+			}, WithExecutor(e))
+
+			assert.ErrorIs(t, err, ErrExecutorClosed, name)
+		}, name)
+
+		assert.Zero(t, atomic.LoadInt32(&visits), name)
+	}
+
+	_, _, ok, err := ParallelFindFirstE(mockWhiteImageImage(), func(_, _ int, _ color.Color) bool { return true }, WithExecutor(e))
+
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, ErrExecutorClosed)
+}
+
+func TestExecutorShouldCapConcurrencyAcrossCalls(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var (
+		workers int32 = 2
+		current int32 = 0
+		peak    int32 = 0
+		mu            = sync.Mutex{}
+	)
+
+	e := NewExecutor(int(workers))
+	defer e.Close()
+
+	delegate := func(_, _ int) {
+		c := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+
+		mu.Lock()
+		if c > peak {
+			peak = c
+		}
+		mu.Unlock()
+
+		runtime.Gosched()
+	}
+
+	wg := &sync.WaitGroup{}
+	for call := 0; call < 8; call += 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ParallelIndices(5, 20, delegate, WithExecutor(e), WithWorkers(4), WithChunkSize(1))
+		}()
+	}
+
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak, workers)
+}
+
+func TestIteratorsShouldCorrectlyIterateUsingExecutor(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := NewExecutor(3)
+	defer e.Close()
+
+	bounds := image.Rect(-1, 2, 9, 17)
+	opts := []Option{WithExecutor(e)}
+
+	img := mockCoordinateImageRgba(bounds)
+	v := newVisitTracker(t, bounds, false)
+	ParallelReadWrite(img, v.visitAndInvert, opts...)
+	v.assertVisitedOnce()
+	assertInvertedCoordinateImage(t, img, bounds)
+
+	img = mockCoordinateImageRgba(bounds)
+	v = newVisitTracker(t, bounds, false)
+	err := ParallelDistributedReadWriteE(img, 4, func(x, y int, c color.Color) (color.Color, error) {
+		return v.visitAndInvert(x, y, c), nil
+	}, opts...)
+	assert.Nil(t, err)
+	v.assertVisitedOnce()
+	assertInvertedCoordinateImage(t, img, bounds)
+
+	img = mockCoordinateImageRgba(bounds)
+	v = newVisitTracker(t, bounds, false)
+	dst := ParallelRgbaReadWriteNew(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		return rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+	}, opts...)
+	v.assertVisitedOnce()
+	assertInvertedCoordinateImage(t, dst, bounds)
+
+	nimg := mockCoordinateImageNrgba(bounds)
+	v = newVisitTracker(t, bounds, false)
+	err = ParallelNrgbaReadWriteE(nimg, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+		r, g, b, a = rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+		return r, g, b, a, nil
+	}, opts...)
+	assert.Nil(t, err)
+	v.assertVisitedOnce()
+	assertInvertedCoordinateImage(t, nimg, bounds)
+
+	matrix := mockCustomMatrix(7, 5, 1)
+	ParallelMatrixReadWrite(matrix, func(_, _ int, value int) int {
+		return value + 1
+	}, opts...)
+	assert.Equal(t, mockCustomMatrix(7, 5, 2), matrix)
+}
@@ -0,0 +1,346 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"sync"
+)
+
+type (
+	ReadPredicate     = func(x, y int, c color.Color) bool
+	RgbaReadPredicate = func(x, y int, r, g, b, a uint8) bool
+)
+
+// The sentinel returned by the delegates of the find functions in order to mark the matching pixel.
+var errFound = errors.New("pimit: pixel found")
+
+// Perform a parallel search of the pixels of the provided image. Return the coordinates of the first pixel, in the
+// row-major order, for which the predicate function returns true. The rows which follow the row of a matching pixel
+// are not processed. The boolean value indicates if a matching pixel has been found. The search is also reported as
+// unsuccessful if the iteration is cancelled, use ParallelFindFirstE in order to distinguish both cases.
+func ParallelFindFirst(src image.Image, p ReadPredicate, opts ...Option) (int, int, bool) {
+	x, y, ok, err := ParallelFindFirstE(src, p, opts...)
+	repanicSearch(err)
+
+	return x, y, ok
+}
+
+// Perform a parallel search of the pixels of the provided image. Return the coordinates of the first pixel, in the
+// row-major order, for which the predicate function returns true. The rows which follow the row of a matching pixel
+// are not processed. The boolean value indicates if a matching pixel has been found. The returned error describes
+// the cancellation of the iteration or the panic of the predicate function, in which case no pixel is reported.
+func ParallelFindFirstE(src image.Image, p ReadPredicate, opts ...Option) (int, int, bool, error) {
+	if p == nil {
+		panic("pimit: the provided predicate function is nil")
+	}
+
+	err := ParallelReadE(src, func(x, y int, c color.Color) error {
+		return match(p(x, y, c))
+	}, firstOptions(opts)...)
+
+	return found(err)
+}
+
+// Perform a parallel search of the pixels of the provided image. Return the coordinates of any pixel for which the
+// predicate function returns true. The iteration stops after the first matching pixel is found by any of the workers.
+// The boolean value indicates if a matching pixel has been found. The search is also reported as unsuccessful if the
+// iteration is cancelled, use ParallelAnyE in order to distinguish both cases.
+func ParallelAny(src image.Image, p ReadPredicate, opts ...Option) (int, int, bool) {
+	x, y, ok, err := ParallelAnyE(src, p, opts...)
+	repanicSearch(err)
+
+	return x, y, ok
+}
+
+// Perform a parallel search of the pixels of the provided image. Return the coordinates of any pixel for which the
+// predicate function returns true. The iteration stops after the first matching pixel is found by any of the workers.
+// The boolean value indicates if a matching pixel has been found. The returned error describes the cancellation of the
+// iteration or the panic of the predicate function if no matching pixel has been found before.
+func ParallelAnyE(src image.Image, p ReadPredicate, opts ...Option) (int, int, bool, error) {
+	if p == nil {
+		panic("pimit: the provided predicate function is nil")
+	}
+
+	s := &search{}
+	err := ParallelReadE(src, func(x, y int, c color.Color) error {
+		return s.match(x, y, p(x, y, c))
+	}, opts...)
+
+	return s.result(err)
+}
+
+// Perform a parallel check of the pixels of the provided image. Return true if the predicate function returns true for
+// all pixels. The iteration stops after the first not matching pixel is found by any of the workers. False is returned
+// if the iteration is cancelled before all pixels are checked, use ParallelAllE in order to distinguish both cases.
+func ParallelAll(src image.Image, p ReadPredicate, opts ...Option) bool {
+	ok, err := ParallelAllE(src, p, opts...)
+	repanicSearch(err)
+
+	return ok
+}
+
+// Perform a parallel check of the pixels of the provided image. Return true if the predicate function returns true for
+// all pixels. The iteration stops after the first not matching pixel is found by any of the workers. The returned
+// error describes the cancellation of the iteration or the panic of the predicate function, in which case false is
+// returned, unless a not matching pixel has been found before.
+func ParallelAllE(src image.Image, p ReadPredicate, opts ...Option) (bool, error) {
+	if p == nil {
+		panic("pimit: the provided predicate function is nil")
+	}
+
+	_, _, ok, err := ParallelAnyE(src, func(x, y int, c color.Color) bool {
+		return !p(x, y, c)
+	}, opts...)
+
+	if ok {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Perform a parallel search of the pixels of the provided RGBA image. Return the coordinates of the first pixel, in the
+// row-major order, for which the predicate function returns true. The rows which follow the row of a matching pixel
+// are not processed. The boolean value indicates if a matching pixel has been found. The search is also reported as
+// unsuccessful if the iteration is cancelled, use ParallelRgbaFindFirstE in order to distinguish both cases.
+func ParallelRgbaFindFirst(src *image.RGBA, p RgbaReadPredicate, opts ...Option) (int, int, bool) {
+	x, y, ok, err := ParallelRgbaFindFirstE(src, p, opts...)
+	repanicSearch(err)
+
+	return x, y, ok
+}
+
+// Perform a parallel search of the pixels of the provided RGBA image. Return the coordinates of the first pixel, in the
+// row-major order, for which the predicate function returns true. The rows which follow the row of a matching pixel
+// are not processed. The boolean value indicates if a matching pixel has been found. The returned error describes
+// the cancellation of the iteration or the panic of the predicate function, in which case no pixel is reported.
+func ParallelRgbaFindFirstE(src *image.RGBA, p RgbaReadPredicate, opts ...Option) (int, int, bool, error) {
+	if p == nil {
+		panic("pimit: the provided predicate function is nil")
+	}
+
+	err := ParallelRgbaReadE(src, func(x, y int, r, g, b, a uint8) error {
+		return match(p(x, y, r, g, b, a))
+	}, firstOptions(opts)...)
+
+	return found(err)
+}
+
+// Perform a parallel search of the pixels of the provided RGBA image. Return the coordinates of any pixel for which the
+// predicate function returns true. The iteration stops after the first matching pixel is found by any of the workers.
+// The boolean value indicates if a matching pixel has been found. The search is also reported as unsuccessful if the
+// iteration is cancelled, use ParallelRgbaAnyE in order to distinguish both cases.
+func ParallelRgbaAny(src *image.RGBA, p RgbaReadPredicate, opts ...Option) (int, int, bool) {
+	x, y, ok, err := ParallelRgbaAnyE(src, p, opts...)
+	repanicSearch(err)
+
+	return x, y, ok
+}
+
+// Perform a parallel search of the pixels of the provided RGBA image. Return the coordinates of any pixel for which the
+// predicate function returns true. The iteration stops after the first matching pixel is found by any of the workers.
+// The boolean value indicates if a matching pixel has been found. The returned error describes the cancellation of the
+// iteration or the panic of the predicate function if no matching pixel has been found before.
+func ParallelRgbaAnyE(src *image.RGBA, p RgbaReadPredicate, opts ...Option) (int, int, bool, error) {
+	if p == nil {
+		panic("pimit: the provided predicate function is nil")
+	}
+
+	s := &search{}
+	err := ParallelRgbaReadE(src, func(x, y int, r, g, b, a uint8) error {
+		return s.match(x, y, p(x, y, r, g, b, a))
+	}, opts...)
+
+	return s.result(err)
+}
+
+// Perform a parallel check of the pixels of the provided RGBA image. Return true if the predicate function returns true
+// for all pixels. The iteration stops after the first not matching pixel is found by any of the workers. False is
+// returned if the iteration is cancelled before all pixels are checked, use ParallelRgbaAllE in order to distinguish
+// both cases.
+func ParallelRgbaAll(src *image.RGBA, p RgbaReadPredicate, opts ...Option) bool {
+	ok, err := ParallelRgbaAllE(src, p, opts...)
+	repanicSearch(err)
+
+	return ok
+}
+
+// Perform a parallel check of the pixels of the provided RGBA image. Return true if the predicate function returns true
+// for all pixels. The iteration stops after the first not matching pixel is found by any of the workers. The returned
+// error describes the cancellation of the iteration or the panic of the predicate function, in which case false is
+// returned, unless a not matching pixel has been found before.
+func ParallelRgbaAllE(src *image.RGBA, p RgbaReadPredicate, opts ...Option) (bool, error) {
+	if p == nil {
+		panic("pimit: the provided predicate function is nil")
+	}
+
+	_, _, ok, err := ParallelRgbaAnyE(src, func(x, y int, r, g, b, a uint8) bool {
+		return !p(x, y, r, g, b, a)
+	}, opts...)
+
+	if ok {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Perform a parallel search of the pixels of the provided NRGBA image. Return the coordinates of the first pixel, in
+// the row-major order, for which the predicate function returns true. The rows which follow the row of a matching pixel
+// are not processed. The boolean value indicates if a matching pixel has been found. The search is also reported as
+// unsuccessful if the iteration is cancelled, use ParallelNrgbaFindFirstE in order to distinguish both cases.
+func ParallelNrgbaFindFirst(src *image.NRGBA, p RgbaReadPredicate, opts ...Option) (int, int, bool) {
+	x, y, ok, err := ParallelNrgbaFindFirstE(src, p, opts...)
+	repanicSearch(err)
+
+	return x, y, ok
+}
+
+// Perform a parallel search of the pixels of the provided NRGBA image. Return the coordinates of the first pixel, in
+// the row-major order, for which the predicate function returns true. The rows which follow the row of a matching pixel
+// are not processed. The boolean value indicates if a matching pixel has been found. The returned error describes the
+// cancellation of the iteration or the panic of the predicate function, in which case no pixel is reported.
+func ParallelNrgbaFindFirstE(src *image.NRGBA, p RgbaReadPredicate, opts ...Option) (int, int, bool, error) {
+	if p == nil {
+		panic("pimit: the provided predicate function is nil")
+	}
+
+	err := ParallelNrgbaReadE(src, func(x, y int, r, g, b, a uint8) error {
+		return match(p(x, y, r, g, b, a))
+	}, firstOptions(opts)...)
+
+	return found(err)
+}
+
+// Perform a parallel search of the pixels of the provided NRGBA image. Return the coordinates of any pixel for which
+// the predicate function returns true. The iteration stops after the first matching pixel is found by any of the
+// workers. The boolean value indicates if a matching pixel has been found. The search is also reported as unsuccessful
+// if the iteration is cancelled, use ParallelNrgbaAnyE in order to distinguish both cases.
+func ParallelNrgbaAny(src *image.NRGBA, p RgbaReadPredicate, opts ...Option) (int, int, bool) {
+	x, y, ok, err := ParallelNrgbaAnyE(src, p, opts...)
+	repanicSearch(err)
+
+	return x, y, ok
+}
+
+// Perform a parallel search of the pixels of the provided NRGBA image. Return the coordinates of any pixel for which
+// the predicate function returns true. The iteration stops after the first matching pixel is found by any of the
+// workers. The boolean value indicates if a matching pixel has been found. The returned error describes the
+// cancellation of the iteration or the panic of the predicate function if no matching pixel has been found before.
+func ParallelNrgbaAnyE(src *image.NRGBA, p RgbaReadPredicate, opts ...Option) (int, int, bool, error) {
+	if p == nil {
+		panic("pimit: the provided predicate function is nil")
+	}
+
+	s := &search{}
+	err := ParallelNrgbaReadE(src, func(x, y int, r, g, b, a uint8) error {
+		return s.match(x, y, p(x, y, r, g, b, a))
+	}, opts...)
+
+	return s.result(err)
+}
+
+// Perform a parallel check of the pixels of the provided NRGBA image. Return true if the predicate function returns
+// true for all pixels. The iteration stops after the first not matching pixel is found by any of the workers. False is
+// returned if the iteration is cancelled before all pixels are checked, use ParallelNrgbaAllE in order to distinguish
+// both cases.
+func ParallelNrgbaAll(src *image.NRGBA, p RgbaReadPredicate, opts ...Option) bool {
+	ok, err := ParallelNrgbaAllE(src, p, opts...)
+	repanicSearch(err)
+
+	return ok
+}
+
+// Perform a parallel check of the pixels of the provided NRGBA image. Return true if the predicate function returns
+// true for all pixels. The iteration stops after the first not matching pixel is found by any of the workers. The
+// returned error describes the cancellation of the iteration or the panic of the predicate function, in which case
+// false is returned, unless a not matching pixel has been found before.
+func ParallelNrgbaAllE(src *image.NRGBA, p RgbaReadPredicate, opts ...Option) (bool, error) {
+	if p == nil {
+		panic("pimit: the provided predicate function is nil")
+	}
+
+	_, _, ok, err := ParallelNrgbaAnyE(src, func(x, y int, r, g, b, a uint8) bool {
+		return !p(x, y, r, g, b, a)
+	}, opts...)
+
+	if ok {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Extend the provided options with the DeterministicFirst error policy, which allows to find the first matching pixel
+// by marking it with the errFound sentinel. The provided options slice is not modified.
+func firstOptions(opts []Option) []Option {
+	return appendOptions(opts, WithErrorPolicy(DeterministicFirst))
+}
+
+// Return the errFound sentinel if the predicate matched.
+func match(matched bool) error {
+	if matched {
+		return errFound
+	}
+
+	return nil
+}
+
+// Return the coordinates of the pixel marked with the errFound sentinel. The remaining errors of the iteration are
+// returned together with the unsuccessful result.
+func found(err error) (int, int, bool, error) {
+	var pxe *PixelError
+	if errors.As(err, &pxe) && errors.Is(pxe.Err, errFound) {
+		return pxe.X, pxe.Y, true, nil
+	}
+
+	return 0, 0, false, err
+}
+
+// Propagate a recovered panic of the predicate function or the rejection of a closed executor to the calling
+// goroutine. This is used by the search functions which are not able to return errors.
+func repanicSearch(err error) {
+	var pe *PanicError
+	if errors.As(err, &pe) {
+		panic(pe)
+	}
+
+	if errors.Is(err, ErrExecutorClosed) {
+		panic(ErrExecutorClosed)
+	}
+}
+
+// The search holds the coordinates of the matching pixel found by any of the workers.
+type search struct {
+	once sync.Once
+	x    int
+	y    int
+	ok   bool
+}
+
+// Store the coordinates of the pixel if the predicate matched and it is the first match, return ErrStop to stop
+// the iteration.
+func (s *search) match(x, y int, matched bool) error {
+	if !matched {
+		return nil
+	}
+
+	s.once.Do(func() {
+		s.x, s.y, s.ok = x, y, true
+	})
+
+	return ErrStop
+}
+
+// Return the coordinates of the matching pixel. The cancellation of the iteration is returned only if no matching
+// pixel has been found, because the matching pixel is a valid result regardless of the later cancellation. A recovered
+// panic of the predicate function is always returned.
+func (s *search) result(err error) (int, int, bool, error) {
+	var pe *PanicError
+	if s.ok && !errors.As(err, &pe) {
+		return s.x, s.y, true, nil
+	}
+
+	return 0, 0, false, err
+}
@@ -0,0 +1,282 @@
+package pimit
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestFindFunctionsShouldPanicOnNilPredicate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() { ParallelFindFirst(mockWhiteImageImage(), nil) })
+	assert.Panics(t, func() { ParallelAny(mockWhiteImageImage(), nil) })
+	assert.Panics(t, func() { ParallelAll(mockWhiteImageImage(), nil) })
+	assert.Panics(t, func() { ParallelRgbaFindFirst(mockWhiteImageRgba(), nil) })
+	assert.Panics(t, func() { ParallelRgbaAny(mockWhiteImageRgba(), nil) })
+	assert.Panics(t, func() { ParallelRgbaAll(mockWhiteImageRgba(), nil) })
+	assert.Panics(t, func() { ParallelNrgbaFindFirst(mockWhiteImageNrgba(), nil) })
+	assert.Panics(t, func() { ParallelNrgbaAny(mockWhiteImageNrgba(), nil) })
+	assert.Panics(t, func() { ParallelNrgbaAll(mockWhiteImageNrgba(), nil) })
+	assert.Panics(t, func() { ParallelFindFirstE(mockWhiteImageImage(), nil) })
+	assert.Panics(t, func() { ParallelAnyE(mockWhiteImageImage(), nil) })
+	assert.Panics(t, func() { ParallelAllE(mockWhiteImageImage(), nil) })
+	assert.Panics(t, func() { ParallelRgbaFindFirstE(mockWhiteImageRgba(), nil) })
+	assert.Panics(t, func() { ParallelRgbaAnyE(mockWhiteImageRgba(), nil) })
+	assert.Panics(t, func() { ParallelRgbaAllE(mockWhiteImageRgba(), nil) })
+	assert.Panics(t, func() { ParallelNrgbaFindFirstE(mockWhiteImageNrgba(), nil) })
+	assert.Panics(t, func() { ParallelNrgbaAnyE(mockWhiteImageNrgba(), nil) })
+	assert.Panics(t, func() { ParallelNrgbaAllE(mockWhiteImageNrgba(), nil) })
+}
+
+func TestFindFirstFunctionsShouldReturnFirstMatchingPixel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-5, 4, 27, 36)
+	matching := map[image.Point]bool{
+		image.Pt(20, 9):  true,
+		image.Pt(-3, 12): true,
+		image.Pt(6, 12):  true,
+		image.Pt(0, 30):  true,
+	}
+
+	for run := 0; run < 10; run += 1 {
+		opts := []Option{WithWorkers(4), WithChunkSize(1)}
+
+		x, y, ok := ParallelFindFirst(mockCoordinateImageRgba(bounds), func(x, y int, _ color.Color) bool {
+			return matching[image.Pt(x, y)]
+		}, opts...)
+
+		assert.True(t, ok)
+		assert.Equal(t, image.Pt(20, 9), image.Pt(x, y))
+
+		x, y, ok = ParallelRgbaFindFirst(mockCoordinateImageRgba(bounds), func(x, y int, _, _, _, _ uint8) bool {
+			return matching[image.Pt(x, y)]
+		}, opts...)
+
+		assert.True(t, ok)
+		assert.Equal(t, image.Pt(20, 9), image.Pt(x, y))
+
+		x, y, ok = ParallelNrgbaFindFirst(mockCoordinateImageNrgba(bounds), func(x, y int, _, _, _, _ uint8) bool {
+			return matching[image.Pt(x, y)]
+		}, opts...)
+
+		assert.True(t, ok)
+		assert.Equal(t, image.Pt(20, 9), image.Pt(x, y))
+		assert.Len(t, opts, 2)
+	}
+}
+
+func TestFindFirstFunctionsShouldHonorRelativeCoordinates(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(10, 20, 30, 40)
+
+	x, y, ok := ParallelRgbaFindFirst(mockCoordinateImageRgba(bounds), func(x, y int, _, _, _, _ uint8) bool {
+		return x == 3 && y == 5
+	}, WithRelativeCoordinates())
+
+	assert.True(t, ok)
+	assert.Equal(t, 3, x)
+	assert.Equal(t, 5, y)
+}
+
+func TestAnyFunctionsShouldReturnMatchingPixel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 40, 40)
+	matching := func(x, y int) bool {
+		return (x*y)%7 == 3
+	}
+
+	x, y, ok := ParallelAny(mockCoordinateImageRgba(bounds), func(x, y int, _ color.Color) bool {
+		return matching(x, y)
+	}, WithWorkers(4))
+
+	assert.True(t, ok)
+	assert.True(t, matching(x, y))
+
+	x, y, ok = ParallelRgbaAny(mockCoordinateImageRgba(bounds), func(x, y int, _, _, _, _ uint8) bool {
+		return matching(x, y)
+	}, WithWorkers(4))
+
+	assert.True(t, ok)
+	assert.True(t, matching(x, y))
+
+	x, y, ok = ParallelNrgbaAny(mockCoordinateImageNrgba(bounds), func(x, y int, _, _, _, _ uint8) bool {
+		return matching(x, y)
+	}, WithWorkers(4))
+
+	assert.True(t, ok)
+	assert.True(t, matching(x, y))
+}
+
+func TestAnyFunctionsShouldStopAfterMatch(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 50, 50)
+
+	var visits int32 = 0
+	_, _, ok := ParallelRgbaAny(mockCoordinateImageRgba(bounds), func(_, _ int, _, _, _, _ uint8) bool {
+		return atomic.AddInt32(&visits, 1) == 10
+	}, WithWorkers(1))
+
+	assert.True(t, ok)
+	assert.Equal(t, int32(10), atomic.LoadInt32(&visits))
+}
+
+func TestFindFunctionsShouldNotFindMissingPixel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	never := func(_, _ int, _ color.Color) bool { return false }
+	neverRgba := func(_, _ int, _, _, _, _ uint8) bool { return false }
+
+	_, _, ok := ParallelFindFirst(mockWhiteImageImage(), never)
+	assert.False(t, ok)
+
+	_, _, ok = ParallelAny(mockWhiteImageImage(), never)
+	assert.False(t, ok)
+
+	_, _, ok = ParallelRgbaFindFirst(mockWhiteImageRgba(), neverRgba)
+	assert.False(t, ok)
+
+	_, _, ok = ParallelRgbaAny(mockWhiteImageRgba(), neverRgba)
+	assert.False(t, ok)
+
+	_, _, ok = ParallelNrgbaFindFirst(mockWhiteImageNrgba(), neverRgba)
+	assert.False(t, ok)
+
+	_, _, ok = ParallelNrgbaAny(mockWhiteImageNrgba(), neverRgba)
+	assert.False(t, ok)
+}
+
+func TestAllFunctionsShouldCheckEveryPixel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	white := func(_, _ int, r, g, b, a uint8) bool {
+		return r == 0xff && g == 0xff && b == 0xff && a == 0xff
+	}
+
+	assert.True(t, ParallelRgbaAll(mockWhiteImageRgba(), white))
+	assert.False(t, ParallelRgbaAll(mockBlackImageRgba(), white))
+	assert.True(t, ParallelNrgbaAll(mockWhiteImageNrgba(), white))
+	assert.False(t, ParallelNrgbaAll(mockBlackImageNrgba(), white))
+
+	assert.True(t, ParallelAll(mockWhiteImageImage(), func(_, _ int, c color.Color) bool {
+		r, g, b, a := c.RGBA()
+		return r == 0xffff && g == 0xffff && b == 0xffff && a == 0xffff
+	}))
+
+	img := mockWhiteImageRgba()
+	img.SetRGBA(img.Bounds().Min.X+3, img.Bounds().Min.Y+4, color.RGBA{0, 0, 0, 0xff})
+
+	assert.False(t, ParallelRgbaAll(img, white, WithWorkers(4)))
+}
+
+func TestFindFunctionsShouldReportCancelledContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	never := func(_, _ int, _ color.Color) bool { return false }
+	neverRgba := func(_, _ int, _, _, _, _ uint8) bool { return false }
+
+	searches := map[string]func() (bool, error){
+		"ParallelFindFirstE": func() (bool, error) {
+			_, _, ok, err := ParallelFindFirstE(mockWhiteImageImage(), never, WithContext(ctx))
+			return ok, err
+		},
+		"ParallelAnyE": func() (bool, error) {
+			_, _, ok, err := ParallelAnyE(mockWhiteImageImage(), never, WithContext(ctx))
+			return ok, err
+		},
+		"ParallelAllE": func() (bool, error) {
+			return ParallelAllE(mockWhiteImageImage(), never, WithContext(ctx))
+		},
+		"ParallelRgbaFindFirstE": func() (bool, error) {
+			_, _, ok, err := ParallelRgbaFindFirstE(mockWhiteImageRgba(), neverRgba, WithContext(ctx))
+			return ok, err
+		},
+		"ParallelRgbaAnyE": func() (bool, error) {
+			_, _, ok, err := ParallelRgbaAnyE(mockWhiteImageRgba(), neverRgba, WithContext(ctx))
+			return ok, err
+		},
+		"ParallelRgbaAllE": func() (bool, error) {
+			return ParallelRgbaAllE(mockWhiteImageRgba(), neverRgba, WithContext(ctx))
+		},
+		"ParallelNrgbaFindFirstE": func() (bool, error) {
+			_, _, ok, err := ParallelNrgbaFindFirstE(mockWhiteImageNrgba(), neverRgba, WithContext(ctx))
+			return ok, err
+		},
+		"ParallelNrgbaAnyE": func() (bool, error) {
+			_, _, ok, err := ParallelNrgbaAnyE(mockWhiteImageNrgba(), neverRgba, WithContext(ctx))
+			return ok, err
+		},
+		"ParallelNrgbaAllE": func() (bool, error) {
+			return ParallelNrgbaAllE(mockWhiteImageNrgba(), neverRgba, WithContext(ctx))
+		},
+	}
+
+	for name, search := range searches {
+		ok, err := search()
+
+		assert.False(t, ok, name)
+		assert.ErrorIs(t, err, context.Canceled, name)
+	}
+
+	_, _, ok := ParallelFindFirst(mockWhiteImageImage(), never, WithContext(ctx))
+	assert.False(t, ok)
+
+	_, _, ok = ParallelAny(mockWhiteImageImage(), never, WithContext(ctx))
+	assert.False(t, ok)
+
+	assert.False(t, ParallelAll(mockWhiteImageImage(), never, WithContext(ctx)))
+	assert.False(t, ParallelRgbaAll(mockWhiteImageRgba(), neverRgba, WithContext(ctx)))
+	assert.False(t, ParallelNrgbaAll(mockWhiteImageNrgba(), neverRgba, WithContext(ctx)))
+}
+
+func TestFindFunctionsShouldNotReportErrorOnCompletedSearch(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	white := func(_, _ int, r, g, b, a uint8) bool {
+		return r == 0xff && g == 0xff && b == 0xff && a == 0xff
+	}
+
+	x, y, ok, err := ParallelRgbaFindFirstE(mockWhiteImageRgba(), white)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, mockWhiteImageRgba().Bounds().Min, image.Pt(x, y))
+
+	_, _, ok, err = ParallelNrgbaAnyE(mockBlackImageNrgba(), white)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	ok, err = ParallelRgbaAllE(mockWhiteImageRgba(), white)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = ParallelNrgbaAllE(mockBlackImageNrgba(), white)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestFindFunctionsShouldRepanicOnPredicatePanic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	predicate := func(_, _ int, _, _, _, _ uint8) bool {
+		panic("pimit-test: test panic")
+	}
+
+	assert.PanicsWithError(t, "pimit: ParallelRgbaReadE panicked on x=0 y=0 with: pimit-test: test panic", func() {
+		ParallelRgbaFindFirst(mockWhiteImageRgba(), predicate, WithWorkers(1))
+	})
+
+	assert.Panics(t, func() { ParallelRgbaAny(mockWhiteImageRgba(), predicate) })
+	assert.Panics(t, func() { ParallelRgbaAll(mockWhiteImageRgba(), predicate) })
+}
@@ -0,0 +1,715 @@
+package pimit
+
+import "image"
+
+type (
+	FloatReadDelegate               = func(x, y int, r, g, b, a float32)
+	FloatReadErrorableDelegate      = func(x, y int, r, g, b, a float32) error
+	FloatReadWriteDelegate          = func(x, y int, r, g, b, a float32) (float32, float32, float32, float32)
+	FloatReadWriteErrorableDelegate = func(x, y int, r, g, b, a float32) (float32, float32, float32, float32, error)
+)
+
+// Perform a parallel iteration of the pixels of the provided float image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as float32) and coordinates. The rows are split into chunks processed
+// by a bounded number of worker goroutines.
+func ParallelFloatRead(src *FloatImage, d FloatReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelFloatRead", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int     = bounds.Min.Y + i
+			baseIndex  int     = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a float32 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			r = src.Pix[baseIndex+0]
+			g = src.Pix[baseIndex+1]
+			b = src.Pix[baseIndex+2]
+			a = src.Pix[baseIndex+3]
+
+			d(xIndex-originX, yIndex-originY, r, g, b, a)
+			baseIndex += 4
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided float image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as float32) and coordinates. The rows are split into chunks processed
+// by a bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned.
+func ParallelFloatReadE(src *FloatImage, d FloatReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelFloatReadE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int     = bounds.Min.Y + i
+			baseIndex  int     = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a float32 = 0, 0, 0, 0
+			err        error   = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r = src.Pix[baseIndex+0]
+			g = src.Pix[baseIndex+1]
+			b = src.Pix[baseIndex+2]
+			a = src.Pix[baseIndex+3]
+
+			if err = d(xIndex-originX, yIndex-originY, r, g, b, a); err != nil && it.fail(p, err) {
+				return
+			}
+
+			baseIndex += 4
+		}
+	})
+
+	return it.err()
+}
+
+// Perform a parallel iteration of the pixels of the provided float image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as float32) and coordinates, the delegate return color will be set at
+// the given coordinates. This changes will be applied to the passed image instance. Consider using ParallelReadWriteNew
+// if you want to avoid changes to the original image at the expense of additional allocations. The rows are split into
+// chunks processed by a bounded number of worker goroutines.
+func ParallelFloatReadWrite(src *FloatImage, d FloatReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelFloatReadWrite", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int     = bounds.Min.Y + i
+			baseIndex  int     = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a float32 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			r = src.Pix[baseIndex+0]
+			g = src.Pix[baseIndex+1]
+			b = src.Pix[baseIndex+2]
+			a = src.Pix[baseIndex+3]
+
+			r, g, b, a = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			src.Pix[baseIndex+0] = r
+			src.Pix[baseIndex+1] = g
+			src.Pix[baseIndex+2] = b
+			src.Pix[baseIndex+3] = a
+
+			baseIndex += 4
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided float image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as float32) and coordinates, the delegate return color will be set at
+// the given coordinates. This changes will be applied to the passed image instance. Consider using
+// ParallelReadWriteNewE if you want to avoid changes to the original image at the expense of additional allocations.
+// The rows are split into chunks processed by a bounded number of worker goroutines. The iteration will break after the
+// first error occurs and the error will be returned.
+func ParallelFloatReadWriteE(src *FloatImage, d FloatReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelFloatReadWriteE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int     = bounds.Min.Y + i
+			baseIndex  int     = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a float32 = 0, 0, 0, 0
+			err        error   = nil
+		)
+
+		if o.atomic {
+			rowIndex, saved := baseIndex, append([]float32(nil), src.Pix[baseIndex:baseIndex+4*bounds.Dx()]...)
+			it.record(func() {
+				copy(src.Pix[rowIndex:], saved)
+			})
+		}
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r = src.Pix[baseIndex+0]
+			g = src.Pix[baseIndex+1]
+			b = src.Pix[baseIndex+2]
+			a = src.Pix[baseIndex+3]
+
+			r, g, b, a, err = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				baseIndex += 4
+				continue
+			}
+
+			src.Pix[baseIndex+0] = r
+			src.Pix[baseIndex+1] = g
+			src.Pix[baseIndex+2] = b
+			src.Pix[baseIndex+3] = a
+
+			baseIndex += 4
+		}
+	})
+
+	return it.commit()
+}
+
+// Perform a parallel iteration of the pixels of the provided float image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as float32) and coordinates, the delegate return color will be set at
+// the given coordinates. This changes will be applied to a new image instance which internaly uses the float color
+// space and is returned by the function. The rows are split into chunks processed by a bounded number of worker
+// goroutines.
+func ParallelFloatReadWriteNew(src *FloatImage, d FloatReadWriteDelegate, opts ...Option) *FloatImage {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := NewFloatImage(bounds)
+
+	it := o.newIteration("ParallelFloatReadWriteNew", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int     = bounds.Min.Y + i
+			srcIndex   int     = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int     = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a float32 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			r = src.Pix[srcIndex+0]
+			g = src.Pix[srcIndex+1]
+			b = src.Pix[srcIndex+2]
+			a = src.Pix[srcIndex+3]
+
+			r, g, b, a = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			dst.Pix[dstIndex+0] = r
+			dst.Pix[dstIndex+1] = g
+			dst.Pix[dstIndex+2] = b
+			dst.Pix[dstIndex+3] = a
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel iteration of the pixels of the provided float image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as float32) and coordinates, the delegate return color will be set at
+// the given coordinates. This changes will be applied to a new image instance which internaly uses the float color
+// space and is returned by the function. The rows are split into chunks processed by a bounded number of worker
+// goroutines. The iteration will break after the first error occurs and the error will be returned.
+func ParallelFloatReadWriteNewE(src *FloatImage, d FloatReadWriteErrorableDelegate, opts ...Option) (*FloatImage, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := NewFloatImage(bounds)
+
+	it := o.newIteration("ParallelFloatReadWriteNewE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int     = bounds.Min.Y + i
+			srcIndex   int     = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int     = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a float32 = 0, 0, 0, 0
+			err        error   = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r = src.Pix[srcIndex+0]
+			g = src.Pix[srcIndex+1]
+			b = src.Pix[srcIndex+2]
+			a = src.Pix[srcIndex+3]
+
+			r, g, b, a, err = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				srcIndex += 4
+				dstIndex += 4
+				continue
+			}
+
+			dst.Pix[dstIndex+0] = r
+			dst.Pix[dstIndex+1] = g
+			dst.Pix[dstIndex+2] = b
+			dst.Pix[dstIndex+3] = a
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	if err := it.err(); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
+
+// Perform a parallel conversion of the provided RGBA image to a new float image. The colors are divided by the alpha
+// channel, because the float image colors are not premultiplied, and the sRGB transfer function is decoded if the
+// WithSrgbLinearization option is used. The rows are split into chunks processed by a bounded number of worker
+// goroutines.
+func ParallelRgbaToFloat(src *image.RGBA, opts ...Option) *FloatImage {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := NewFloatImage(bounds)
+
+	it := o.newIteration("ParallelRgbaToFloat", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int     = bounds.Min.Y + i
+			srcIndex int     = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int     = dst.PixOffset(bounds.Min.X, yIndex)
+			a        float32 = 0
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			if a = float32(src.Pix[srcIndex+3]); a != 0 {
+				dst.Pix[dstIndex+0] = decodeChannel(float32(src.Pix[srcIndex+0])/a, o.linear)
+				dst.Pix[dstIndex+1] = decodeChannel(float32(src.Pix[srcIndex+1])/a, o.linear)
+				dst.Pix[dstIndex+2] = decodeChannel(float32(src.Pix[srcIndex+2])/a, o.linear)
+				dst.Pix[dstIndex+3] = a / 0xff
+			}
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel conversion of the provided NRGBA image to a new float image. The sRGB transfer function is
+// decoded if the WithSrgbLinearization option is used. The rows are split into chunks processed by a bounded number of
+// worker goroutines.
+func ParallelNrgbaToFloat(src *image.NRGBA, opts ...Option) *FloatImage {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := NewFloatImage(bounds)
+
+	it := o.newIteration("ParallelNrgbaToFloat", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int = bounds.Min.Y + i
+			srcIndex int = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int = dst.PixOffset(bounds.Min.X, yIndex)
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			dst.Pix[dstIndex+0] = decodeChannel(float32(src.Pix[srcIndex+0])/0xff, o.linear)
+			dst.Pix[dstIndex+1] = decodeChannel(float32(src.Pix[srcIndex+1])/0xff, o.linear)
+			dst.Pix[dstIndex+2] = decodeChannel(float32(src.Pix[srcIndex+2])/0xff, o.linear)
+			dst.Pix[dstIndex+3] = float32(src.Pix[srcIndex+3]) / 0xff
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel conversion of the provided RGBA64 image to a new float image. The colors are divided by the alpha
+// channel, because the float image colors are not premultiplied, and the sRGB transfer function is decoded if the
+// WithSrgbLinearization option is used. The rows are split into chunks processed by a bounded number of worker
+// goroutines.
+func ParallelRgba64ToFloat(src *image.RGBA64, opts ...Option) *FloatImage {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := NewFloatImage(bounds)
+
+	it := o.newIteration("ParallelRgba64ToFloat", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int     = bounds.Min.Y + i
+			srcIndex   int     = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int     = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16  = 0, 0, 0, 0
+			alpha      float32 = 0
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			r = uint16(src.Pix[srcIndex+0])<<8 | uint16(src.Pix[srcIndex+1])
+			g = uint16(src.Pix[srcIndex+2])<<8 | uint16(src.Pix[srcIndex+3])
+			b = uint16(src.Pix[srcIndex+4])<<8 | uint16(src.Pix[srcIndex+5])
+			a = uint16(src.Pix[srcIndex+6])<<8 | uint16(src.Pix[srcIndex+7])
+
+			if alpha = float32(a); a != 0 {
+				dst.Pix[dstIndex+0] = decodeChannel(float32(r)/alpha, o.linear)
+				dst.Pix[dstIndex+1] = decodeChannel(float32(g)/alpha, o.linear)
+				dst.Pix[dstIndex+2] = decodeChannel(float32(b)/alpha, o.linear)
+				dst.Pix[dstIndex+3] = alpha / 0xffff
+			}
+
+			srcIndex += 8
+			dstIndex += 4
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel conversion of the provided NRGBA64 image to a new float image. The sRGB transfer function is
+// decoded if the WithSrgbLinearization option is used. The rows are split into chunks processed by a bounded number of
+// worker goroutines.
+func ParallelNrgba64ToFloat(src *image.NRGBA64, opts ...Option) *FloatImage {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := NewFloatImage(bounds)
+
+	it := o.newIteration("ParallelNrgba64ToFloat", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			srcIndex   int    = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int    = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			r = uint16(src.Pix[srcIndex+0])<<8 | uint16(src.Pix[srcIndex+1])
+			g = uint16(src.Pix[srcIndex+2])<<8 | uint16(src.Pix[srcIndex+3])
+			b = uint16(src.Pix[srcIndex+4])<<8 | uint16(src.Pix[srcIndex+5])
+			a = uint16(src.Pix[srcIndex+6])<<8 | uint16(src.Pix[srcIndex+7])
+
+			dst.Pix[dstIndex+0] = decodeChannel(float32(r)/0xffff, o.linear)
+			dst.Pix[dstIndex+1] = decodeChannel(float32(g)/0xffff, o.linear)
+			dst.Pix[dstIndex+2] = decodeChannel(float32(b)/0xffff, o.linear)
+			dst.Pix[dstIndex+3] = float32(a) / 0xffff
+
+			srcIndex += 8
+			dstIndex += 4
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel conversion of the provided float image to a new RGBA image. The channels are clamped to the
+// nominal range, the sRGB transfer function is encoded if the WithSrgbLinearization option is used and the colors are
+// premultiplied by the alpha channel. The rows are split into chunks processed by a bounded number of worker
+// goroutines.
+func ParallelFloatToRgba(src *FloatImage, opts ...Option) *image.RGBA {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewRGBA(bounds)
+
+	it := o.newIteration("ParallelFloatToRgba", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int     = bounds.Min.Y + i
+			srcIndex int     = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int     = dst.PixOffset(bounds.Min.X, yIndex)
+			a        float32 = 0
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			a = clampFloat(src.Pix[srcIndex+3]) * 0xff
+
+			dst.Pix[dstIndex+0] = uint8(encodeChannel(src.Pix[srcIndex+0], o.linear)*a + 0.5)
+			dst.Pix[dstIndex+1] = uint8(encodeChannel(src.Pix[srcIndex+1], o.linear)*a + 0.5)
+			dst.Pix[dstIndex+2] = uint8(encodeChannel(src.Pix[srcIndex+2], o.linear)*a + 0.5)
+			dst.Pix[dstIndex+3] = uint8(a + 0.5)
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel conversion of the provided float image to a new NRGBA image. The channels are clamped to the
+// nominal range and the sRGB transfer function is encoded if the WithSrgbLinearization option is used. The rows are
+// split into chunks processed by a bounded number of worker goroutines.
+func ParallelFloatToNrgba(src *FloatImage, opts ...Option) *image.NRGBA {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewNRGBA(bounds)
+
+	it := o.newIteration("ParallelFloatToNrgba", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int = bounds.Min.Y + i
+			srcIndex int = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int = dst.PixOffset(bounds.Min.X, yIndex)
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			dst.Pix[dstIndex+0] = uint8(encodeChannel(src.Pix[srcIndex+0], o.linear)*0xff + 0.5)
+			dst.Pix[dstIndex+1] = uint8(encodeChannel(src.Pix[srcIndex+1], o.linear)*0xff + 0.5)
+			dst.Pix[dstIndex+2] = uint8(encodeChannel(src.Pix[srcIndex+2], o.linear)*0xff + 0.5)
+			dst.Pix[dstIndex+3] = uint8(clampFloat(src.Pix[srcIndex+3])*0xff + 0.5)
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel conversion of the provided float image to a new RGBA64 image. The channels are clamped to the
+// nominal range, the sRGB transfer function is encoded if the WithSrgbLinearization option is used and the colors are
+// premultiplied by the alpha channel. The rows are split into chunks processed by a bounded number of worker
+// goroutines.
+func ParallelFloatToRgba64(src *FloatImage, opts ...Option) *image.RGBA64 {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewRGBA64(bounds)
+
+	it := o.newIteration("ParallelFloatToRgba64", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int     = bounds.Min.Y + i
+			srcIndex   int     = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int     = dst.PixOffset(bounds.Min.X, yIndex)
+			a          float32 = 0
+			r, g, b, w uint16  = 0, 0, 0, 0
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			a = clampFloat(src.Pix[srcIndex+3]) * 0xffff
+
+			r = uint16(encodeChannel(src.Pix[srcIndex+0], o.linear)*a + 0.5)
+			g = uint16(encodeChannel(src.Pix[srcIndex+1], o.linear)*a + 0.5)
+			b = uint16(encodeChannel(src.Pix[srcIndex+2], o.linear)*a + 0.5)
+			w = uint16(a + 0.5)
+
+			dst.Pix[dstIndex+0], dst.Pix[dstIndex+1] = uint8(r>>8), uint8(r)
+			dst.Pix[dstIndex+2], dst.Pix[dstIndex+3] = uint8(g>>8), uint8(g)
+			dst.Pix[dstIndex+4], dst.Pix[dstIndex+5] = uint8(b>>8), uint8(b)
+			dst.Pix[dstIndex+6], dst.Pix[dstIndex+7] = uint8(w>>8), uint8(w)
+
+			srcIndex += 4
+			dstIndex += 8
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel conversion of the provided float image to a new NRGBA64 image. The channels are clamped to the
+// nominal range and the sRGB transfer function is encoded if the WithSrgbLinearization option is used. The rows are
+// split into chunks processed by a bounded number of worker goroutines.
+func ParallelFloatToNrgba64(src *FloatImage, opts ...Option) *image.NRGBA64 {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewNRGBA64(bounds)
+
+	it := o.newIteration("ParallelFloatToNrgba64", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			srcIndex   int    = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int    = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			r = uint16(encodeChannel(src.Pix[srcIndex+0], o.linear)*0xffff + 0.5)
+			g = uint16(encodeChannel(src.Pix[srcIndex+1], o.linear)*0xffff + 0.5)
+			b = uint16(encodeChannel(src.Pix[srcIndex+2], o.linear)*0xffff + 0.5)
+			a = uint16(clampFloat(src.Pix[srcIndex+3])*0xffff + 0.5)
+
+			dst.Pix[dstIndex+0], dst.Pix[dstIndex+1] = uint8(r>>8), uint8(r)
+			dst.Pix[dstIndex+2], dst.Pix[dstIndex+3] = uint8(g>>8), uint8(g)
+			dst.Pix[dstIndex+4], dst.Pix[dstIndex+5] = uint8(b>>8), uint8(b)
+			dst.Pix[dstIndex+6], dst.Pix[dstIndex+7] = uint8(a>>8), uint8(a)
+
+			srcIndex += 4
+			dstIndex += 8
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
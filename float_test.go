@@ -0,0 +1,533 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelFloatReadShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelFloatRead(nil, func(x, y int, r, g, b, a float32) {})
+	})
+}
+
+func TestParallelFloatReadShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	assert.Panics(t, func() {
+		ParallelFloatRead(img, nil)
+	})
+}
+
+func TestParallelFloatReadShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	exR, exG, exB, exA := float32(1), float32(1), float32(1), float32(1)
+
+	ParallelFloatRead(img, func(xIndex int, yIndex int, acR, acG, acB, acA float32) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+	})
+}
+
+func TestParallelFloatReadEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelFloatReadE(nil, func(x, y int, r, g, b, a float32) error {
+			return nil
+		})
+	})
+}
+
+func TestParallelFloatReadEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	assert.Panics(t, func() {
+		ParallelFloatReadE(img, nil)
+	})
+}
+
+func TestParallelFloatReadEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	err := ParallelFloatReadE(img, func(x, y int, r, g, b, a float32) error {
+		return errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelFloatReadEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	exR, exG, exB, exA := float32(1), float32(1), float32(1), float32(1)
+
+	err := ParallelFloatReadE(img, func(xIndex, yIndex int, acR, acG, acB, acA float32) error {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestParallelFloatReadWriteShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelFloatReadWrite(nil, func(x int, y int, r float32, g float32, b float32, a float32) (float32, float32, float32, float32) {
+			return r, g, b, a
+		})
+	})
+}
+
+func TestParallelFloatReadWriteShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	assert.Panics(t, func() {
+		ParallelFloatReadWrite(img, nil)
+	})
+}
+
+func TestParallelFloatReadWriteShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	exR, exG, exB, exA := float32(1), float32(1), float32(1), float32(1)
+
+	ParallelFloatReadWrite(img, func(xIndex, yIndex int, acR, acG, acB, acA float32) (float32, float32, float32, float32) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 1
+	})
+
+	expectedImage := mockBlackImageFloat()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.FloatAt(x, y), img.FloatAt(x, y))
+		}
+	}
+}
+
+func TestParallelFloatReadWriteShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageFloat()
+
+	rBlack, gBlack, bBlack, aBlack := float32(0), float32(0), float32(0), float32(0)
+	rWhite, gWhite, bWhite, aWhite := float32(1), float32(1), float32(1), float32(1)
+
+	ParallelFloatReadWrite(image, func(x, y int, rCurrent, gCurrent, bCurrent, aCurrent float32) (float32, float32, float32, float32) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 1, 1, 1, 1
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent
+	})
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := image.FloatAt(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelFloatReadWriteEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelFloatReadWriteE(nil, func(x, y int, r, g, b, a float32) (float32, float32, float32, float32, error) {
+			return r, g, b, a, nil
+		})
+	})
+}
+
+func TestParallelFloatReadWriteEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	assert.Panics(t, func() {
+		ParallelFloatReadWriteE(img, nil)
+	})
+}
+
+func TestParallelFloatReadWriteEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	err := ParallelFloatReadWriteE(img, func(x, y int, r, g, b, a float32) (float32, float32, float32, float32, error) {
+		return r, g, b, a, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelFloatReadWriteEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	exR, exG, exB, exA := float32(1), float32(1), float32(1), float32(1)
+
+	ParallelFloatReadWriteE(img, func(xIndex, yIndex int, acR, acG, acB, acA float32) (float32, float32, float32, float32, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 1, nil
+	})
+
+	expectedImage := mockBlackImageFloat()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.FloatAt(x, y), img.FloatAt(x, y))
+		}
+	}
+}
+
+func TestParallelFloatReadWriteEShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageFloat()
+
+	rBlack, gBlack, bBlack, aBlack := float32(0), float32(0), float32(0), float32(0)
+	rWhite, gWhite, bWhite, aWhite := float32(1), float32(1), float32(1), float32(1)
+
+	err := ParallelFloatReadWriteE(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent float32) (float32, float32, float32, float32, error) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 1, 1, 1, 1, nil
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0, nil
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent, nil
+
+	})
+
+	assert.Nil(t, err)
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := image.FloatAt(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelFloatReadWriteNewShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelFloatReadWriteNew(nil, func(x int, y int, r float32, g float32, b float32, a float32) (float32, float32, float32, float32) {
+			return r, g, b, a
+		})
+	})
+}
+
+func TestParallelFloatReadWriteNewShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	assert.Panics(t, func() {
+		ParallelFloatReadWriteNew(img, nil)
+	})
+}
+
+func TestParallelFloatReadWriteNewShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	exR, exG, exB, exA := float32(1), float32(1), float32(1), float32(1)
+
+	actualImage := ParallelFloatReadWriteNew(img, func(xIndex, yIndex int, acR, acG, acB, acA float32) (float32, float32, float32, float32) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 1
+	})
+
+	expectedImage := mockBlackImageFloat()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.FloatAt(x, y), actualImage.FloatAt(x, y))
+		}
+	}
+}
+
+func TestParallelFloatReadWriteNewShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageFloat()
+
+	rBlack, gBlack, bBlack, aBlack := float32(0), float32(0), float32(0), float32(0)
+	rWhite, gWhite, bWhite, aWhite := float32(1), float32(1), float32(1), float32(1)
+
+	actualImage := ParallelFloatReadWriteNew(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent float32) (float32, float32, float32, float32) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 1, 1, 1, 1
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent
+	})
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := actualImage.FloatAt(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelFloatReadWriteNewEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelFloatReadWriteNewE(nil, func(x, y int, r, g, b, a float32) (float32, float32, float32, float32, error) {
+			return r, g, b, a, nil
+		})
+	})
+}
+
+func TestParallelFloatReadWriteNewEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	assert.Panics(t, func() {
+		ParallelFloatReadWriteNewE(img, nil)
+	})
+}
+
+func TestParallelFloatReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	modifiedImg, err := ParallelFloatReadWriteNewE(img, func(x, y int, r, g, b, a float32) (float32, float32, float32, float32, error) {
+		return r, g, b, a, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, modifiedImg)
+}
+
+func TestParallelFloatReadWriteNewEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageFloat()
+
+	exR, exG, exB, exA := float32(1), float32(1), float32(1), float32(1)
+
+	actualImage, err := ParallelFloatReadWriteNewE(img, func(xIndex, yIndex int, acR, acG, acB, acA float32) (float32, float32, float32, float32, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 1, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockBlackImageFloat()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.FloatAt(x, y), actualImage.FloatAt(x, y))
+		}
+	}
+}
+
+func TestParallelFloatReadWriteENewShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageFloat()
+
+	rBlack, gBlack, bBlack, aBlack := float32(0), float32(0), float32(0), float32(0)
+	rWhite, gWhite, bWhite, aWhite := float32(1), float32(1), float32(1), float32(1)
+
+	actualImage, err := ParallelFloatReadWriteNewE(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent float32) (float32, float32, float32, float32, error) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 1, 1, 1, 1, nil
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0, nil
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent, nil
+	})
+
+	assert.Nil(t, err)
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := actualImage.FloatAt(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelFloatFunctionsShouldPreserveOutOfRangeValues(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := NewFloatImage(image.Rect(0, 0, 3, 2))
+	img.SetFloat(1, 1, FloatColor{4.5, -0.25, 0.125, 1})
+
+	ParallelFloatRead(img, func(x, y int, r, g, b, a float32) {
+		if x == 1 && y == 1 {
+			assert.Equal(t, []float32{4.5, -0.25, 0.125, 1}, []float32{r, g, b, a})
+		}
+	})
+
+	dst := ParallelFloatReadWriteNew(img, func(_, _ int, r, g, b, a float32) (float32, float32, float32, float32) {
+		return r * 2, g * 2, b - 1, a
+	})
+
+	ParallelFloatReadWrite(img, func(_, _ int, r, g, b, a float32) (float32, float32, float32, float32) {
+		return r * 2, g * 2, b - 1, a
+	})
+
+	assert.Equal(t, FloatColor{9, -0.5, -0.875, 1}, img.FloatAt(1, 1))
+	assert.Equal(t, FloatColor{0, 0, -1, 0}, img.FloatAt(0, 0))
+	assert.Equal(t, img.Pix, dst.Pix)
+}
+
+func mockWhiteImageFloat() *FloatImage {
+	width, height := 5, 6
+
+	img := NewFloatImage(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	return img
+}
+
+func mockBlackImageFloat() *FloatImage {
+	width, height := 5, 6
+
+	img := NewFloatImage(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Black)
+		}
+	}
+
+	return img
+}
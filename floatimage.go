@@ -0,0 +1,207 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// FloatColor represents a color with the R, G, B and A channels stored as float32 values. The colors are not
+// premultiplied by the alpha channel and the nominal range of the channels is [0, 1], however the values outside of
+// the range are preserved, so the intermediate results of a processing chain are neither clamped nor quantized.
+type FloatColor struct {
+	R, G, B, A float32
+}
+
+// Return the alpha-premultiplied color with the channels in range [0, 0xffff]. The channels are clamped to the nominal
+// range before the conversion.
+func (c FloatColor) RGBA() (uint32, uint32, uint32, uint32) {
+	a := clampFloat(c.A)
+
+	r := uint32(clampFloat(c.R)*a*0xffff + 0.5)
+	g := uint32(clampFloat(c.G)*a*0xffff + 0.5)
+	b := uint32(clampFloat(c.B)*a*0xffff + 0.5)
+
+	return r, g, b, uint32(a*0xffff + 0.5)
+}
+
+// The color model of the float images. The conversion does not apply any transfer function to the channels.
+var FloatModel color.Model = color.ModelFunc(floatModel)
+
+func floatModel(c color.Color) color.Color {
+	if fc, ok := c.(FloatColor); ok {
+		return fc
+	}
+
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return FloatColor{0, 0, 0, 0}
+	}
+
+	return FloatColor{
+		R: float32(r) / float32(a),
+		G: float32(g) / float32(a),
+		B: float32(b) / float32(a),
+		A: float32(a) / 0xffff,
+	}
+}
+
+// FloatImage is an in-memory image whose At method returns FloatColor values. The channels of the pixels are stored
+// interleaved in the R, G, B, A order, which means that the Pix slice layout is the same as the layout of the
+// image.NRGBA image, except that the Stride is expressed in float32 values instead of bytes.
+type FloatImage struct {
+	// Pix holds the image's pixels, in R, G, B, A order. The pixel at (x, y) starts at
+	// Pix[(y-Rect.Min.Y)*Stride + (x-Rect.Min.X)*4].
+	Pix []float32
+	// Stride is the Pix stride (in float32 values) between vertically adjacent pixels.
+	Stride int
+	// Rect is the image's bounds.
+	Rect image.Rectangle
+}
+
+// Return a new float image with the provided bounds.
+func NewFloatImage(r image.Rectangle) *FloatImage {
+	w, h := r.Dx(), r.Dy()
+	if w < 0 || h < 0 || (w > 0 && h > math.MaxInt/4/w) {
+		panic("pimit: the provided image bounds are invalid")
+	}
+
+	return &FloatImage{
+		Pix:    make([]float32, 4*w*h),
+		Stride: 4 * w,
+		Rect:   r,
+	}
+}
+
+func (p *FloatImage) ColorModel() color.Model { return FloatModel }
+
+func (p *FloatImage) Bounds() image.Rectangle { return p.Rect }
+
+func (p *FloatImage) At(x, y int) color.Color {
+	return p.FloatAt(x, y)
+}
+
+func (p *FloatImage) FloatAt(x, y int) FloatColor {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return FloatColor{}
+	}
+
+	i := p.PixOffset(x, y)
+	s := p.Pix[i : i+4 : i+4]
+	return FloatColor{s[0], s[1], s[2], s[3]}
+}
+
+// PixOffset returns the index of the first element of Pix that corresponds to the pixel at (x, y).
+func (p *FloatImage) PixOffset(x, y int) int {
+	return (y-p.Rect.Min.Y)*p.Stride + (x-p.Rect.Min.X)*4
+}
+
+func (p *FloatImage) Set(x, y int, c color.Color) {
+	p.SetFloat(x, y, FloatModel.Convert(c).(FloatColor))
+}
+
+func (p *FloatImage) SetFloat(x, y int, c FloatColor) {
+	if !(image.Point{x, y}.In(p.Rect)) {
+		return
+	}
+
+	i := p.PixOffset(x, y)
+	s := p.Pix[i : i+4 : i+4]
+	s[0], s[1], s[2], s[3] = c.R, c.G, c.B, c.A
+}
+
+// SubImage returns an image representing the portion of the image p visible through r. The returned value shares
+// pixels with the original image.
+func (p *FloatImage) SubImage(r image.Rectangle) image.Image {
+	r = r.Intersect(p.Rect)
+	if r.Empty() {
+		return &FloatImage{}
+	}
+
+	i := p.PixOffset(r.Min.X, r.Min.Y)
+	return &FloatImage{
+		Pix:    p.Pix[i:],
+		Stride: p.Stride,
+		Rect:   r,
+	}
+}
+
+// Opaque scans the entire image and reports whether it is fully opaque.
+func (p *FloatImage) Opaque() bool {
+	if p.Rect.Empty() {
+		return true
+	}
+
+	i0, i1 := 3, p.Rect.Dx()*4
+	for y := p.Rect.Min.Y; y < p.Rect.Max.Y; y += 1 {
+		for i := i0; i < i1; i += 4 {
+			if !(p.Pix[i] >= 1) {
+				return false
+			}
+		}
+
+		i0 += p.Stride
+		i1 += p.Stride
+	}
+
+	return true
+}
+
+// Decode the sRGB transfer function of the channels when converting an image to a float image and encode it when
+// converting a float image to another image, so the float image holds the linear light intensities, which are suitable
+// for the physically based operations like exposure adjustment or blurring. The alpha channel is never affected. The
+// option has no effect on the remaining functions.
+func WithSrgbLinearization() Option {
+	return func(o *options) {
+		o.linear = true
+	}
+}
+
+// Convert the sRGB encoded channel value in range [0, 1] to the linear light intensity.
+func srgbToLinear(v float32) float32 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+
+	return float32(math.Pow((float64(v)+0.055)/1.055, 2.4))
+}
+
+// Convert the linear light intensity in range [0, 1] to the sRGB encoded channel value.
+func linearToSrgb(v float32) float32 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+
+	return float32(1.055*math.Pow(float64(v), 1/2.4) - 0.055)
+}
+
+// Clamp the channel value to the nominal range [0, 1]. The NaN values are mapped to zero.
+func clampFloat(v float32) float32 {
+	if v > 1 {
+		return 1
+	}
+
+	if v > 0 {
+		return v
+	}
+
+	return 0
+}
+
+// Decode the sRGB transfer function of the channel value read from an image if the linearization is enabled.
+func decodeChannel(v float32, linear bool) float32 {
+	if linear {
+		return srgbToLinear(v)
+	}
+
+	return v
+}
+
+// Clamp the channel value written to an image and encode the sRGB transfer function if the linearization is enabled.
+func encodeChannel(v float32, linear bool) float32 {
+	if v = clampFloat(v); linear {
+		return linearToSrgb(v)
+	}
+
+	return v
+}
@@ -0,0 +1,183 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestFloatImageShouldStoreColorsWithoutPremultiplication(t *testing.T) {
+	img := NewFloatImage(image.Rect(-2, 3, 4, 8))
+
+	img.Set(-1, 4, color.RGBA{100, 50, 0, 200})
+	img.SetFloat(3, 7, FloatColor{2, -1, 0.5, 1})
+	img.SetFloat(4, 8, FloatColor{1, 1, 1, 1})
+
+	assert.Equal(t, FloatColor{0.5, 0.25, 0, float32(0xc8c8) / 0xffff}, img.FloatAt(-1, 4))
+	assert.Equal(t, FloatColor{2, -1, 0.5, 1}, img.At(3, 7))
+	assert.Equal(t, FloatColor{}, img.At(4, 8))
+	assert.Equal(t, 24, img.Stride)
+	assert.Len(t, img.Pix, 4*6*5)
+}
+
+func TestFloatColorShouldClampAndPremultiplyChannels(t *testing.T) {
+	r, g, b, a := FloatColor{2, -1, 0.5, 0.5}.RGBA()
+
+	assert.Equal(t, []uint32{0x8000, 0, 0x4000, 0x8000}, []uint32{r, g, b, a})
+	assert.Equal(t, color.RGBA64{0x8000, 0, 0x4000, 0x8000}, color.RGBA64Model.Convert(FloatColor{2, -1, 0.5, 0.5}))
+	assert.Equal(t, FloatColor{}, FloatModel.Convert(color.Transparent))
+	assert.Equal(t, FloatColor{1, 1, 1, 1}, FloatModel.Convert(color.White))
+}
+
+func TestFloatImageShouldShareSubImagePixels(t *testing.T) {
+	img := NewFloatImage(image.Rect(0, 0, 4, 4))
+
+	sub := img.SubImage(image.Rect(1, 1, 3, 5)).(*FloatImage)
+	sub.SetFloat(2, 3, FloatColor{0.1, 0.2, 0.3, 0.4})
+
+	assert.Equal(t, image.Rect(1, 1, 3, 4), sub.Bounds())
+	assert.Equal(t, FloatColor{0.1, 0.2, 0.3, 0.4}, img.FloatAt(2, 3))
+	assert.True(t, img.SubImage(image.Rect(5, 5, 6, 6)).Bounds().Empty())
+}
+
+func TestFloatImageShouldReportOpaque(t *testing.T) {
+	img := NewFloatImage(image.Rect(0, 0, 3, 2))
+	assert.False(t, img.Opaque())
+
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	assert.True(t, img.Opaque())
+
+	img.SetFloat(2, 1, FloatColor{1, 1, 1, 0.99})
+	assert.False(t, img.Opaque())
+	assert.True(t, img.SubImage(image.Rect(0, 0, 2, 2)).(*FloatImage).Opaque())
+}
+
+func TestNewFloatImageShouldPanicOnInvalidBounds(t *testing.T) {
+	assert.Panics(t, func() {
+		NewFloatImage(image.Rectangle{Min: image.Pt(4, 0), Max: image.Pt(0, 4)})
+	})
+}
+
+func TestFloatConversionsShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() { ParallelRgbaToFloat(nil) })
+	assert.Panics(t, func() { ParallelNrgbaToFloat(nil) })
+	assert.Panics(t, func() { ParallelRgba64ToFloat(nil) })
+	assert.Panics(t, func() { ParallelNrgba64ToFloat(nil) })
+	assert.Panics(t, func() { ParallelFloatToRgba(nil) })
+	assert.Panics(t, func() { ParallelFloatToNrgba(nil) })
+	assert.Panics(t, func() { ParallelFloatToRgba64(nil) })
+	assert.Panics(t, func() { ParallelFloatToNrgba64(nil) })
+}
+
+func TestFloatConversionsShouldRoundTrip8BitImages(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	nrgba := image.NewNRGBA(image.Rect(-3, 5, 253, 7))
+	for x := nrgba.Rect.Min.X; x < nrgba.Rect.Max.X; x += 1 {
+		nrgba.SetNRGBA(x, 5, color.NRGBA{uint8(x), uint8(x + 85), uint8(x + 170), 255})
+		nrgba.SetNRGBA(x, 6, color.NRGBA{uint8(x + 3), 0, 255, uint8(x + 3)})
+	}
+
+	for _, opts := range [][]Option{nil, {WithSrgbLinearization()}} {
+		assert.Equal(t, nrgba, ParallelFloatToNrgba(ParallelNrgbaToFloat(nrgba, opts...), opts...))
+	}
+
+	rgba := image.NewRGBA(nrgba.Rect)
+	draw.Draw(rgba, rgba.Rect, nrgba, nrgba.Rect.Min, draw.Src)
+
+	for _, opts := range [][]Option{nil, {WithSrgbLinearization()}} {
+		assert.Equal(t, rgba, ParallelFloatToRgba(ParallelRgbaToFloat(rgba, opts...), opts...))
+	}
+}
+
+func TestFloatConversionsShouldRoundTrip16BitImages(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	nrgba := image.NewNRGBA64(image.Rect(0, 0, 64, 2))
+	for x := 0; x < 64; x += 1 {
+		v := uint16(x * 1031)
+
+		nrgba.SetNRGBA64(x, 0, color.NRGBA64{v, ^v, v / 2, 0xffff})
+		nrgba.SetNRGBA64(x, 1, color.NRGBA64{v, ^v, v / 2, v})
+	}
+
+	for _, opts := range [][]Option{nil, {WithSrgbLinearization()}} {
+		assert.Equal(t, nrgba, ParallelFloatToNrgba64(ParallelNrgba64ToFloat(nrgba, opts...), opts...))
+	}
+
+	rgba := image.NewRGBA64(nrgba.Rect)
+	draw.Draw(rgba, rgba.Rect, nrgba, image.Point{}, draw.Src)
+
+	assert.Equal(t, rgba, ParallelFloatToRgba64(ParallelRgba64ToFloat(rgba)))
+}
+
+func TestFloatConversionsShouldUnpremultiplyColors(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	rgba := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	rgba.SetRGBA(0, 0, color.RGBA{100, 50, 0, 200})
+
+	img := ParallelRgbaToFloat(rgba)
+	assert.Equal(t, FloatColor{0.5, 0.25, 0, float32(200) / 0xff}, img.FloatAt(0, 0))
+	assert.Equal(t, FloatColor{0, 0, 0, 0}, img.FloatAt(1, 0))
+
+	rgba64 := image.NewRGBA64(image.Rect(0, 0, 1, 1))
+	rgba64.SetRGBA64(0, 0, color.RGBA64{0x4000, 0x2000, 0, 0x8000})
+
+	assert.Equal(t, FloatColor{0.5, 0.25, 0, float32(0x8000) / 0xffff}, ParallelRgba64ToFloat(rgba64).FloatAt(0, 0))
+}
+
+func TestFloatConversionsShouldClampOutOfRangeChannels(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := NewFloatImage(image.Rect(0, 0, 1, 1))
+	img.SetFloat(0, 0, FloatColor{4, -2, 0.5, 2})
+
+	assert.Equal(t, color.RGBA{255, 0, 128, 255}, ParallelFloatToRgba(img).RGBAAt(0, 0))
+	assert.Equal(t, color.NRGBA{255, 0, 128, 255}, ParallelFloatToNrgba(img).NRGBAAt(0, 0))
+	assert.Equal(t, color.RGBA64{0xffff, 0, 0x8000, 0xffff}, ParallelFloatToRgba64(img).RGBA64At(0, 0))
+	assert.Equal(t, color.NRGBA64{0xffff, 0, 0x8000, 0xffff}, ParallelFloatToNrgba64(img).NRGBA64At(0, 0))
+}
+
+func TestFloatConversionsShouldApplySrgbTransferFunction(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	nrgba := image.NewNRGBA(image.Rect(0, 0, 3, 1))
+	nrgba.SetNRGBA(0, 0, color.NRGBA{0, 10, 128, 255})
+	nrgba.SetNRGBA(1, 0, color.NRGBA{188, 255, 255, 64})
+
+	img := ParallelNrgbaToFloat(nrgba, WithSrgbLinearization())
+
+	c := img.FloatAt(0, 0)
+	assert.Equal(t, float32(0), c.R)
+	assert.InDelta(t, 0.003035, c.G, 1e-6)
+	assert.InDelta(t, 0.215861, c.B, 1e-6)
+
+	c = img.FloatAt(1, 0)
+	assert.InDelta(t, 0.502886, c.R, 1e-6)
+	assert.Equal(t, float32(1), c.G)
+	assert.Equal(t, float32(64)/0xff, c.A)
+
+	img.SetFloat(2, 0, FloatColor{0.5, 0.002, 0.18, 1})
+
+	assert.Equal(t, color.NRGBA{188, 7, 118, 255}, ParallelFloatToNrgba(img, WithSrgbLinearization()).NRGBAAt(2, 0))
+	assert.Equal(t, color.NRGBA{128, 1, 46, 255}, ParallelFloatToNrgba(img).NRGBAAt(2, 0))
+}
+
+func TestFloatConversionsShouldNotDependOnRelativeCoordinates(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(7, -4, 19, 9)
+
+	img := ParallelRgbaToFloat(mockCoordinateImageRgba(bounds), WithRelativeCoordinates(), WithWorkers(3))
+	assert.Equal(t, bounds, img.Bounds())
+
+	assertUntouchedCoordinateImage(t, ParallelFloatToRgba(img, WithRelativeCoordinates()), image.Rectangle{})
+	assertUntouchedCoordinateImage(t, ParallelFloatToNrgba64(img, WithChunkSize(1)), image.Rectangle{})
+}
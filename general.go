@@ -1,12 +1,9 @@
 package pimit
 
 import (
-	"context"
-	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
-	"sync"
 )
 
 type (
@@ -18,8 +15,9 @@ type (
 )
 
 // Perform a parallel iteration of the indexes according to the width and height provided via the parameters.
-// Execute the delegate for each indexes combination. Each row is iterated in a separate goroutine.
-func ParallelIndices(w, h int, d IndicesDelegate) {
+// Execute the delegate for each indexes combination. The rows are split into chunks processed by a bounded number
+// of worker goroutines.
+func ParallelIndices(w, h int, d IndicesDelegate, opts ...Option) {
 	if w <= 0 {
 		panic("pimit: the provided nagative or zero width is invalid")
 	}
@@ -28,25 +26,25 @@ func ParallelIndices(w, h int, d IndicesDelegate) {
 		panic("pimit: the provided negative or zero height is invalid")
 	}
 
-	wg := &sync.WaitGroup{}
+	it := newOptions(opts).newIteration("ParallelIndices", false)
+	defer it.cancel()
 
-	for y := 0; y < h; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
-
-			for xIndex := 0; xIndex < w; xIndex += 1 {
-				d(xIndex, yIndex)
-			}
-		}(y)
-	}
+	it.schedule(h, func(yIndex int, p *cursor) {
+		p.y = yIndex
+		for xIndex := 0; xIndex < w; xIndex += 1 {
+			p.x = xIndex
+			d(xIndex, yIndex)
+		}
+	})
 
-	wg.Wait()
+	it.repanic()
 }
 
 // Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
-// allowing you to read the color and coordinates. Each row is iterated in a separate goroutine.
-func ParallelRead(src image.Image, d ReadDelegate) {
+// allowing you to read the color and coordinates. The rows are split into chunks processed by a bounded number of
+// worker goroutines. The *image.RGBA and *image.NRGBA images are accessed directly, without the image.At and
+// draw.Image.Set calls.
+func ParallelRead(src image.Image, d ReadDelegate, opts ...Option) {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -55,31 +53,49 @@ func ParallelRead(src image.Image, d ReadDelegate) {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	wg := &sync.WaitGroup{}
+	switch img := src.(type) {
+	case *image.RGBA:
+		ParallelRgbaRead(img, func(x, y int, r, g, b, a uint8) {
+			d(x, y, color.RGBA{r, g, b, a})
+		}, appendOptions(opts, withOperation("ParallelRead"))...)
+		return
+	case *image.NRGBA:
+		ParallelNrgbaRead(img, func(x, y int, r, g, b, a uint8) {
+			d(x, y, color.NRGBA{r, g, b, a})
+		}, appendOptions(opts, withOperation("ParallelRead"))...)
+		return
+	}
 
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
 
-			var c color.Color = nil
+	it := o.newIteration("ParallelRead", false)
+	defer it.cancel()
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				c = src.At(xIndex, yIndex)
-				d(xIndex, yIndex, c)
-			}
-		}(y)
-	}
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex int         = bounds.Min.Y + i
+			c      color.Color = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			c = src.At(xIndex, yIndex)
+			d(xIndex-originX, yIndex-originY, c)
+		}
+	})
 
-	wg.Wait()
+	it.repanic()
 }
 
 // Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
-// allowing you to read the color and coordinates. Each row is iterated in a separate goroutine. The iteration will
-// break after the first error occurs and the error will be returned.
-func ParallelReadE(src image.Image, d ReadErrorableDelegate) error {
+// allowing you to read the color and coordinates. The rows are split into chunks processed by a bounded number of
+// worker goroutines. The iteration will break after the first error occurs and the error will be returned. The
+// *image.RGBA and *image.NRGBA images are accessed directly, without the image.At and draw.Image.Set calls.
+func ParallelReadE(src image.Image, d ReadErrorableDelegate, opts ...Option) error {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -88,51 +104,55 @@ func ParallelReadE(src image.Image, d ReadErrorableDelegate) error {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	wg := &sync.WaitGroup{}
-
-	errt := NewErrorTrap()
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
-
-			var (
-				c   color.Color = nil
-				err error       = nil
-			)
+	switch img := src.(type) {
+	case *image.RGBA:
+		return ParallelRgbaReadE(img, func(x, y int, r, g, b, a uint8) error {
+			return d(x, y, color.RGBA{r, g, b, a})
+		}, appendOptions(opts, withOperation("ParallelReadE"))...)
+	case *image.NRGBA:
+		return ParallelNrgbaReadE(img, func(x, y int, r, g, b, a uint8) error {
+			return d(x, y, color.NRGBA{r, g, b, a})
+		}, appendOptions(opts, withOperation("ParallelReadE"))...)
+	}
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-				}
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelReadE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex int         = bounds.Min.Y + i
+			c      color.Color = nil
+			err    error       = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
 
-				c = src.At(xIndex, yIndex)
-				if err = d(xIndex, yIndex, c); err != nil {
-					errt.Set(fmt.Errorf("pimit: delegate function failed on x=%d y=%d with: %w", xIndex, yIndex, err))
-					cancel()
-					return
-				}
+			c = src.At(xIndex, yIndex)
+			if err = d(xIndex-originX, yIndex-originY, c); err != nil && it.fail(p, err) {
+				return
 			}
-		}(y)
-	}
+		}
+	})
 
-	wg.Wait()
-	return errt.Err()
+	return it.err()
 }
 
 // Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
-// allowing you to read the color and coordinates, the delegate return color will be set at the given coordinates.
-// This changes will be applied to the passed image instance. Consider using ParallelReadWriteNew if you want to
-// avoid changes to the original image at the expense of additional allocations. Each row is iterated in a separate
-// goroutine.
-func ParallelReadWrite(src draw.Image, d ReadWriteDelegate) {
+// allowing you to read the color and coordinates, the delegate return color will be set at the given coordinates. This
+// changes will be applied to the passed image instance. Consider using ParallelReadWriteNew if you want to avoid
+// changes to the original image at the expense of additional allocations. The rows are split into chunks processed by a
+// bounded number of worker goroutines. The *image.RGBA and *image.NRGBA images are accessed directly, without the
+// image.At and draw.Image.Set calls.
+func ParallelReadWrite(src draw.Image, d ReadWriteDelegate, opts ...Option) {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -141,34 +161,55 @@ func ParallelReadWrite(src draw.Image, d ReadWriteDelegate) {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	wg := &sync.WaitGroup{}
+	switch img := src.(type) {
+	case *image.RGBA:
+		ParallelRgbaReadWrite(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+			c := rgbaColor(d(x, y, color.RGBA{r, g, b, a}))
+			return c.R, c.G, c.B, c.A
+		}, appendOptions(opts, withOperation("ParallelReadWrite"))...)
+		return
+	case *image.NRGBA:
+		ParallelNrgbaReadWrite(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+			c := nrgbaColor(d(x, y, color.NRGBA{r, g, b, a}))
+			return c.R, c.G, c.B, c.A
+		}, appendOptions(opts, withOperation("ParallelReadWrite"))...)
+		return
+	}
 
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
 
-			var c color.Color = nil
+	it := o.newIteration("ParallelReadWrite", false)
+	defer it.cancel()
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				c = src.At(xIndex, yIndex)
-				c = d(xIndex, yIndex, c)
-				src.Set(xIndex, yIndex, c)
-			}
-		}(y)
-	}
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex int         = bounds.Min.Y + i
+			c      color.Color = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
 
-	wg.Wait()
+			c = src.At(xIndex, yIndex)
+			c = d(xIndex-originX, yIndex-originY, c)
+			src.Set(xIndex, yIndex, c)
+		}
+	})
+
+	it.repanic()
 }
 
 // Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
-// allowing you to read the color and coordinates, the delegate return color will be set at the given coordinates.
-// This changes will be applied to the passed image instance. Consider using ParallelReadWriteNewE if you want to
-// avoid changes to the original image at the expense of additional allocations. Each row is iterated in a separate
-// goroutine. The iteration will break after the first error occurs and the error will be returned.
-func ParallelReadWriteE(src draw.Image, d ReadWriteErrorableDelegate) error {
+// allowing you to read the color and coordinates, the delegate return color will be set at the given coordinates. This
+// changes will be applied to the passed image instance. Consider using ParallelReadWriteNewE if you want to avoid
+// changes to the original image at the expense of additional allocations. The rows are split into chunks processed by a
+// bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned. The *image.RGBA and *image.NRGBA images are accessed directly, without the image.At and draw.Image.Set
+// calls.
+func ParallelReadWriteE(src draw.Image, d ReadWriteErrorableDelegate, opts ...Option) error {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -177,54 +218,86 @@ func ParallelReadWriteE(src draw.Image, d ReadWriteErrorableDelegate) error {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	wg := &sync.WaitGroup{}
-
-	errt := NewErrorTrap()
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	switch img := src.(type) {
+	case *image.RGBA:
+		return ParallelRgbaReadWriteE(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+			c, err := d(x, y, color.RGBA{r, g, b, a})
+			if err != nil {
+				return r, g, b, a, err
+			}
 
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
+			rgba := rgbaColor(c)
+			return rgba.R, rgba.G, rgba.B, rgba.A, nil
+		}, appendOptions(opts, withOperation("ParallelReadWriteE"))...)
+	case *image.NRGBA:
+		return ParallelNrgbaReadWriteE(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error) {
+			c, err := d(x, y, color.NRGBA{r, g, b, a})
+			if err != nil {
+				return r, g, b, a, err
+			}
 
-			var (
-				c   color.Color = nil
-				err error       = nil
-			)
+			nrgba := nrgbaColor(c)
+			return nrgba.R, nrgba.G, nrgba.B, nrgba.A, nil
+		}, appendOptions(opts, withOperation("ParallelReadWriteE"))...)
+	}
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				select {
-				case <-ctx.Done():
-					return
-				default:
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelReadWriteE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex int           = bounds.Min.Y + i
+			c      color.Color   = nil
+			err    error         = nil
+			saved  []color.Color = nil
+		)
+
+		if o.atomic {
+			it.record(func() {
+				for offset, c := range saved {
+					src.Set(bounds.Min.X+offset, yIndex, c)
 				}
+			})
+		}
 
-				c = src.At(xIndex, yIndex)
-				c, err = d(xIndex, yIndex, c)
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
 
-				if err != nil {
-					errt.Set(fmt.Errorf("pimit: delegate function failed on x=%d y=%d with: %w", xIndex, yIndex, err))
-					cancel()
+			c = src.At(xIndex, yIndex)
+			if o.atomic {
+				saved = append(saved, c)
+			}
+
+			c, err = d(xIndex-originX, yIndex-originY, c)
+
+			if err != nil {
+				if it.fail(p, err) {
 					return
 				}
 
-				src.Set(xIndex, yIndex, c)
+				continue
 			}
-		}(y)
-	}
 
-	wg.Wait()
-	return errt.Err()
+			src.Set(xIndex, yIndex, c)
+		}
+	})
+
+	return it.commit()
 }
 
 // Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
 // allowing you to read the color and coordinates, the delegate return color will be set at the given coordinates.
 // This changes will be applied to the passed image instance. The integer parameter is the number of clustes into
-// which the image will be devided. Each cluster is then iterated in a separate goroutine.
-func ParallelDistributedReadWrite(src draw.Image, c int, d ReadWriteDelegate) {
+// which the image will be devided. The clusters are processed by a bounded number of worker goroutines.
+func ParallelDistributedReadWrite(src draw.Image, c int, d ReadWriteDelegate, opts ...Option) {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -237,53 +310,52 @@ func ParallelDistributedReadWrite(src draw.Image, c int, d ReadWriteDelegate) {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	width := src.Bounds().Dx()
-	height := src.Bounds().Dy()
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	width := bounds.Dx()
+	height := bounds.Dy()
 
 	pCount := width * height
 	cCount := pCount / c
 	cLeft := pCount % c
 
-	wg := &sync.WaitGroup{}
+	it := o.newIteration("ParallelDistributedReadWrite", false)
+	defer it.cancel()
 
-	for offsetFactor := 0; offsetFactor < c; offsetFactor += 1 {
-		cOffset := cCount * offsetFactor
-		cLength := cCount
+	it.schedule(c, func(offsetFactor int, p *cursor) {
+		offset, length := cCount*offsetFactor, cCount
 		if offsetFactor+1 == c {
-			cLength += cLeft
+			length += cLeft
 		}
 
-		wg.Add(1)
-		go func(offset, length int) {
-			defer wg.Done()
-
-			var (
-				xIndex int         = 0
-				yIndex int         = 0
-				c      color.Color = nil
-			)
+		var (
+			xIndex int         = 0
+			yIndex int         = 0
+			c      color.Color = nil
+		)
 
-			for innerOffset := 0; innerOffset < length; innerOffset += 1 {
-				xIndex = (offset + innerOffset) % width
-				yIndex = (offset + innerOffset - xIndex) / width
+		for innerOffset := 0; innerOffset < length; innerOffset += 1 {
+			xIndex = bounds.Min.X + (offset+innerOffset)%width
+			yIndex = bounds.Min.Y + (offset+innerOffset)/width
+			p.x, p.y = xIndex-originX, yIndex-originY
 
-				c = src.At(xIndex, yIndex)
-				c = d(xIndex, yIndex, c)
+			c = src.At(xIndex, yIndex)
+			c = d(xIndex-originX, yIndex-originY, c)
 
-				src.Set(xIndex, yIndex, c)
-			}
-		}(cOffset, cLength)
-	}
+			src.Set(xIndex, yIndex, c)
+		}
+	})
 
-	wg.Wait()
+	it.repanic()
 }
 
 // Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
 // allowing you to read the color and coordinates, the delegate return color will be set at the given coordinates.
 // This changes will be applied to the passed image instance. The integer parameter is the number of clustes into
-// which the image will be devided. Each cluster is then iterated in a separate goroutine. The iteration will break
-// after the first error occurs and the error will be returned.
-func ParallelDistributedReadWriteE(src draw.Image, c int, d ReadWriteErrorableDelegate) error {
+// which the image will be devided. The clusters are processed by a bounded number of worker goroutines. The iteration
+// will break after the first error occurs and the error will be returned.
+func ParallelDistributedReadWriteE(src draw.Image, c int, d ReadWriteErrorableDelegate, opts ...Option) error {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -296,70 +368,81 @@ func ParallelDistributedReadWriteE(src draw.Image, c int, d ReadWriteErrorableDe
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	width := src.Bounds().Dx()
-	height := src.Bounds().Dy()
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	width := bounds.Dx()
+	height := bounds.Dy()
 
 	pCount := width * height
 	cCount := pCount / c
 	cLeft := pCount % c
 
-	wg := &sync.WaitGroup{}
-
-	errt := NewErrorTrap()
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	it := o.newIteration("ParallelDistributedReadWriteE", true)
+	defer it.cancel()
 
-	for offsetFactor := 0; offsetFactor < c; offsetFactor += 1 {
-		cOffset := cCount * offsetFactor
-		cLength := cCount
+	it.schedule(c, func(offsetFactor int, p *cursor) {
+		offset, length := cCount*offsetFactor, cCount
 		if offsetFactor+1 == c {
-			cLength += cLeft
+			length += cLeft
 		}
 
-		wg.Add(1)
-		go func(offset, length int) {
-			defer wg.Done()
-
-			var (
-				xIndex int         = 0
-				yIndex int         = 0
-				c      color.Color = nil
-				err    error       = nil
-			)
-
-			for innerOffset := 0; innerOffset < length; innerOffset += 1 {
-				select {
-				case <-ctx.Done():
-					return
-				default:
+		var (
+			xIndex int           = 0
+			yIndex int           = 0
+			c      color.Color   = nil
+			err    error         = nil
+			saved  []color.Color = nil
+		)
+
+		if o.atomic {
+			it.record(func() {
+				for innerOffset, c := range saved {
+					src.Set(bounds.Min.X+(offset+innerOffset)%width, bounds.Min.Y+(offset+innerOffset)/width, c)
 				}
+			})
+		}
 
-				xIndex = (offset + innerOffset) % width
-				yIndex = (offset + innerOffset - xIndex) / width
+		for innerOffset := 0; innerOffset < length; innerOffset += 1 {
+			xIndex = bounds.Min.X + (offset+innerOffset)%width
+			yIndex = bounds.Min.Y + (offset+innerOffset)/width
+			p.x, p.y = xIndex-originX, yIndex-originY
 
-				c = src.At(xIndex, yIndex)
+			if it.interrupted(p) {
+				return
+			}
 
-				c, err = d(xIndex, yIndex, c)
-				if err != nil {
-					errt.Set(fmt.Errorf("pimit: delegate function failed on x=%d y=%d with: %w", xIndex, yIndex, err))
-					cancel()
+			c = src.At(xIndex, yIndex)
+			if o.atomic {
+				saved = append(saved, c)
+			}
+
+			c, err = d(xIndex-originX, yIndex-originY, c)
+			if err != nil {
+				if it.fail(p, err) {
 					return
 				}
 
-				src.Set(xIndex, yIndex, c)
+				continue
 			}
-		}(cOffset, cLength)
-	}
 
-	wg.Wait()
-	return errt.Err()
+			src.Set(xIndex, yIndex, c)
+		}
+	})
+
+	return it.commit()
 }
 
 // Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
-// allowing you to read the color and coordinates, the delegate return color will be set at the given coordinates.
-// This changes will be applied to a new image instance which internaly uses the NRGBA color space and is returned
-// by the function. Each row is iterated in a separate goroutine.
-func ParallelReadWriteNew(src image.Image, d ReadWriteDelegate) draw.Image {
+// allowing you to read the color and coordinates, the delegate return color will be set at the given coordinates. The
+// changes will be applied to a new image instance which is returned by the function. By default an *image.NRGBA image
+// is allocated, the WithSourceColorModel option allocates an image of the same type as the source image and the
+// WithAllocator and WithDestination options provide a custom one. The rows are split into chunks processed by a bounded
+// number of worker goroutines. The *image.RGBA and *image.NRGBA source images are read directly, without the image.At
+// calls. The colors are stored in the *image.RGBA and *image.NRGBA destination images directly, converted in the same
+// way as by the color.RGBAModel and color.NRGBAModel, while the remaining destination images are modified using the
+// draw.Image.Set method, which converts the colors to their own color model.
+func ParallelReadWriteNew(src image.Image, d ReadWriteDelegate, opts ...Option) draw.Image {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -368,37 +451,61 @@ func ParallelReadWriteNew(src image.Image, d ReadWriteDelegate) draw.Image {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	dst := image.NewNRGBA(image.Rect(0, 0, srcWidth, srcHeight))
-	wg := &sync.WaitGroup{}
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := o.destination(src)
+	set := colorSetter(dst)
+
+	switch img := src.(type) {
+	case *image.RGBA:
+		ParallelRgbaRead(img, func(x, y int, r, g, b, a uint8) {
+			set(x+originX, y+originY, d(x, y, color.RGBA{r, g, b, a}))
+		}, appendOptions(opts, withOperation("ParallelReadWriteNew"))...)
+		return dst
+	case *image.NRGBA:
+		ParallelNrgbaRead(img, func(x, y int, r, g, b, a uint8) {
+			set(x+originX, y+originY, d(x, y, color.NRGBA{r, g, b, a}))
+		}, appendOptions(opts, withOperation("ParallelReadWriteNew"))...)
+		return dst
+	}
 
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
+	it := o.newIteration("ParallelReadWriteNew", false)
+	defer it.cancel()
 
-			var c color.Color = nil
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex int         = bounds.Min.Y + i
+			c      color.Color = nil
+		)
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				c = src.At(xIndex, yIndex)
-				c = d(xIndex, yIndex, c)
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
 
-				dst.Set(xIndex, yIndex, c)
-			}
-		}(y)
-	}
+			c = src.At(xIndex, yIndex)
+			c = d(xIndex-originX, yIndex-originY, c)
+
+			set(xIndex, yIndex, c)
+		}
+	})
+
+	it.repanic()
 
-	wg.Wait()
 	return dst
 }
 
 // Perform a parallel iteration of the pixels of the provided image. For each pixel, execute the delegate function
-// allowing you to read the color and coordinates, the delegate return color will be set at the given coordinates.
-// This changes will be applied to a new image instance which internaly uses the NRGBA color space and is returned
-// by the function. Each row is iterated in a separate goroutine. The iteration will break after the first error
-// occurs and the error will be returned.
-func ParallelReadWriteNewE(src image.Image, d ReadWriteErrorableDelegate) (draw.Image, error) {
+// allowing you to read the color and coordinates, the delegate return color will be set at the given coordinates. The
+// changes will be applied to a new image instance which is returned by the function. By default an *image.NRGBA image
+// is allocated, the WithSourceColorModel option allocates an image of the same type as the source image and the
+// WithAllocator and WithDestination options provide a custom one. The rows are split into chunks processed by a bounded
+// number of worker goroutines. The iteration will break after the first error occurs and the error will be returned.
+// The *image.RGBA and *image.NRGBA source images are read directly, without the image.At calls. The colors are stored
+// in the *image.RGBA and *image.NRGBA destination images directly, converted in the same way as by the color.RGBAModel
+// and color.NRGBAModel, while the remaining destination images are modified using the draw.Image.Set method, which
+// converts the colors to their own color model.
+func ParallelReadWriteNewE(src image.Image, d ReadWriteErrorableDelegate, opts ...Option) (draw.Image, error) {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -407,51 +514,325 @@ func ParallelReadWriteNewE(src image.Image, d ReadWriteErrorableDelegate) (draw.
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	dst := image.NewNRGBA(image.Rect(0, 0, srcWidth, srcHeight))
-	wg := &sync.WaitGroup{}
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := o.destination(src)
+	set := colorSetter(dst)
+
+	var err error = nil
+	switch img := src.(type) {
+	case *image.RGBA:
+		err = ParallelRgbaReadE(img, func(x, y int, r, g, b, a uint8) error {
+			c, err := d(x, y, color.RGBA{r, g, b, a})
+			if err == nil {
+				set(x+originX, y+originY, c)
+			}
 
-	errt := NewErrorTrap()
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+			return err
+		}, appendOptions(opts, withOperation("ParallelReadWriteNewE"))...)
+	case *image.NRGBA:
+		err = ParallelNrgbaReadE(img, func(x, y int, r, g, b, a uint8) error {
+			c, err := d(x, y, color.NRGBA{r, g, b, a})
+			if err == nil {
+				set(x+originX, y+originY, c)
+			}
 
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
+			return err
+		}, appendOptions(opts, withOperation("ParallelReadWriteNewE"))...)
+	default:
+		it := o.newIteration("ParallelReadWriteNewE", true)
+		defer it.cancel()
 
+		it.schedule(bounds.Dy(), func(i int, p *cursor) {
 			var (
-				c   color.Color = nil
-				err error       = nil
+				yIndex int         = bounds.Min.Y + i
+				c      color.Color = nil
+				err    error       = nil
 			)
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				select {
-				case <-ctx.Done():
+			p.y = yIndex - originY
+			for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+				p.x = xIndex - originX
+				if it.interrupted(p) {
 					return
-				default:
 				}
 
 				c = src.At(xIndex, yIndex)
-				c, err = d(xIndex, yIndex, c)
+				c, err = d(xIndex-originX, yIndex-originY, c)
 
 				if err != nil {
-					errt.Set(fmt.Errorf("pimit: delegate function failed on x=%d y=%d with: %w", xIndex, yIndex, err))
-					cancel()
-					return
+					if it.fail(p, err) {
+						return
+					}
+
+					continue
 				}
 
-				dst.Set(xIndex, yIndex, c)
+				set(xIndex, yIndex, c)
 			}
-		}(y)
-	}
+		})
 
-	wg.Wait()
+		err = it.err()
+	}
 
-	if err := errt.Err(); err != nil {
+	if err != nil {
 		return nil, err
 	} else {
 		return dst, nil
 	}
 }
+
+// Perform a parallel reduction of the pixels of the provided image. Every chunk of rows is accumulated into its own
+// accumulator created by the init function, for each pixel the delegate function receives the current accumulator, the
+// coordinates and the color and returns the updated accumulator. The accumulators of the chunks are merged using the
+// combine function in the order of the chunks, so the result does not depend on the timing of the workers. The chunks
+// are derived only from the size of the image, unless the chunk size is specified explicitly using the WithChunkSize
+// option, so the result (e.g. a sum of floats) is reproducible regardless of the number of workers and the machine. The
+// rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelReduce[A any](src image.Image, init func() A, d func(acc A, x, y int, c color.Color) A, combine func(a, b A) A, opts ...Option) A {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if init == nil {
+		panic("pimit: the provided accumulator init function is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	if combine == nil {
+		panic("pimit: the provided accumulator combine function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	r := newReduction[A](o, bounds.Dy())
+
+	it := o.newIteration("ParallelReduce", false)
+	defer it.cancel()
+
+	it.scheduleChunks(bounds.Dy(), func(chunk, lo, hi int, p *cursor) {
+		acc := init()
+		defer func() { r.set(chunk, acc) }()
+
+		for i := lo; i < hi; i += 1 {
+			if it.interrupted(p) {
+				return
+			}
+
+			yIndex := bounds.Min.Y + i
+
+			p.y, p.unit = yIndex-originY, i
+			for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+				p.x = xIndex - originX
+
+				acc = d(acc, xIndex-originX, yIndex-originY, src.At(xIndex, yIndex))
+			}
+		}
+	})
+
+	it.repanic()
+
+	return r.merge(init, combine)
+}
+
+// Perform a parallel iteration of the pixels of the provided image using a worker-local state. The state is created by
+// the init function once per worker goroutine, for each pixel execute the delegate function allowing you to read the
+// state of the worker, the color and coordinates. The optional finalize function is executed with the state when the
+// worker finishes. The state allows to reuse buffers or to accumulate partial results without synchronization. The
+// rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelReadWithState[S any](src image.Image, init func() S, d func(state S, x, y int, c color.Color), finalize func(state S), opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if init == nil {
+		panic("pimit: the provided state init function is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelReadWithState", false)
+	defer it.cancel()
+
+	scheduleWithState(it, bounds.Dy(), init, finalize, func(state S, i int, p *cursor) {
+		var (
+			yIndex int         = bounds.Min.Y + i
+			c      color.Color = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			c = src.At(xIndex, yIndex)
+			d(state, xIndex-originX, yIndex-originY, c)
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided image using a worker-local state. The state is created by
+// the init function once per worker goroutine, for each pixel execute the delegate function allowing you to read the
+// state of the worker, the color and coordinates, the delegate return color will be set at the given coordinates. This
+// changes will be applied to the passed image instance. The optional finalize function is executed with the state
+// when the worker finishes. The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelReadWriteWithState[S any](src draw.Image, init func() S, d func(state S, x, y int, c color.Color) color.Color, finalize func(state S), opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if init == nil {
+		panic("pimit: the provided state init function is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelReadWriteWithState", false)
+	defer it.cancel()
+
+	scheduleWithState(it, bounds.Dy(), init, finalize, func(state S, i int, p *cursor) {
+		var (
+			yIndex int         = bounds.Min.Y + i
+			c      color.Color = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			c = src.At(xIndex, yIndex)
+			c = d(state, xIndex-originX, yIndex-originY, c)
+
+			src.Set(xIndex, yIndex, c)
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided image which are covered by the provided mask. For each
+// pixel with a non-zero mask coverage, execute the delegate function allowing you to read the color and coordinates,
+// the delegate return color will be set at the given coordinates. The mask is sampled at the actual image coordinates
+// and the pixels outside of the mask bounds are skipped. Using the WithMaskBlending option, the returned color is
+// blended with the original color according to the mask coverage. This changes will be applied to the passed image
+// instance. The rows are split into chunks processed by a bounded number of worker goroutines. The *image.RGBA and
+// *image.NRGBA images are accessed directly, without the image.At and draw.Image.Set calls.
+func ParallelMaskedReadWrite(src draw.Image, mask image.Image, d ReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if mask == nil {
+		panic("pimit: the provided mask image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	switch img := src.(type) {
+	case *image.RGBA:
+		ParallelRgbaMaskedReadWrite(img, mask, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+			c := rgbaColor(d(x, y, color.RGBA{r, g, b, a}))
+			return c.R, c.G, c.B, c.A
+		}, appendOptions(opts, withOperation("ParallelMaskedReadWrite"))...)
+		return
+	case *image.NRGBA:
+		ParallelNrgbaMaskedReadWrite(img, mask, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+			c := nrgbaColor(d(x, y, color.NRGBA{r, g, b, a}))
+			return c.R, c.G, c.B, c.A
+		}, appendOptions(opts, withOperation("ParallelMaskedReadWrite"))...)
+		return
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	coverage := maskCoverage(mask)
+
+	it := o.newIteration("ParallelMaskedReadWrite", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex int         = bounds.Min.Y + i
+			c      color.Color = nil
+			m      uint32      = 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			if m = coverage(xIndex, yIndex); m == 0 {
+				continue
+			}
+
+			c = src.At(xIndex, yIndex)
+			if o.blend && m != 0xffff {
+				src.Set(xIndex, yIndex, blendColor(c, d(xIndex-originX, yIndex-originY, c), m))
+			} else {
+				src.Set(xIndex, yIndex, d(xIndex-originX, yIndex-originY, c))
+			}
+		}
+	})
+
+	it.repanic()
+}
+
+// Convert the provided color to the RGBA color. This is equivalent to color.RGBAModel.Convert, but the result is not
+// boxed into the color.Color interface, which avoids an allocation.
+func rgbaColor(c color.Color) color.RGBA {
+	if rgba, ok := c.(color.RGBA); ok {
+		return rgba
+	}
+
+	r, g, b, a := c.RGBA()
+	return color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+}
+
+// Convert the provided color to the NRGBA color. This is equivalent to color.NRGBAModel.Convert, but the result is
+// not boxed into the color.Color interface, which avoids an allocation.
+func nrgbaColor(c color.Color) color.NRGBA {
+	if nrgba, ok := c.(color.NRGBA); ok {
+		return nrgba
+	}
+
+	r, g, b, a := c.RGBA()
+	return nrgbaPremultiplied(r, g, b, a)
+}
+
+// Convert the provided premultiplied 16-bit color channels to the NRGBA color in the same way as color.NRGBAModel.
+func nrgbaPremultiplied(r, g, b, a uint32) color.NRGBA {
+	if a == 0xffff {
+		return color.NRGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), 0xff}
+	}
+
+	if a == 0 {
+		return color.NRGBA{0, 0, 0, 0}
+	}
+
+	r = (r * 0xffff) / a
+	g = (g * 0xffff) / a
+	b = (b * 0xffff) / a
+
+	return color.NRGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+}
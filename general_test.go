@@ -5,6 +5,7 @@ import (
 	"image"
 	"image/color"
 	"image/draw"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -821,6 +822,294 @@ func TestParallelReadWriteENewShouldAccessPixelsOnce(t *testing.T) {
 	}
 }
 
+func TestParallelReduceShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelReduce(nil, func() int { return 0 }, func(acc int, x, y int, c color.Color) int {
+			return acc
+		}, func(a, b int) int { return a + b })
+	})
+}
+
+func TestParallelReduceShouldPanicOnNilFunctions(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageImage()
+
+	init := func() int { return 0 }
+	d := func(acc int, x, y int, c color.Color) int { return acc }
+	combine := func(a, b int) int { return a + b }
+
+	assert.Panics(t, func() { ParallelReduce(img, nil, d, combine) })
+	assert.Panics(t, func() { ParallelReduce(img, init, nil, combine) })
+	assert.Panics(t, func() { ParallelReduce(img, init, d, nil) })
+}
+
+func TestParallelReduceShouldCorrectlyReduce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockCustomImageImage(23, 31, color.Gray{Y: 3})
+
+	for _, workers := range []int{1, 4} {
+		actual := ParallelReduce(img, func() int { return 0 }, func(acc int, x, y int, c color.Color) int {
+			return acc + int(color.GrayModel.Convert(c).(color.Gray).Y)
+		}, func(a, b int) int { return a + b }, WithWorkers(workers))
+
+		assert.Equal(t, 23*31*3, actual)
+	}
+}
+
+func TestParallelReduceShouldProduceReproducibleFloatSums(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 97, 313)
+	img := mockCoordinateImageRgba(bounds)
+
+	sum := func(opts ...Option) float32 {
+		return ParallelReduce(img, func() float32 { return 0 }, func(acc float32, x, y int, c color.Color) float32 {
+			r, g, b, _ := c.RGBA()
+			return acc + float32(r)*0.2126e-3 + float32(g)*0.7152e-3 + float32(b)*0.0722e-3
+		}, func(a, b float32) float32 {
+			return a + b
+		}, opts...)
+	}
+
+	expected := sum(WithWorkers(1))
+	for _, workers := range []int{2, 3, 4, 7, 16, 64} {
+		assert.Equal(t, expected, sum(WithWorkers(workers)), "unexpected sum for %d workers", workers)
+	}
+
+	executor := NewExecutor(5)
+	defer executor.Close()
+
+	assert.Equal(t, expected, sum())
+	assert.Equal(t, expected, sum(WithExecutor(executor)))
+
+	expected = sum(WithWorkers(1), WithChunkSize(5))
+	for run := 0; run < 10; run += 1 {
+		assert.Equal(t, expected, sum(WithWorkers(4), WithChunkSize(5)))
+	}
+}
+
+func TestParallelReadWithStateShouldPanicOnNilArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	init := func() int { return 0 }
+	d := func(_ int, x, y int, c color.Color) {}
+
+	assert.Panics(t, func() { ParallelReadWithState(nil, init, d, nil) })
+	assert.Panics(t, func() { ParallelReadWithState(mockWhiteImageImage(), nil, d, nil) })
+	assert.Panics(t, func() { ParallelReadWithState[int](mockWhiteImageImage(), init, nil, nil) })
+}
+
+func TestParallelReadWithStateShouldUseWorkerLocalState(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockCustomImageImage(29, 41, color.White)
+
+	var (
+		inits     int32 = 0
+		finalizes int32 = 0
+		visits    int64 = 0
+	)
+
+	ParallelReadWithState(img, func() *int {
+		atomic.AddInt32(&inits, 1)
+		return new(int)
+	}, func(count *int, x, y int, c color.Color) {
+		*count += 1
+	}, func(count *int) {
+		atomic.AddInt32(&finalizes, 1)
+		atomic.AddInt64(&visits, int64(*count))
+	}, WithWorkers(4), WithChunkSize(1))
+
+	assert.Equal(t, int64(29*41), visits)
+	assert.Equal(t, inits, finalizes)
+	assert.LessOrEqual(t, inits, int32(4))
+}
+
+func TestParallelReadWriteWithStateShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-5, 7, 13, 22)
+	img := mockCoordinateImageRgba(bounds)
+
+	ParallelReadWriteWithState(img, func() *color.RGBA {
+		return &color.RGBA{}
+	}, func(scratch *color.RGBA, x, y int, c color.Color) color.Color {
+		rgba := c.(color.RGBA)
+		scratch.R, scratch.G, scratch.B, scratch.A = ^rgba.R, ^rgba.G, ^rgba.B, rgba.A
+		return *scratch
+	}, nil, WithWorkers(3))
+
+	assertInvertedCoordinateImage(t, img, bounds)
+}
+
+func TestGeneralFunctionsShouldProduceSameResultsOnFastPath(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 5, 21, 30)
+	transform := func(x, y int, c color.Color) color.Color {
+		r, g, b, a := c.RGBA()
+		return color.RGBA64{uint16(^r), uint16(g), uint16(b ^ uint32(x*y)), uint16(a)}
+	}
+
+	for _, img := range []draw.Image{mockCoordinateImageRgba(bounds), mockCoordinateImageNrgba(bounds)} {
+		for _, relative := range []bool{false, true} {
+			opts := boundsTestOptions(relative)
+
+			fast := cloneDrawImage(img)
+			slow := opaqueDrawImage{cloneDrawImage(img)}
+
+			ParallelReadWrite(fast, transform, opts...)
+			ParallelReadWrite(slow, transform, opts...)
+			assertEqualImages(t, slow, fast)
+
+			fast, slow = cloneDrawImage(img), opaqueDrawImage{cloneDrawImage(img)}
+
+			assert.Nil(t, ParallelReadWriteE(fast, func(x, y int, c color.Color) (color.Color, error) {
+				return transform(x, y, c), nil
+			}, opts...))
+			assert.Nil(t, ParallelReadWriteE(slow, func(x, y int, c color.Color) (color.Color, error) {
+				return transform(x, y, c), nil
+			}, opts...))
+			assertEqualImages(t, slow, fast)
+
+			assertEqualImages(t, ParallelReadWriteNew(slow, transform, opts...), ParallelReadWriteNew(fast, transform, opts...))
+
+			fastNew, err := ParallelReadWriteNewE(fast, func(x, y int, c color.Color) (color.Color, error) {
+				return transform(x, y, c), nil
+			}, opts...)
+			assert.Nil(t, err)
+
+			slowNew, err := ParallelReadWriteNewE(slow, func(x, y int, c color.Color) (color.Color, error) {
+				return transform(x, y, c), nil
+			}, opts...)
+			assert.Nil(t, err)
+			assertEqualImages(t, slowNew, fastNew)
+
+			var fastSum, slowSum int64 = 0, 0
+			ParallelRead(fast, func(x, y int, c color.Color) {
+				r, _, _, _ := c.RGBA()
+				atomic.AddInt64(&fastSum, int64(r)*int64(x+y))
+			}, opts...)
+			assert.Nil(t, ParallelReadE(slow, func(x, y int, c color.Color) error {
+				r, _, _, _ := c.RGBA()
+				atomic.AddInt64(&slowSum, int64(r)*int64(x+y))
+				return nil
+			}, opts...))
+			assert.Equal(t, slowSum, fastSum)
+		}
+	}
+}
+
+func TestGeneralFunctionsShouldPassConcreteColorsOnFastPath(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ParallelRead(mockWhiteImageRgba(), func(_, _ int, c color.Color) {
+		assert.IsType(t, color.RGBA{}, c)
+	})
+
+	ParallelRead(mockWhiteImageNrgba(), func(_, _ int, c color.Color) {
+		assert.IsType(t, color.NRGBA{}, c)
+	})
+}
+
+func TestColorConversionsShouldMatchColorModels(t *testing.T) {
+	colors := []color.Color{
+		color.RGBA{10, 20, 30, 40},
+		color.NRGBA{200, 100, 50, 128},
+		color.RGBA64{0xffff, 0x1234, 0x0, 0xffff},
+		color.NRGBA64{0x8000, 0x4000, 0x2000, 0x0},
+		color.Gray{77},
+		color.Alpha{0},
+		color.CMYK{10, 20, 30, 40},
+	}
+
+	for _, c := range colors {
+		assert.Equal(t, color.RGBAModel.Convert(c), rgbaColor(c))
+		assert.Equal(t, color.NRGBAModel.Convert(c), nrgbaColor(c))
+	}
+}
+
+// The draw image wrapper hiding the concrete type of the image, which forces the general functions to use the
+// image.At and draw.Image.Set calls.
+type opaqueDrawImage struct {
+	draw.Image
+}
+
+func cloneDrawImage(img draw.Image) draw.Image {
+	var clone draw.Image
+	switch img.(type) {
+	case *image.RGBA:
+		clone = image.NewRGBA(img.Bounds())
+	case *image.NRGBA:
+		clone = image.NewNRGBA(img.Bounds())
+	default:
+		clone = image.NewRGBA64(img.Bounds())
+	}
+
+	draw.Draw(clone, clone.Bounds(), img, img.Bounds().Min, draw.Src)
+	return clone
+}
+
+func assertEqualImages(t *testing.T, expected, actual image.Image) {
+	assert.Equal(t, expected.Bounds(), actual.Bounds())
+
+	bounds := expected.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			er, eg, eb, ea := expected.At(x, y).RGBA()
+			ar, ag, ab, aa := actual.At(x, y).RGBA()
+
+			assert.Equal(t, []uint32{er, eg, eb, ea}, []uint32{ar, ag, ab, aa}, "unexpected color at x=%d y=%d", x, y)
+		}
+	}
+}
+
+func BenchmarkGeneralFunctionsFastPath(b *testing.B) {
+	bounds := image.Rect(0, 0, 1280, 720)
+
+	images := []struct {
+		name string
+		img  draw.Image
+	}{
+		{"RGBA", mockCoordinateImageRgba(bounds)},
+		{"NRGBA", mockCoordinateImageNrgba(bounds)},
+	}
+
+	for _, c := range images {
+		for _, path := range []string{"before", "after"} {
+			var img draw.Image = c.img
+			if path == "before" {
+				img = opaqueDrawImage{c.img}
+			}
+
+			b.Run(c.name+"/ParallelRead/"+path, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i += 1 {
+					ParallelRead(img, func(_, _ int, _ color.Color) {})
+				}
+			})
+
+			b.Run(c.name+"/ParallelReadWrite/"+path, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i += 1 {
+					ParallelReadWrite(img, func(_, _ int, c color.Color) color.Color { return c })
+				}
+			})
+
+			b.Run(c.name+"/ParallelReadWriteNew/"+path, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i += 1 {
+					ParallelReadWriteNew(img, func(_, _ int, c color.Color) color.Color { return c })
+				}
+			})
+		}
+	}
+}
+
 func mockCustomDrawImage(w, h int, c color.Color) draw.Image {
 	img := image.NewRGBA(image.Rect(0, 0, w, h))
 	for x := 0; x < w; x += 1 {
@@ -0,0 +1,119 @@
+package pimit
+
+import "image"
+
+type (
+	GrayReadDelegate               = func(x, y int, v uint8)
+	GrayReadErrorableDelegate      = func(x, y int, v uint8) error
+	GrayReadWriteDelegate          = func(x, y int, v uint8) uint8
+	GrayReadWriteErrorableDelegate = func(x, y int, v uint8) (uint8, error)
+)
+
+// Perform a parallel iteration of the pixels of the provided Gray image. For each pixel, execute the delegate function
+// allowing you to read the gray level (Y as uint8) and coordinates. The rows are split into chunks processed by
+// a bounded number of worker goroutines.
+func ParallelGrayRead(src *image.Gray, d GrayReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	parallelChannelRead("ParallelGrayRead", newChannelImage[uint8](src.Pix, src.Stride, src.Rect), d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Gray image. For each pixel, execute the delegate function
+// allowing you to read the gray level (Y as uint8) and coordinates. The rows are split into chunks processed by
+// a bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned.
+func ParallelGrayReadE(src *image.Gray, d GrayReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	return parallelChannelReadE("ParallelGrayReadE", newChannelImage[uint8](src.Pix, src.Stride, src.Rect), d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Gray image. For each pixel, execute the delegate function
+// allowing you to read the gray level (Y as uint8) and coordinates, the delegate return gray level will be set at the
+// given coordinates. This changes will be applied to the passed image instance. Consider using ParallelReadWriteNew if
+// you want to avoid changes to the original image at the expense of additional allocations. The rows are split into
+// chunks processed by a bounded number of worker goroutines.
+func ParallelGrayReadWrite(src *image.Gray, d GrayReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	img := newChannelImage[uint8](src.Pix, src.Stride, src.Rect)
+	parallelChannelReadWrite("ParallelGrayReadWrite", img, img, d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Gray image. For each pixel, execute the delegate function
+// allowing you to read the gray level (Y as uint8) and coordinates, the delegate return gray level will be set at the
+// given coordinates. This changes will be applied to the passed image instance. Consider using ParallelReadWriteNewE if
+// you want to avoid changes to the original image at the expense of additional allocations. The rows are split into
+// chunks processed by a bounded number of worker goroutines. The iteration will break after the first error occurs and
+// the error will be returned.
+func ParallelGrayReadWriteE(src *image.Gray, d GrayReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	img := newChannelImage[uint8](src.Pix, src.Stride, src.Rect)
+	return parallelChannelReadWriteE("ParallelGrayReadWriteE", img, img, d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Gray image. For each pixel, execute the delegate function
+// allowing you to read the gray level (Y as uint8) and coordinates, the delegate return gray level will be set at the
+// given coordinates. This changes will be applied to a new image instance which internally uses the Gray color space
+// and is returned by the function. The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelGrayReadWriteNew(src *image.Gray, d GrayReadWriteDelegate, opts ...Option) *image.Gray {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	dst := image.NewGray(src.Bounds())
+	parallelChannelReadWrite("ParallelGrayReadWriteNew", newChannelImage[uint8](src.Pix, src.Stride, src.Rect), newChannelImage[uint8](dst.Pix, dst.Stride, dst.Rect), d, opts)
+
+	return dst
+}
+
+// Perform a parallel iteration of the pixels of the provided Gray image. For each pixel, execute the delegate function
+// allowing you to read the gray level (Y as uint8) and coordinates, the delegate return gray level will be set at the
+// given coordinates. This changes will be applied to a new image instance which internally uses the Gray color space
+// and is returned by the function. The rows are split into chunks processed by a bounded number of worker goroutines.
+// The iteration will break after the first error occurs and the error will be returned.
+func ParallelGrayReadWriteNewE(src *image.Gray, d GrayReadWriteErrorableDelegate, opts ...Option) (*image.Gray, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	dst := image.NewGray(src.Bounds())
+	if err := parallelChannelReadWriteE("ParallelGrayReadWriteNewE", newChannelImage[uint8](src.Pix, src.Stride, src.Rect), newChannelImage[uint8](dst.Pix, dst.Stride, dst.Rect), d, opts); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
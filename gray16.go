@@ -0,0 +1,120 @@
+package pimit
+
+import "image"
+
+type (
+	Gray16ReadDelegate               = func(x, y int, v uint16)
+	Gray16ReadErrorableDelegate      = func(x, y int, v uint16) error
+	Gray16ReadWriteDelegate          = func(x, y int, v uint16) uint16
+	Gray16ReadWriteErrorableDelegate = func(x, y int, v uint16) (uint16, error)
+)
+
+// Perform a parallel iteration of the pixels of the provided Gray16 image. For each pixel, execute the delegate
+// function allowing you to read the gray level (Y as uint16) and coordinates. The rows are split into chunks processed
+// by a bounded number of worker goroutines.
+func ParallelGray16Read(src *image.Gray16, d Gray16ReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	parallelChannelRead("ParallelGray16Read", newChannelImage[uint16](src.Pix, src.Stride, src.Rect), d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Gray16 image. For each pixel, execute the delegate
+// function allowing you to read the gray level (Y as uint16) and coordinates. The rows are split into chunks processed
+// by a bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned.
+func ParallelGray16ReadE(src *image.Gray16, d Gray16ReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	return parallelChannelReadE("ParallelGray16ReadE", newChannelImage[uint16](src.Pix, src.Stride, src.Rect), d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Gray16 image. For each pixel, execute the delegate
+// function allowing you to read the gray level (Y as uint16) and coordinates, the delegate return gray level will be
+// set at the given coordinates. This changes will be applied to the passed image instance. Consider using
+// ParallelReadWriteNew if you want to avoid changes to the original image at the expense of additional allocations. The
+// rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelGray16ReadWrite(src *image.Gray16, d Gray16ReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	img := newChannelImage[uint16](src.Pix, src.Stride, src.Rect)
+	parallelChannelReadWrite("ParallelGray16ReadWrite", img, img, d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Gray16 image. For each pixel, execute the delegate
+// function allowing you to read the gray level (Y as uint16) and coordinates, the delegate return gray level will be
+// set at the given coordinates. This changes will be applied to the passed image instance. Consider using
+// ParallelReadWriteNewE if you want to avoid changes to the original image at the expense of additional allocations.
+// The rows are split into chunks processed by a bounded number of worker goroutines. The iteration will break after the
+// first error occurs and the error will be returned.
+func ParallelGray16ReadWriteE(src *image.Gray16, d Gray16ReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	img := newChannelImage[uint16](src.Pix, src.Stride, src.Rect)
+	return parallelChannelReadWriteE("ParallelGray16ReadWriteE", img, img, d, opts)
+}
+
+// Perform a parallel iteration of the pixels of the provided Gray16 image. For each pixel, execute the delegate
+// function allowing you to read the gray level (Y as uint16) and coordinates, the delegate return gray level will be
+// set at the given coordinates. This changes will be applied to a new image instance which internally uses the Gray16
+// color space and is returned by the function. The rows are split into chunks processed by a bounded number of worker
+// goroutines.
+func ParallelGray16ReadWriteNew(src *image.Gray16, d Gray16ReadWriteDelegate, opts ...Option) *image.Gray16 {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	dst := image.NewGray16(src.Bounds())
+	parallelChannelReadWrite("ParallelGray16ReadWriteNew", newChannelImage[uint16](src.Pix, src.Stride, src.Rect), newChannelImage[uint16](dst.Pix, dst.Stride, dst.Rect), d, opts)
+
+	return dst
+}
+
+// Perform a parallel iteration of the pixels of the provided Gray16 image. For each pixel, execute the delegate
+// function allowing you to read the gray level (Y as uint16) and coordinates, the delegate return gray level will be
+// set at the given coordinates. This changes will be applied to a new image instance which internally uses the Gray16
+// color space and is returned by the function. The rows are split into chunks processed by a bounded number of worker
+// goroutines. The iteration will break after the first error occurs and the error will be returned.
+func ParallelGray16ReadWriteNewE(src *image.Gray16, d Gray16ReadWriteErrorableDelegate, opts ...Option) (*image.Gray16, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	dst := image.NewGray16(src.Bounds())
+	if err := parallelChannelReadWriteE("ParallelGray16ReadWriteNewE", newChannelImage[uint16](src.Pix, src.Stride, src.Rect), newChannelImage[uint16](dst.Pix, dst.Stride, dst.Rect), d, opts); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
@@ -0,0 +1,374 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelGray16ReadShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGray16Read(nil, func(x, y int, v uint16) {})
+	})
+}
+
+func TestParallelGray16ReadShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	assert.Panics(t, func() {
+		ParallelGray16Read(img, nil)
+	})
+}
+
+func TestParallelGray16ReadShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	exV := uint16(65535)
+
+	ParallelGray16Read(img, func(xIndex int, yIndex int, acV uint16) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+	})
+}
+
+func TestParallelGray16ReadEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGray16ReadE(nil, func(x, y int, v uint16) error {
+			return nil
+		})
+	})
+}
+
+func TestParallelGray16ReadEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	assert.Panics(t, func() {
+		ParallelGray16ReadE(img, nil)
+	})
+}
+
+func TestParallelGray16ReadEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	err := ParallelGray16ReadE(img, func(x, y int, v uint16) error {
+		return errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelGray16ReadEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	exV := uint16(65535)
+
+	err := ParallelGray16ReadE(img, func(xIndex, yIndex int, acV uint16) error {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestParallelGray16ReadWriteShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGray16ReadWrite(nil, func(x, y int, v uint16) uint16 {
+			return v
+		})
+	})
+}
+
+func TestParallelGray16ReadWriteShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	assert.Panics(t, func() {
+		ParallelGray16ReadWrite(img, nil)
+	})
+}
+
+func TestParallelGray16ReadWriteShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	exV := uint16(65535)
+
+	ParallelGray16ReadWrite(img, func(xIndex, yIndex int, acV uint16) uint16 {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0
+	})
+
+	expectedImage := mockBlackImageGray16()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+	assert.Equal(t, expectedImage.Pix, img.Pix)
+}
+
+func TestParallelGray16ReadWriteEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGray16ReadWriteE(nil, func(x, y int, v uint16) (uint16, error) {
+			return v, nil
+		})
+	})
+}
+
+func TestParallelGray16ReadWriteEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	assert.Panics(t, func() {
+		ParallelGray16ReadWriteE(img, nil)
+	})
+}
+
+func TestParallelGray16ReadWriteEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	err := ParallelGray16ReadWriteE(img, func(x, y int, v uint16) (uint16, error) {
+		return v, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelGray16ReadWriteEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	exV := uint16(65535)
+
+	err := ParallelGray16ReadWriteE(img, func(xIndex, yIndex int, acV uint16) (uint16, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockBlackImageGray16()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+	assert.Equal(t, expectedImage.Pix, img.Pix)
+}
+
+func TestParallelGray16ReadWriteEShouldLeaveImageUnchangedOnFailureWhenAtomic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	err := ParallelGray16ReadWriteE(img, func(x, y int, v uint16) (uint16, error) {
+		if x == 3 && y == 4 {
+			return v, errors.New("pimit-test: test errror")
+		}
+
+		return 0, nil
+	}, WithAtomic(), WithWorkers(2), WithChunkSize(1))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, mockWhiteImageGray16().Pix, img.Pix)
+}
+
+func TestParallelGray16ReadWriteNewShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGray16ReadWriteNew(nil, func(x, y int, v uint16) uint16 {
+			return v
+		})
+	})
+}
+
+func TestParallelGray16ReadWriteNewShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	assert.Panics(t, func() {
+		ParallelGray16ReadWriteNew(img, nil)
+	})
+}
+
+func TestParallelGray16ReadWriteNewShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	exV := uint16(65535)
+
+	actualImage := ParallelGray16ReadWriteNew(img, func(xIndex, yIndex int, acV uint16) uint16 {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0
+	})
+
+	expectedImage := mockBlackImageGray16()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+	assert.Equal(t, expectedImage.Pix, actualImage.Pix)
+	assert.Equal(t, mockWhiteImageGray16().Pix, img.Pix)
+}
+
+func TestParallelGray16ReadWriteNewEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGray16ReadWriteNewE(nil, func(x, y int, v uint16) (uint16, error) {
+			return v, nil
+		})
+	})
+}
+
+func TestParallelGray16ReadWriteNewEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	assert.Panics(t, func() {
+		ParallelGray16ReadWriteNewE(img, nil)
+	})
+}
+
+func TestParallelGray16ReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	modifiedImg, err := ParallelGray16ReadWriteNewE(img, func(x, y int, v uint16) (uint16, error) {
+		return v, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, modifiedImg)
+}
+
+func TestParallelGray16ReadWriteNewEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray16()
+
+	exV := uint16(65535)
+
+	actualImage, err := ParallelGray16ReadWriteNewE(img, func(xIndex, yIndex int, acV uint16) (uint16, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockBlackImageGray16()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+	assert.Equal(t, expectedImage.Pix, actualImage.Pix)
+}
+
+func TestParallelGray16FunctionsShouldMatchColorModel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 2, 9, 11)
+	img := mockCoordinateImageGray16(bounds)
+
+	ParallelGray16Read(img, func(x, y int, v uint16) {
+		assert.Equal(t, img.Gray16At(x, y).Y, v)
+	})
+
+	dst := ParallelGray16ReadWriteNew(img, func(x, y int, v uint16) uint16 {
+		return ^v
+	})
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			assert.Equal(t, color.Gray16{^img.Gray16At(x, y).Y}, dst.Gray16At(x, y))
+		}
+	}
+}
+
+func mockWhiteImageGray16() *image.Gray16 {
+	width, height := 5, 6
+
+	img := image.NewGray16(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	return img
+}
+
+func mockBlackImageGray16() *image.Gray16 {
+	width, height := 5, 6
+
+	img := image.NewGray16(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Black)
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,374 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelGrayReadShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGrayRead(nil, func(x, y int, v uint8) {})
+	})
+}
+
+func TestParallelGrayReadShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	assert.Panics(t, func() {
+		ParallelGrayRead(img, nil)
+	})
+}
+
+func TestParallelGrayReadShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	exV := uint8(255)
+
+	ParallelGrayRead(img, func(xIndex int, yIndex int, acV uint8) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+	})
+}
+
+func TestParallelGrayReadEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGrayReadE(nil, func(x, y int, v uint8) error {
+			return nil
+		})
+	})
+}
+
+func TestParallelGrayReadEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	assert.Panics(t, func() {
+		ParallelGrayReadE(img, nil)
+	})
+}
+
+func TestParallelGrayReadEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	err := ParallelGrayReadE(img, func(x, y int, v uint8) error {
+		return errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelGrayReadEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	exV := uint8(255)
+
+	err := ParallelGrayReadE(img, func(xIndex, yIndex int, acV uint8) error {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestParallelGrayReadWriteShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGrayReadWrite(nil, func(x, y int, v uint8) uint8 {
+			return v
+		})
+	})
+}
+
+func TestParallelGrayReadWriteShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	assert.Panics(t, func() {
+		ParallelGrayReadWrite(img, nil)
+	})
+}
+
+func TestParallelGrayReadWriteShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	exV := uint8(255)
+
+	ParallelGrayReadWrite(img, func(xIndex, yIndex int, acV uint8) uint8 {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0
+	})
+
+	expectedImage := mockBlackImageGray()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+	assert.Equal(t, expectedImage.Pix, img.Pix)
+}
+
+func TestParallelGrayReadWriteEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGrayReadWriteE(nil, func(x, y int, v uint8) (uint8, error) {
+			return v, nil
+		})
+	})
+}
+
+func TestParallelGrayReadWriteEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	assert.Panics(t, func() {
+		ParallelGrayReadWriteE(img, nil)
+	})
+}
+
+func TestParallelGrayReadWriteEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	err := ParallelGrayReadWriteE(img, func(x, y int, v uint8) (uint8, error) {
+		return v, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelGrayReadWriteEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	exV := uint8(255)
+
+	err := ParallelGrayReadWriteE(img, func(xIndex, yIndex int, acV uint8) (uint8, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockBlackImageGray()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+	assert.Equal(t, expectedImage.Pix, img.Pix)
+}
+
+func TestParallelGrayReadWriteEShouldLeaveImageUnchangedOnFailureWhenAtomic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	err := ParallelGrayReadWriteE(img, func(x, y int, v uint8) (uint8, error) {
+		if x == 3 && y == 4 {
+			return v, errors.New("pimit-test: test errror")
+		}
+
+		return 0, nil
+	}, WithAtomic(), WithWorkers(2), WithChunkSize(1))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, mockWhiteImageGray().Pix, img.Pix)
+}
+
+func TestParallelGrayReadWriteNewShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGrayReadWriteNew(nil, func(x, y int, v uint8) uint8 {
+			return v
+		})
+	})
+}
+
+func TestParallelGrayReadWriteNewShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	assert.Panics(t, func() {
+		ParallelGrayReadWriteNew(img, nil)
+	})
+}
+
+func TestParallelGrayReadWriteNewShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	exV := uint8(255)
+
+	actualImage := ParallelGrayReadWriteNew(img, func(xIndex, yIndex int, acV uint8) uint8 {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0
+	})
+
+	expectedImage := mockBlackImageGray()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+	assert.Equal(t, expectedImage.Pix, actualImage.Pix)
+	assert.Equal(t, mockWhiteImageGray().Pix, img.Pix)
+}
+
+func TestParallelGrayReadWriteNewEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelGrayReadWriteNewE(nil, func(x, y int, v uint8) (uint8, error) {
+			return v, nil
+		})
+	})
+}
+
+func TestParallelGrayReadWriteNewEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	assert.Panics(t, func() {
+		ParallelGrayReadWriteNewE(img, nil)
+	})
+}
+
+func TestParallelGrayReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	modifiedImg, err := ParallelGrayReadWriteNewE(img, func(x, y int, v uint8) (uint8, error) {
+		return v, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, modifiedImg)
+}
+
+func TestParallelGrayReadWriteNewEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageGray()
+
+	exV := uint8(255)
+
+	actualImage, err := ParallelGrayReadWriteNewE(img, func(xIndex, yIndex int, acV uint8) (uint8, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockBlackImageGray()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+	assert.Equal(t, expectedImage.Pix, actualImage.Pix)
+}
+
+func TestParallelGrayFunctionsShouldMatchColorModel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 2, 9, 11)
+	img := mockCoordinateImageGray(bounds)
+
+	ParallelGrayRead(img, func(x, y int, v uint8) {
+		assert.Equal(t, img.GrayAt(x, y).Y, v)
+	})
+
+	dst := ParallelGrayReadWriteNew(img, func(x, y int, v uint8) uint8 {
+		return ^v
+	})
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			assert.Equal(t, color.Gray{^img.GrayAt(x, y).Y}, dst.GrayAt(x, y))
+		}
+	}
+}
+
+func mockWhiteImageGray() *image.Gray {
+	width, height := 5, 6
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	return img
+}
+
+func mockBlackImageGray() *image.Gray {
+	width, height := 5, 6
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Black)
+		}
+	}
+
+	return img
+}
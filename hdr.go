@@ -0,0 +1,366 @@
+package pimit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The only pixel format of the Radiance HDR images which is supported by the decoding and encoding functions.
+const hdrPixelFormat = "32-bit_rle_rgbe"
+
+// Perform a parallel decoding of the Radiance HDR (RGBE) image read from the provided reader to a new float image. The
+// whole input is read into memory, the scanlines, including the run-length encoded ones, are located sequentially and
+// then decoded in parallel. Only the RGBE pixel format and the standard orientation of the scanlines (-Y H +X W) are
+// supported and the alpha channel of the decoded pixels is set to one. The scanlines are split into chunks processed
+// by a bounded number of worker goroutines. The returned error wraps ErrInvalidFormat if the data is not valid.
+func ParallelDecodeHdr(r io.Reader, opts ...Option) (*FloatImage, error) {
+	if r == nil {
+		panic("pimit: the provided reader is nil")
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	width, height, offset, err := decodeHdrHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if height > (len(data)-offset)/4 {
+		return nil, fmt.Errorf("%w: the hdr data is truncated", ErrInvalidFormat)
+	}
+
+	// NOTE: The length of a run-length encoded scanline is not stored in the data, so the offsets of the scanlines
+	// have to be found sequentially. The scanlines are validated in the process, so the decoding can not fail.
+	offsets, ok := make([]int, height), false
+	for y := 0; y < height; y += 1 {
+		offsets[y] = offset
+		if offset, ok = skipHdrScanline(data, offset, width); !ok {
+			return nil, fmt.Errorf("%w: the hdr scanline at y=%d is invalid", ErrInvalidFormat, y)
+		}
+	}
+
+	o := newOptions(opts)
+	dst := NewFloatImage(image.Rect(0, 0, width, height))
+
+	it := o.newIteration("ParallelDecodeHdr", true)
+	defer it.cancel()
+
+	scheduleWithState(it, height, func() []byte { return make([]byte, 4*width) }, nil, func(rgbe []byte, i int, p *cursor) {
+		p.x, p.y = 0, i
+		if it.interrupted(p) {
+			return
+		}
+
+		decodeHdrScanline(data[offsets[i]:], width, rgbe)
+
+		dstIndex := dst.PixOffset(0, i)
+		for index := 0; index < len(rgbe); index += 4 {
+			dst.Pix[dstIndex+0], dst.Pix[dstIndex+1], dst.Pix[dstIndex+2] = rgbeToFloat(rgbe[index : index+4])
+			dst.Pix[dstIndex+3] = 1
+
+			dstIndex += 4
+		}
+	})
+
+	if err := it.err(); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
+
+// Perform a parallel encoding of the provided float image to the Radiance HDR (RGBE) format and write it to the
+// provided writer. The scanlines are encoded in parallel, using the run-length encoding if the width of the image
+// allows it, and written with a single write after all of them are encoded. The alpha channel is discarded and the negative
+// values of the channels are clamped to zero. The rows are split into chunks processed by a bounded number of worker
+// goroutines.
+func ParallelEncodeHdr(w io.Writer, img *FloatImage, opts ...Option) error {
+	if w == nil {
+		panic("pimit: the provided writer is nil")
+	}
+
+	if img == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := img.Bounds()
+	originX, originY := o.origin(bounds)
+	width, height := bounds.Dx(), bounds.Dy()
+	if bounds.Empty() {
+		return errors.New("pimit: the provided image is empty and can not be encoded")
+	}
+
+	scanlines := make([][]byte, height)
+
+	it := o.newIteration("ParallelEncodeHdr", true)
+	defer it.cancel()
+
+	scheduleWithState(it, height, func() []byte { return make([]byte, 4*width) }, nil, func(rgbe []byte, i int, p *cursor) {
+		var (
+			yIndex   int = bounds.Min.Y + i
+			srcIndex int = img.PixOffset(bounds.Min.X, yIndex)
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		if it.interrupted(p) {
+			return
+		}
+
+		for index := 0; index < len(rgbe); index += 4 {
+			floatToRgbe(img.Pix[srcIndex+0], img.Pix[srcIndex+1], img.Pix[srcIndex+2], rgbe[index:index+4])
+			srcIndex += 4
+		}
+
+		scanlines[i] = encodeHdrScanline(rgbe, width)
+	})
+
+	if err := it.err(); err != nil {
+		return err
+	}
+
+	header := fmt.Sprintf("#?RADIANCE\nFORMAT=%s\n\n-Y %d +X %d\n", hdrPixelFormat, height, width)
+
+	size := len(header)
+	for _, scanline := range scanlines {
+		size += len(scanline)
+	}
+
+	data := make([]byte, 0, size)
+	data = append(data, header...)
+	for _, scanline := range scanlines {
+		data = append(data, scanline...)
+	}
+
+	_, err := w.Write(data)
+	return err
+}
+
+// Decode the header of the Radiance HDR image and return the width and height of the image together with the offset
+// of the first scanline.
+func decodeHdrHeader(data []byte) (int, int, int, error) {
+	line, offset, ok := nextHdrLine(data, 0)
+	if !ok || !bytes.HasPrefix(line, []byte("#?")) {
+		return 0, 0, 0, fmt.Errorf("%w: the hdr signature is missing", ErrInvalidFormat)
+	}
+
+	for {
+		if line, offset, ok = nextHdrLine(data, offset); !ok {
+			return 0, 0, 0, fmt.Errorf("%w: the hdr header is truncated", ErrInvalidFormat)
+		}
+
+		if len(line) == 0 {
+			break
+		}
+
+		if format, found := bytes.CutPrefix(line, []byte("FORMAT=")); found && string(format) != hdrPixelFormat {
+			return 0, 0, 0, fmt.Errorf("%w: the hdr pixel format %q is not supported", ErrInvalidFormat, format)
+		}
+	}
+
+	if line, offset, ok = nextHdrLine(data, offset); !ok {
+		return 0, 0, 0, fmt.Errorf("%w: the hdr resolution is missing", ErrInvalidFormat)
+	}
+
+	fields := strings.Fields(string(line))
+	if len(fields) != 4 || fields[0] != "-Y" || fields[2] != "+X" {
+		return 0, 0, 0, fmt.Errorf("%w: the hdr resolution %q is not supported", ErrInvalidFormat, line)
+	}
+
+	height, errHeight := strconv.Atoi(fields[1])
+	width, errWidth := strconv.Atoi(fields[3])
+	if errHeight != nil || errWidth != nil || !validImageSize(width, height) {
+		return 0, 0, 0, fmt.Errorf("%w: the hdr resolution %q is invalid", ErrInvalidFormat, line)
+	}
+
+	return width, height, offset, nil
+}
+
+// Return the line starting at the provided offset without the line terminator and the offset of the next line.
+func nextHdrLine(data []byte, offset int) ([]byte, int, bool) {
+	length := bytes.IndexByte(data[offset:], '\n')
+	if length < 0 {
+		return nil, 0, false
+	}
+
+	return bytes.TrimSuffix(data[offset:offset+length], []byte("\r")), offset + length + 1, true
+}
+
+// Check if the scanline starting with the provided data is run-length encoded. The run-length encoding can only be used
+// for the images with the width in range [8, 0x7fff].
+func isHdrScanlineRle(data []byte, width int) bool {
+	return width >= 8 && width <= 0x7fff && len(data) >= 4 && data[0] == 2 && data[1] == 2 && data[2]&0x80 == 0
+}
+
+// Validate the scanline starting at the provided offset and return the offset of the next scanline. The returned value
+// indicates if the scanline is valid.
+func skipHdrScanline(data []byte, offset, width int) (int, bool) {
+	if !isHdrScanlineRle(data[offset:], width) {
+		if len(data)-offset < 4*width {
+			return 0, false
+		}
+
+		return offset + 4*width, true
+	}
+
+	if int(data[offset+2])<<8|int(data[offset+3]) != width {
+		return 0, false
+	}
+
+	offset += 4
+	for channel := 0; channel < 4; channel += 1 {
+		for x := 0; x < width; {
+			if offset >= len(data) {
+				return 0, false
+			}
+
+			count := int(data[offset])
+			if count > 128 {
+				count, offset = count-128, offset+2
+			} else {
+				offset += 1 + count
+			}
+
+			if x += count; count == 0 || x > width {
+				return 0, false
+			}
+		}
+	}
+
+	if offset > len(data) {
+		return 0, false
+	}
+
+	return offset, true
+}
+
+// Decode the validated scanline starting with the provided data to the interleaved RGBE values.
+func decodeHdrScanline(data []byte, width int, rgbe []byte) {
+	if !isHdrScanlineRle(data, width) {
+		copy(rgbe, data[:4*width])
+		return
+	}
+
+	offset := 4
+	for channel := 0; channel < 4; channel += 1 {
+		for x := 0; x < width; {
+			count := int(data[offset])
+			offset += 1
+
+			if count > 128 {
+				for value := data[offset]; count > 128; count -= 1 {
+					rgbe[4*x+channel] = value
+					x += 1
+				}
+
+				offset += 1
+			} else {
+				for ; count > 0; count -= 1 {
+					rgbe[4*x+channel] = data[offset]
+					offset += 1
+					x += 1
+				}
+			}
+		}
+	}
+}
+
+// Encode the interleaved RGBE values to a new scanline. The scanline is run-length encoded if the width of the image
+// allows it, the channels are encoded separately and the runs of at least three equal values are compressed.
+func encodeHdrScanline(rgbe []byte, width int) []byte {
+	if width < 8 || width > 0x7fff {
+		return append([]byte(nil), rgbe...)
+	}
+
+	scanline := make([]byte, 0, 4+len(rgbe)+len(rgbe)/64+4)
+	scanline = append(scanline, 2, 2, byte(width>>8), byte(width))
+
+	for channel := 0; channel < 4; channel += 1 {
+		value := func(x int) byte { return rgbe[4*x+channel] }
+
+		for x := 0; x < width; {
+			run := 1
+			for x+run < width && run < 127 && value(x+run) == value(x) {
+				run += 1
+			}
+
+			if run >= 3 {
+				scanline = append(scanline, byte(128+run), value(x))
+				x += run
+				continue
+			}
+
+			end := x + 1
+			for end < width && end-x < 128 {
+				if end+2 < width && value(end) == value(end+1) && value(end) == value(end+2) {
+					break
+				}
+
+				end += 1
+			}
+
+			scanline = append(scanline, byte(end-x))
+			for ; x < end; x += 1 {
+				scanline = append(scanline, value(x))
+			}
+		}
+	}
+
+	return scanline
+}
+
+// Convert the RGBE value to the float channels. The mantissas are scaled by the shared exponent.
+func rgbeToFloat(rgbe []byte) (float32, float32, float32) {
+	if rgbe[3] == 0 {
+		return 0, 0, 0
+	}
+
+	f := float32(math.Ldexp(1, int(rgbe[3])-(128+8)))
+	return float32(rgbe[0]) * f, float32(rgbe[1]) * f, float32(rgbe[2]) * f
+}
+
+// Convert the float channels to the RGBE value. The exponent is shared by the channels and selected according to the
+// largest one. The negative and NaN values are stored as zero and the values exceeding the RGBE range are saturated.
+func floatToRgbe(r, g, b float32, rgbe []byte) {
+	rf, gf, bf := rgbeChannel(r), rgbeChannel(g), rgbeChannel(b)
+
+	v := math.Max(rf, math.Max(gf, bf))
+	if v < 1e-32 {
+		rgbe[0], rgbe[1], rgbe[2], rgbe[3] = 0, 0, 0, 0
+		return
+	}
+
+	_, exp := math.Frexp(math.Min(v, math.MaxFloat32))
+	if exp > 127 {
+		exp = 127
+	}
+
+	scale := math.Ldexp(1, 8-exp)
+	rgbe[0] = uint8(math.Min(rf*scale, 255))
+	rgbe[1] = uint8(math.Min(gf*scale, 255))
+	rgbe[2] = uint8(math.Min(bf*scale, 255))
+	rgbe[3] = uint8(exp + 128)
+}
+
+// Return the channel value which can be stored using the RGBE value. The negative and NaN values are mapped to zero.
+func rgbeChannel(v float32) float64 {
+	if v > 0 {
+		return float64(v)
+	}
+
+	return 0
+}
+
+// Check if the provided image size is positive and the pixels of a float image of this size can be allocated.
+func validImageSize(width, height int) bool {
+	return width > 0 && height > 0 && height <= math.MaxInt/4/width
+}
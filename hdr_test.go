@@ -0,0 +1,258 @@
+package pimit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelDecodeHdrShouldPanicOnNilReader(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelDecodeHdr(nil)
+	})
+}
+
+func TestParallelDecodeHdrShouldDecodeFlatScanlines(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	data := mockHdrData("-Y 2 +X 2", []byte{
+		128, 64, 0, 129, 0, 0, 0, 0,
+		255, 128, 32, 128, 200, 100, 50, 140,
+	})
+
+	img, err := ParallelDecodeHdr(bytes.NewReader(data), WithWorkers(2))
+
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 2, 2), img.Bounds())
+	assert.Equal(t, FloatColor{1, 0.5, 0, 1}, img.FloatAt(0, 0))
+	assert.Equal(t, FloatColor{0, 0, 0, 1}, img.FloatAt(1, 0))
+	assert.Equal(t, FloatColor{0.99609375, 0.5, 0.125, 1}, img.FloatAt(0, 1))
+	assert.Equal(t, FloatColor{3200, 1600, 800, 1}, img.FloatAt(1, 1))
+}
+
+func TestParallelDecodeHdrShouldDecodeRunLengthEncodedScanlines(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img, err := ParallelDecodeHdr(bytes.NewReader(mockHdrRleData()))
+
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 8, 2), img.Bounds())
+
+	for y := 0; y < 2; y += 1 {
+		for x := 0; x < 8; x += 1 {
+			expected := FloatColor{1, float32(16*x) / 128, 0, 1}
+			if x >= 4 {
+				expected.B = float32(x-3) / 128
+			}
+
+			if y == 1 {
+				expected = FloatColor{float32(200+x) / 256, float32(x+1) / 256, 0.5, 1}
+			}
+
+			assert.Equal(t, expected, img.FloatAt(x, y), "unexpected color at x=%d y=%d", x, y)
+		}
+	}
+}
+
+func TestParallelDecodeHdrShouldReturnErrorOnInvalidData(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	rle := mockHdrRleData()
+	header := len(rle) - 50
+
+	cases := map[string][]byte{
+		"missing signature":   []byte("RADIANCE\n\n-Y 1 +X 1\n\x80\x80\x80\x80"),
+		"truncated header":    []byte("#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n"),
+		"unsupported format":  []byte("#?RADIANCE\nFORMAT=32-bit_rle_xyze\n\n-Y 1 +X 1\n\x80\x80\x80\x80"),
+		"missing resolution":  []byte("#?RADIANCE\n\n"),
+		"unsupported layout":  mockHdrData("+Y 1 +X 1", []byte{128, 128, 128, 128}),
+		"invalid resolution":  mockHdrData("-Y 0 +X 1", []byte{128, 128, 128, 128}),
+		"truncated flat data": mockHdrData("-Y 2 +X 1", []byte{128, 128, 128, 128}),
+		"truncated rle data":  rle[:len(rle)-1],
+		"invalid rle width":   append(append(append([]byte(nil), rle[:header+3]...), 9), rle[header+4:]...),
+		"zero run length":     append(append(append([]byte(nil), rle[:header+4]...), 0), rle[header+5:]...),
+		"overflowing run":     append(append(append([]byte(nil), rle[:header+4]...), 137), rle[header+5:]...),
+	}
+
+	for name, data := range cases {
+		img, err := ParallelDecodeHdr(bytes.NewReader(data))
+
+		assert.Nil(t, img, name)
+		assert.ErrorIs(t, err, ErrInvalidFormat, name)
+	}
+}
+
+func TestParallelDecodeHdrShouldReturnErrorOnTruncatedRunLengthEncodedScanlines(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	rle := mockHdrRleData()
+	header := len(rle) - 50
+
+	for length := header; length < len(rle); length += 1 {
+		assert.NotPanics(t, func() {
+			img, err := ParallelDecodeHdr(bytes.NewReader(rle[:length]))
+
+			assert.Nil(t, img, "unexpected image for length %d", length)
+			assert.ErrorIs(t, err, ErrInvalidFormat, "unexpected error for length %d", length)
+		}, "unexpected panic for length %d", length)
+	}
+}
+
+func TestParallelDecodeHdrShouldReturnErrorOnCancelledContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	img, err := ParallelDecodeHdr(bytes.NewReader(mockHdrRleData()), WithContext(ctx))
+
+	assert.Nil(t, img)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParallelEncodeHdrShouldPanicOnNilArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelEncodeHdr(nil, NewFloatImage(image.Rect(0, 0, 1, 1)))
+	})
+
+	assert.Panics(t, func() {
+		ParallelEncodeHdr(&bytes.Buffer{}, nil)
+	})
+}
+
+func TestParallelEncodeHdrShouldReturnErrorOnEmptyImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.NotNil(t, ParallelEncodeHdr(&bytes.Buffer{}, NewFloatImage(image.Rectangle{})))
+}
+
+func TestParallelEncodeHdrShouldEncodeRunLengthEncodedScanlines(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	expected := mockHdrRleData()
+
+	img, err := ParallelDecodeHdr(bytes.NewReader(expected))
+	assert.Nil(t, err)
+
+	actual := &bytes.Buffer{}
+	assert.Nil(t, ParallelEncodeHdr(actual, img))
+
+	header := "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y 2 +X 8\n"
+	assert.Equal(t, append([]byte(header), expected[len(expected)-50:]...), actual.Bytes())
+}
+
+func TestParallelEncodeHdrShouldRoundTripRepresentableValues(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, bounds := range []image.Rectangle{image.Rect(0, 0, 5, 3), image.Rect(-7, 4, 293, 21)} {
+		img := NewFloatImage(bounds)
+		for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+			for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+				scale := float32(math.Ldexp(1, (x+y)%40-20))
+				mantissa := float32(128 + (x/7+y)%128)
+
+				img.SetFloat(x, y, FloatColor{mantissa * scale, float32((x%3+3)%3) * 64 * scale, 0, 1})
+			}
+		}
+
+		buffer := &bytes.Buffer{}
+		assert.Nil(t, ParallelEncodeHdr(buffer, img, WithChunkSize(2)))
+
+		actual, err := ParallelDecodeHdr(buffer, WithWorkers(3))
+		assert.Nil(t, err)
+		assert.Equal(t, image.Rect(0, 0, bounds.Dx(), bounds.Dy()), actual.Bounds())
+
+		for y := 0; y < bounds.Dy(); y += 1 {
+			for x := 0; x < bounds.Dx(); x += 1 {
+				assert.Equal(t, img.FloatAt(bounds.Min.X+x, bounds.Min.Y+y), actual.FloatAt(x, y))
+			}
+		}
+	}
+}
+
+func TestParallelEncodeHdrShouldClampUnrepresentableValues(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := NewFloatImage(image.Rect(0, 0, 4, 1))
+	img.SetFloat(0, 0, FloatColor{-1, float32(math.NaN()), 2, 0})
+	img.SetFloat(1, 0, FloatColor{1e-40, 0, 0, 1})
+	img.SetFloat(2, 0, FloatColor{float32(math.Inf(1)), 0, 0, 1})
+	img.SetFloat(3, 0, FloatColor{0.3, 0.2, 0.1, 1})
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, ParallelEncodeHdr(buffer, img))
+
+	raster := buffer.Bytes()[buffer.Len()-16:]
+	assert.Equal(t, []byte{0, 0, 128, 130}, raster[0:4])
+	assert.Equal(t, []byte{0, 0, 0, 0}, raster[4:8])
+	assert.Equal(t, []byte{255, 0, 0, 255}, raster[8:12])
+	assert.Equal(t, []byte{153, 102, 51, 127}, raster[12:16])
+
+	actual, err := ParallelDecodeHdr(buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, FloatColor{0, 0, 2, 1}, actual.FloatAt(0, 0))
+	assert.InDelta(t, 0.3, actual.FloatAt(3, 0).R, 0.3/128)
+	assert.InDelta(t, 0.1, actual.FloatAt(3, 0).B, 0.3/128)
+}
+
+func TestParallelEncodeHdrShouldReturnErrorOnCancelledContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	buffer := &bytes.Buffer{}
+	err := ParallelEncodeHdr(buffer, NewFloatImage(image.Rect(0, 0, 4, 4)), WithContext(ctx))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, buffer.Len())
+}
+
+func TestParallelEncodeHdrShouldReturnWriterError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	err := ParallelEncodeHdr(&mockFailingWriter{}, NewFloatImage(image.Rect(0, 0, 4, 4)))
+
+	assert.ErrorIs(t, err, errMockWrite)
+}
+
+func mockHdrData(resolution string, raster []byte) []byte {
+	header := "#?RADIANCE\n# pimit test fixture\nFORMAT=32-bit_rle_rgbe\nEXPOSURE=1.0\n\n" + resolution + "\n"
+	return append([]byte(header), raster...)
+}
+
+// The fixture of a run-length encoded image with the size of 8x2. The first scanline contains runs and literals of
+// different lengths and the second scanline contains only literals.
+func mockHdrRleData() []byte {
+	return append(mockHdrData("-Y 2 +X 8", []byte{
+		2, 2, 0, 8,
+		136, 128,
+		8, 0, 16, 32, 48, 64, 80, 96, 112,
+		132, 0, 4, 1, 2, 3, 4,
+		136, 129,
+	}), []byte{
+		2, 2, 0, 8,
+		8, 200, 201, 202, 203, 204, 205, 206, 207,
+		8, 1, 2, 3, 4, 5, 6, 7, 8,
+		136, 128,
+		136, 128,
+	}...)
+}
+
+var errMockWrite = errors.New("pimit-test: write failed")
+
+type mockFailingWriter struct{}
+
+func (w *mockFailingWriter) Write(_ []byte) (int, error) {
+	return 0, errMockWrite
+}
@@ -0,0 +1,225 @@
+package pimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// The iteration holds the state shared by the workers processing a single call of an iteration function. It binds
+// the context provided via options, the cancellation of the remaining work and the trap for the errors and panics.
+// The failures of the delegate functions are handled according to the error policy provided via options.
+type iteration struct {
+	o          *options
+	op         string
+	ctx        context.Context
+	cancel     context.CancelFunc
+	errt       *errorTrap
+	errorable  bool
+	mu         sync.Mutex
+	failures   []failure
+	failedUnit int64
+	stopped    int32
+	journal    []func()
+}
+
+// The failure of the delegate function recorded together with the index of the unit in which it occurred.
+type failure struct {
+	unit int
+	err  error
+}
+
+// The cursor tracks the coordinates of the element currently processed by a worker and the index of the unit the
+// element belongs to. The coordinates are attached to the errors describing failures, panics and cancellations.
+type cursor struct {
+	x    int
+	y    int
+	unit int
+}
+
+// Create a new iteration state for the function with the provided name, which is attached to the reported errors,
+// unless it is overridden via options.
+// The errorable iterations are expected to check for interruptions on every element using the interrupted method,
+// which allows to report the coordinates where the processing stopped. The remaining iterations are interrupted
+// between the units.
+func (o *options) newIteration(op string, errorable bool) *iteration {
+	ctx, cancel := context.WithCancel(o.ctx)
+	if o.op != "" {
+		op = o.op
+	}
+
+	return &iteration{
+		o:          o,
+		op:         op,
+		ctx:        ctx,
+		cancel:     cancel,
+		errt:       NewErrorTrap(),
+		errorable:  errorable,
+		mu:         sync.Mutex{},
+		failures:   nil,
+		failedUnit: math.MaxInt64,
+		stopped:    0,
+		journal:    nil,
+	}
+}
+
+// Check if the iteration has been interrupted. If the interruption is caused by the cancellation of the context
+// provided via options, the cancellation error with the cursor coordinates is recorded. Using the DeterministicFirst
+// error policy, the units following the earliest failed unit are also considered interrupted.
+func (it *iteration) interrupted(p *cursor) bool {
+	if it.o.policy == DeterministicFirst && int64(p.unit) > atomic.LoadInt64(&it.failedUnit) {
+		return true
+	}
+
+	select {
+	case <-it.ctx.Done():
+		if err := it.o.ctx.Err(); err != nil {
+			it.errt.Set(it.pixelError(p, err))
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+// Record the failure of the delegate function at the cursor coordinates according to the error policy. The returned
+// value indicates if the processing of the current unit should stop. Using the FailFast error policy, or after the
+// error limit of the CollectAll policy is reached, the remaining work is cancelled. The ErrStop sentinel cancels the
+// remaining work without recording a failure.
+func (it *iteration) fail(p *cursor, err error) bool {
+	if errors.Is(err, ErrStop) {
+		atomic.StoreInt32(&it.stopped, 1)
+		it.cancel()
+		return true
+	}
+
+	switch it.o.policy {
+	case CollectAll:
+		it.mu.Lock()
+		defer it.mu.Unlock()
+
+		it.failures = append(it.failures, failure{unit: p.unit, err: it.pixelError(p, err)})
+		if it.o.errorLimit > 0 && len(it.failures) >= it.o.errorLimit {
+			it.cancel()
+			return true
+		}
+
+		return false
+	case DeterministicFirst:
+		it.mu.Lock()
+		defer it.mu.Unlock()
+
+		// NOTE: The elements of a unit are processed in order, so only the first failure of a unit is recorded.
+		if int64(p.unit) < atomic.LoadInt64(&it.failedUnit) {
+			it.failures = []failure{{unit: p.unit, err: it.pixelError(p, err)}}
+			atomic.StoreInt64(&it.failedUnit, int64(p.unit))
+		}
+
+		return true
+	default:
+		it.errt.Set(it.pixelError(p, err))
+		it.cancel()
+		return true
+	}
+}
+
+// Recover a panic of the delegate function, record it at the cursor coordinates and cancel the remaining work. The
+// function must be called directly by a deferred call in the worker goroutine.
+func (it *iteration) recover(p *cursor) {
+	if r := recover(); r != nil {
+		it.errt.Set(&PanicError{
+			Op:    it.op,
+			X:     p.x,
+			Y:     p.y,
+			Value: r,
+			Stack: debug.Stack(),
+		})
+
+		it.cancel()
+	}
+}
+
+// Create the error describing the failure of the element at the cursor coordinates.
+func (it *iteration) pixelError(p *cursor, err error) *PixelError {
+	return &PixelError{
+		Op:  it.op,
+		X:   p.x,
+		Y:   p.y,
+		Err: err,
+	}
+}
+
+// Return the error of the iteration. This includes the failures of the delegate functions, the cancellation of the
+// context and the recovered panics. Using the CollectAll error policy, the failures ordered by the units are joined
+// together with the cancellation or panic error. Using the DeterministicFirst error policy, the earliest failure is
+// preferred over the cancellation, but not over a panic.
+func (it *iteration) err() error {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	switch it.o.policy {
+	case CollectAll:
+		sort.SliceStable(it.failures, func(i, j int) bool {
+			return it.failures[i].unit < it.failures[j].unit
+		})
+
+		if it.o.errorLimit > 0 && len(it.failures) > it.o.errorLimit {
+			it.failures = it.failures[:it.o.errorLimit]
+		}
+
+		errs := make([]error, 0, len(it.failures)+1)
+		for _, f := range it.failures {
+			errs = append(errs, f.err)
+		}
+
+		return errors.Join(append(errs, it.errt.Err())...)
+	case DeterministicFirst:
+		var pe *PanicError
+		if err := it.errt.Err(); errors.As(err, &pe) || len(it.failures) == 0 {
+			return err
+		}
+
+		return it.failures[0].err
+	default:
+		return it.errt.Err()
+	}
+}
+
+// Record the function restoring the original state of a unit which is going to be modified. The function is executed
+// after all workers are done, so it can reference the state which is updated during the processing of the unit.
+func (it *iteration) record(restore func()) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	it.journal = append(it.journal, restore)
+}
+
+// Return the error of the iteration. If the iteration failed or has been stopped with the ErrStop sentinel, the
+// recorded units are restored to their original state, because the remaining units have not been processed.
+func (it *iteration) commit() error {
+	err := it.err()
+	if err != nil || atomic.LoadInt32(&it.stopped) != 0 {
+		it.mu.Lock()
+		defer it.mu.Unlock()
+
+		for index := len(it.journal) - 1; index >= 0; index -= 1 {
+			it.journal[index]()
+		}
+	}
+
+	return err
+}
+
+// Panic on the calling goroutine if a panic of a delegate function has been recovered by one of the workers. This is
+// used by the functions which are not able to return errors.
+func (it *iteration) repanic() {
+	var pe *PanicError
+	if errors.As(it.errt.Err(), &pe) {
+		panic(pe)
+	}
+}
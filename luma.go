@@ -0,0 +1,136 @@
+package pimit
+
+import (
+	"image"
+	"math"
+)
+
+// The weights of the R, G and B channels used to calculate the luma (gray level) of a color. The weights are
+// normalized, so only their proportions are relevant.
+type LumaWeights struct {
+	R float64
+	G float64
+	B float64
+}
+
+var (
+	// The luma weights defined by the ITU-R BT.601 recommendation, which are also used by the color.GrayModel.
+	LumaRec601 = LumaWeights{R: 0.299, G: 0.587, B: 0.114}
+
+	// The luma weights defined by the ITU-R BT.709 recommendation, which is used by the sRGB color space.
+	LumaRec709 = LumaWeights{R: 0.2126, G: 0.7152, B: 0.0722}
+
+	// The equal weights of the channels, which result in the average of the channels.
+	LumaAverage = LumaWeights{R: 1, G: 1, B: 1}
+)
+
+// Convert the weights to the fixed-point representation with 16 fractional bits. The converted weights sum up to one,
+// so the result of the weighted sum of the channels does not overflow.
+func (w LumaWeights) fixed() (uint32, uint32, uint32) {
+	sum := w.R + w.G + w.B
+	if !(w.R >= 0 && w.G >= 0 && w.B >= 0) || !(sum > 0) || math.IsInf(sum, 0) {
+		panic("pimit: the provided luma weights are invalid")
+	}
+
+	const one = 1 << 16
+
+	wr := int64(math.Round(w.R / sum * one))
+	wg := int64(math.Round(w.G / sum * one))
+	wb := one - wr - wg
+	if wb < 0 {
+		wg, wb = wg+wb, 0
+	}
+
+	return uint32(wr), uint32(wg), uint32(wb)
+}
+
+// Perform a parallel conversion of the provided RGBA image to a new Gray image using the provided luma weights. The
+// gray level of the pixels is calculated in the same way as by the color.GrayModel, which means that the LumaRec601
+// weights produce the same result as the image.Gray Set method. The rows are split into chunks processed by a bounded
+// number of worker goroutines.
+func ParallelRgbaToGray(src *image.RGBA, w LumaWeights, opts ...Option) *image.Gray {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	wr, wg, wb := w.fixed()
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewGray(bounds)
+
+	it := o.newIteration("ParallelRgbaToGray", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int    = bounds.Min.Y + i
+			srcIndex int    = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int    = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b  uint32 = 0, 0, 0
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			r = uint32(src.Pix[srcIndex+0]) * 0x101
+			g = uint32(src.Pix[srcIndex+1]) * 0x101
+			b = uint32(src.Pix[srcIndex+2]) * 0x101
+
+			dst.Pix[dstIndex] = uint8((wr*r + wg*g + wb*b + 1<<15) >> 24)
+
+			srcIndex += 4
+			dstIndex += 1
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel conversion of the provided NRGBA image to a new Gray image using the provided luma weights. The
+// colors are premultiplied by the alpha channel and the gray level of the pixels is calculated in the same way as by
+// the color.GrayModel, which means that the LumaRec601 weights produce the same result as the image.Gray Set method.
+// The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelNrgbaToGray(src *image.NRGBA, w LumaWeights, opts ...Option) *image.Gray {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	wr, wg, wb := w.fixed()
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewGray(bounds)
+
+	it := o.newIteration("ParallelNrgbaToGray", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			srcIndex   int    = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int    = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint32 = 0, 0, 0, 0
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			a = uint32(src.Pix[srcIndex+3]) * 0x101
+			r = uint32(src.Pix[srcIndex+0]) * 0x101 * a / 0xffff
+			g = uint32(src.Pix[srcIndex+1]) * 0x101 * a / 0xffff
+			b = uint32(src.Pix[srcIndex+2]) * 0x101 * a / 0xffff
+
+			dst.Pix[dstIndex] = uint8((wr*r + wg*g + wb*b + 1<<15) >> 24)
+
+			srcIndex += 4
+			dstIndex += 1
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
@@ -0,0 +1,110 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestLumaWeightsShouldPanicOnInvalidWeights(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, w := range []LumaWeights{
+		{R: 0, G: 0, B: 0},
+		{R: -0.5, G: 1, B: 0.5},
+		{R: math.NaN(), G: 1, B: 1},
+		{R: math.Inf(1), G: 1, B: 1},
+	} {
+		assert.Panics(t, func() {
+			ParallelRgbaToGray(mockWhiteImageRgba(), w)
+		})
+
+		assert.Panics(t, func() {
+			ParallelNrgbaToGray(mockWhiteImageNrgba(), w)
+		})
+	}
+}
+
+func TestLumaWeightsShouldBeNormalized(t *testing.T) {
+	for _, w := range []LumaWeights{LumaRec601, LumaRec709, LumaAverage, {R: 1, G: 0, B: 0}, {R: 0.5, G: 0.50001, B: 0}} {
+		wr, wg, wb := w.fixed()
+
+		assert.Equal(t, uint32(1<<16), wr+wg+wb)
+	}
+
+	wr, wg, wb := LumaRec601.fixed()
+
+	assert.Equal(t, []uint32{19595, 38470, 7471}, []uint32{wr, wg, wb})
+}
+
+func TestParallelRgbaToGrayShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRgbaToGray(nil, LumaRec601)
+	})
+
+	assert.Panics(t, func() {
+		ParallelNrgbaToGray(nil, LumaRec601)
+	})
+}
+
+func TestParallelRgbaToGrayShouldMatchGrayModel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-5, 3, 40, 31)
+	random := rand.New(rand.NewSource(2024))
+
+	rgba := image.NewRGBA(bounds)
+	nrgba := image.NewNRGBA(bounds)
+	random.Read(nrgba.Pix)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			rgba.Set(x, y, nrgba.At(x, y))
+		}
+	}
+
+	for _, workers := range []int{1, 4} {
+		actualRgba := ParallelRgbaToGray(rgba, LumaRec601, WithWorkers(workers))
+		actualNrgba := ParallelNrgbaToGray(nrgba, LumaRec601, WithWorkers(workers))
+
+		assert.Equal(t, bounds, actualRgba.Bounds())
+		assert.Equal(t, bounds, actualNrgba.Bounds())
+
+		for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+			for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+				assert.Equal(t, color.GrayModel.Convert(rgba.At(x, y)), actualRgba.GrayAt(x, y))
+				assert.Equal(t, color.GrayModel.Convert(nrgba.At(x, y)), actualNrgba.GrayAt(x, y))
+			}
+		}
+	}
+}
+
+func TestParallelRgbaToGrayShouldUseProvidedWeights(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := image.NewRGBA(image.Rect(0, 0, 3, 1))
+	img.SetRGBA(0, 0, color.RGBA{255, 0, 0, 255})
+	img.SetRGBA(1, 0, color.RGBA{0, 255, 0, 255})
+	img.SetRGBA(2, 0, color.RGBA{0, 0, 255, 255})
+
+	cases := []struct {
+		weights  LumaWeights
+		expected []uint8
+	}{
+		{LumaRec601, []uint8{76, 150, 29}},
+		{LumaRec709, []uint8{54, 183, 18}},
+		{LumaAverage, []uint8{85, 85, 85}},
+		{LumaWeights{R: 0, G: 2, B: 0}, []uint8{0, 255, 0}},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, ParallelRgbaToGray(img, c.weights).Pix)
+	}
+}
@@ -0,0 +1,69 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+)
+
+// Blend the colors returned by the delegate functions of the masked iterations with the original colors according to
+// the coverage of the mask. The channels of the premultiplied colors are linearly interpolated, so the partially covered
+// pixels are only partially affected. By default the returned color replaces the original color wherever the mask is
+// not zero.
+func WithMaskBlending() Option {
+	return func(o *options) {
+		o.blend = true
+	}
+}
+
+// Return the function reading the coverage of the provided mask at the given coordinates as a 16-bit value. The
+// coverage is the alpha of the mask color and it is zero outside of the mask bounds. The *image.Alpha, *image.Alpha16
+// and *image.Uniform masks are accessed without the image.At calls.
+func maskCoverage(mask image.Image) func(x, y int) uint32 {
+	switch m := mask.(type) {
+	case *image.Alpha:
+		return func(x, y int) uint32 {
+			return uint32(m.AlphaAt(x, y).A) * 0x101
+		}
+	case *image.Alpha16:
+		return func(x, y int) uint32 {
+			return uint32(m.Alpha16At(x, y).A)
+		}
+	case *image.Uniform:
+		_, _, _, a := m.C.RGBA()
+		return func(_, _ int) uint32 {
+			return a
+		}
+	default:
+		return func(x, y int) uint32 {
+			_, _, _, a := mask.At(x, y).RGBA()
+			return a
+		}
+	}
+}
+
+// Interpolate between the original and the returned value of a channel using the provided 16-bit coverage.
+func blendChannel(orig, out, m uint32) uint32 {
+	return (out*m + orig*(0xffff-m)) / 0xffff
+}
+
+// Blend the original and the returned colors using the provided 16-bit coverage in the premultiplied color space.
+func blendColor(orig, out color.Color, m uint32) color.RGBA64 {
+	or, og, ob, oa := orig.RGBA()
+	r, g, b, a := out.RGBA()
+
+	return color.RGBA64{
+		R: uint16(blendChannel(or, r, m)),
+		G: uint16(blendChannel(og, g, m)),
+		B: uint16(blendChannel(ob, b, m)),
+		A: uint16(blendChannel(oa, a, m)),
+	}
+}
+
+// Blend the original and the returned NRGBA colors using the provided 16-bit coverage in the premultiplied color space.
+func blendNrgba(orig, out color.NRGBA, m uint32) (uint8, uint8, uint8, uint8) {
+	or, og, ob, oa := orig.RGBA()
+	r, g, b, a := out.RGBA()
+
+	c := nrgbaPremultiplied(blendChannel(or, r, m), blendChannel(og, g, m), blendChannel(ob, b, m), blendChannel(oa, a, m))
+	return c.R, c.G, c.B, c.A
+}
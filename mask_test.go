@@ -0,0 +1,221 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestMaskedFunctionsShouldPanicOnNilArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelMaskedReadWrite(nil, image.Opaque, func(_, _ int, c color.Color) color.Color { return c })
+	})
+
+	assert.Panics(t, func() {
+		ParallelMaskedReadWrite(mockWhiteDrawImage(), nil, func(_, _ int, c color.Color) color.Color { return c })
+	})
+
+	assert.Panics(t, func() {
+		ParallelMaskedReadWrite(mockWhiteDrawImage(), image.Opaque, nil)
+	})
+
+	assert.Panics(t, func() {
+		ParallelRgbaMaskedReadWrite(nil, image.Opaque, func(_, _ int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) { return r, g, b, a })
+	})
+
+	assert.Panics(t, func() {
+		ParallelRgbaMaskedReadWrite(mockWhiteImageRgba(), nil, func(_, _ int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) { return r, g, b, a })
+	})
+
+	assert.Panics(t, func() {
+		ParallelRgbaMaskedReadWrite(mockWhiteImageRgba(), image.Opaque, nil)
+	})
+
+	assert.Panics(t, func() {
+		ParallelNrgbaMaskedReadWrite(nil, image.Opaque, func(_, _ int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) { return r, g, b, a })
+	})
+
+	assert.Panics(t, func() {
+		ParallelNrgbaMaskedReadWrite(mockWhiteImageNrgba(), nil, func(_, _ int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) { return r, g, b, a })
+	})
+
+	assert.Panics(t, func() {
+		ParallelNrgbaMaskedReadWrite(mockWhiteImageNrgba(), image.Opaque, nil)
+	})
+}
+
+func TestMaskedFunctionsShouldSkipUncoveredPixels(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 2, 17, 19)
+	selection := image.Rect(1, 5, 9, 12)
+
+	for _, mask := range mockSelectionMasks(selection) {
+		for name, iterate := range mockMaskedIterations(bounds) {
+			var visits int32 = 0
+
+			img := iterate(mask, func(x, y int) {
+				assert.True(t, image.Pt(x, y).In(selection), name)
+				atomic.AddInt32(&visits, 1)
+			}, WithWorkers(3))
+
+			assert.Equal(t, int32(selection.Dx()*selection.Dy()), atomic.LoadInt32(&visits), name)
+			assertInvertedCoordinateImage(t, img, selection)
+			assertUntouchedCoordinateImage(t, img, selection)
+		}
+	}
+}
+
+func TestMaskedFunctionsShouldSampleMaskAtActualCoordinates(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(10, 10, 20, 20)
+	selection := image.Rect(12, 13, 15, 18)
+
+	for name, iterate := range mockMaskedIterations(bounds) {
+		img := iterate(mockSelectionMasks(selection)[0], func(x, y int) {
+			assert.True(t, image.Pt(x, y).Add(bounds.Min).In(selection), name)
+		}, WithRelativeCoordinates())
+
+		assertInvertedCoordinateImage(t, img, selection)
+		assertUntouchedCoordinateImage(t, img, selection)
+	}
+}
+
+func TestMaskedFunctionsShouldReplaceColorWithoutBlending(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 4, 4)
+	mask := image.NewUniform(color.Alpha{0x20})
+
+	for _, iterate := range mockMaskedIterations(bounds) {
+		img := iterate(mask, func(_, _ int) {})
+
+		assertInvertedCoordinateImage(t, img, bounds)
+	}
+}
+
+func TestMaskedFunctionsShouldBlendByMaskCoverage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 3, 1)
+	mask := image.NewAlpha(bounds)
+	mask.Pix = []uint8{0x00, 0x80, 0xff}
+
+	orig := color.RGBA{200, 100, 0, 255}
+	out := color.RGBA{0, 0, 0, 255}
+	expected := []color.RGBA{orig, {99, 49, 0, 255}, out}
+
+	images := map[string]draw.Image{
+		"ParallelMaskedReadWrite":      &opaqueDrawImage{image.NewRGBA(bounds)},
+		"ParallelRgbaMaskedReadWrite":  image.NewRGBA(bounds),
+		"ParallelNrgbaMaskedReadWrite": image.NewNRGBA(bounds),
+	}
+
+	for name, img := range images {
+		draw.Draw(img, bounds, image.NewUniform(orig), image.Point{}, draw.Src)
+
+		switch i := img.(type) {
+		case *image.RGBA:
+			ParallelRgbaMaskedReadWrite(i, mask, func(_, _ int, _, _, _, _ uint8) (uint8, uint8, uint8, uint8) {
+				return out.R, out.G, out.B, out.A
+			}, WithMaskBlending())
+		case *image.NRGBA:
+			ParallelNrgbaMaskedReadWrite(i, mask, func(_, _ int, _, _, _, _ uint8) (uint8, uint8, uint8, uint8) {
+				return out.R, out.G, out.B, out.A
+			}, WithMaskBlending())
+		default:
+			ParallelMaskedReadWrite(i, mask, func(_, _ int, _ color.Color) color.Color {
+				return out
+			}, WithMaskBlending())
+		}
+
+		for x, c := range expected {
+			assert.Equal(t, c, color.RGBAModel.Convert(img.At(x, 0)), name)
+		}
+	}
+}
+
+func TestMaskedFunctionsShouldBlendTranslucentColorsInPremultipliedColorSpace(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 1, 1)
+	mask := image.NewUniform(color.Alpha16{0x8000})
+
+	general := &opaqueDrawImage{image.NewNRGBA(bounds)}
+	general.Set(0, 0, color.NRGBA{255, 0, 0, 255})
+
+	ParallelMaskedReadWrite(general, mask, func(_, _ int, _ color.Color) color.Color {
+		return color.NRGBA{0, 0, 255, 0}
+	}, WithMaskBlending())
+
+	fast := image.NewNRGBA(bounds)
+	fast.SetNRGBA(0, 0, color.NRGBA{255, 0, 0, 255})
+
+	ParallelNrgbaMaskedReadWrite(fast, mask, func(_, _ int, _, _, _, _ uint8) (uint8, uint8, uint8, uint8) {
+		return 0, 0, 255, 0
+	}, WithMaskBlending())
+
+	assert.Equal(t, color.NRGBA{255, 0, 0, 127}, fast.NRGBAAt(0, 0))
+	assert.Equal(t, fast.NRGBAAt(0, 0), general.At(0, 0))
+}
+
+func mockSelectionMasks(selection image.Rectangle) []image.Image {
+	alpha := image.NewAlpha(selection)
+	alpha16 := image.NewAlpha16(selection.Inset(-1))
+
+	for y := selection.Min.Y; y < selection.Max.Y; y += 1 {
+		for x := selection.Min.X; x < selection.Max.X; x += 1 {
+			alpha.SetAlpha(x, y, color.Alpha{uint8(1 + x + y)})
+			alpha16.SetAlpha16(x, y, color.Alpha16{uint16(1 + x*y)})
+		}
+	}
+
+	return []image.Image{alpha, alpha16, &opaqueDrawImage{alpha}}
+}
+
+func mockMaskedIterations(bounds image.Rectangle) map[string]func(mask image.Image, visit func(x, y int), opts ...Option) image.Image {
+	return map[string]func(mask image.Image, visit func(x, y int), opts ...Option) image.Image{
+		"ParallelMaskedReadWrite": func(mask image.Image, visit func(x, y int), opts ...Option) image.Image {
+			img := &opaqueDrawImage{mockCoordinateImageRgba(bounds)}
+			ParallelMaskedReadWrite(img, mask, func(x, y int, c color.Color) color.Color {
+				visit(x, y)
+				r, g, b, a := rgbaComponents(c)
+				return color.RGBA{^r, ^g, ^b, a}
+			}, opts...)
+			return img
+		},
+		"ParallelMaskedReadWriteFastPath": func(mask image.Image, visit func(x, y int), opts ...Option) image.Image {
+			img := mockCoordinateImageNrgba(bounds)
+			ParallelMaskedReadWrite(img, mask, func(x, y int, c color.Color) color.Color {
+				visit(x, y)
+				r, g, b, a := rgbaComponents(c)
+				return color.RGBA{^r, ^g, ^b, a}
+			}, opts...)
+			return img
+		},
+		"ParallelRgbaMaskedReadWrite": func(mask image.Image, visit func(x, y int), opts ...Option) image.Image {
+			img := mockCoordinateImageRgba(bounds)
+			ParallelRgbaMaskedReadWrite(img, mask, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				visit(x, y)
+				return ^r, ^g, ^b, a
+			}, opts...)
+			return img
+		},
+		"ParallelNrgbaMaskedReadWrite": func(mask image.Image, visit func(x, y int), opts ...Option) image.Image {
+			img := mockCoordinateImageNrgba(bounds)
+			ParallelNrgbaMaskedReadWrite(img, mask, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				visit(x, y)
+				return ^r, ^g, ^b, a
+			}, opts...)
+			return img
+		},
+	}
+}
@@ -1,16 +1,10 @@
 package pimit
 
-import (
-	"context"
-	"fmt"
-	"sync"
-)
-
 // Perform a parallel iteration of the values of the provided matrix represented as a two-dimentional generic slice.
 // For each entry, execute the delegate function allowing you to read the values and coordinates, the delegate return
 // value will be set at the given coordinates. This changes will be applied to the passed two-dimentional slice instance.
-// Each column is iterated in a separate goroutine.
-func ParallelMatrixReadWrite[T any](m [][]T, d func(x, y int, value T) T) {
+// The columns are split into chunks processed by a bounded number of worker goroutines.
+func ParallelMatrixReadWrite[T any](m [][]T, d func(x, y int, value T) T, opts ...Option) {
 	if m == nil {
 		panic("pimit: the provided matrix slice reference is nil")
 	}
@@ -24,33 +18,32 @@ func ParallelMatrixReadWrite[T any](m [][]T, d func(x, y int, value T) T) {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	wg := &sync.WaitGroup{}
+	it := newOptions(opts).newIteration("ParallelMatrixReadWrite", false)
+	defer it.cancel()
 
-	for x := 0; x < width; x += 1 {
-		wg.Add(1)
-		go func(xIndex int) {
-			defer wg.Done()
+	it.schedule(width, func(xIndex int, p *cursor) {
+		var value T = *new(T)
 
-			var value T = *new(T)
+		p.x = xIndex
+		for yIndex := 0; yIndex < height; yIndex += 1 {
+			p.y = yIndex
 
-			for yIndex := 0; yIndex < height; yIndex += 1 {
-				value = m[xIndex][yIndex]
-				value = d(xIndex, yIndex, value)
+			value = m[xIndex][yIndex]
+			value = d(xIndex, yIndex, value)
 
-				m[xIndex][yIndex] = value
-			}
-		}(x)
-	}
+			m[xIndex][yIndex] = value
+		}
+	})
 
-	wg.Wait()
+	it.repanic()
 }
 
 // Perform a parallel iteration of the values of the provided matrix represented as a two-dimentional generic slice.
 // For each entry, execute the delegate function allowing you to read the values and coordinates, the delegate return
 // value will be set at the given coordinates. This changes will be applied to the passed two-dimentional slice instance.
-// Each column is iterated in a separate goroutine. The iteration will break after the first error occurs and the error
-// will be returned.
-func ParallelMatrixReadWriteE[T any](m [][]T, d func(x, y int, value T) (T, error)) error {
+// The columns are split into chunks processed by a bounded number of worker goroutines. The iteration will break after
+// the first error occurs and the error will be returned.
+func ParallelMatrixReadWriteE[T any](m [][]T, d func(x, y int, value T) (T, error), opts ...Option) error {
 	if m == nil {
 		panic("pimit: the provided matrix slice reference is nil")
 	}
@@ -64,45 +57,91 @@ func ParallelMatrixReadWriteE[T any](m [][]T, d func(x, y int, value T) (T, erro
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	wg := &sync.WaitGroup{}
+	o := newOptions(opts)
 
-	errt := NewErrorTrap()
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	it := o.newIteration("ParallelMatrixReadWriteE", true)
+	defer it.cancel()
 
-	for x := 0; x < width; x += 1 {
-		wg.Add(1)
-		go func(xIndex int) {
-			defer wg.Done()
+	it.schedule(width, func(xIndex int, p *cursor) {
+		var (
+			value T     = *new(T)
+			err   error = nil
+		)
 
-			var (
-				value T     = *new(T)
-				err   error = nil
-			)
+		if o.atomic {
+			saved := append([]T(nil), m[xIndex]...)
+			it.record(func() {
+				copy(m[xIndex], saved)
+			})
+		}
 
-			for yIndex := 0; yIndex < height; yIndex += 1 {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-				}
+		p.x = xIndex
+		for yIndex := 0; yIndex < height; yIndex += 1 {
+			p.y = yIndex
+			if it.interrupted(p) {
+				return
+			}
 
-				value = m[xIndex][yIndex]
+			value = m[xIndex][yIndex]
 
-				value, err = d(xIndex, yIndex, value)
-				if err != nil {
-					errt.Set(fmt.Errorf("pimit: delegate function failed on x=%d y=%d with: %w", xIndex, yIndex, err))
-					cancel()
+			value, err = d(xIndex, yIndex, value)
+			if err != nil {
+				if it.fail(p, err) {
 					return
 				}
 
-				m[xIndex][yIndex] = value
+				continue
 			}
-		}(x)
+
+			m[xIndex][yIndex] = value
+		}
+	})
+
+	return it.commit()
+}
+
+// Perform a parallel iteration of the values of the provided matrix represented as a two-dimentional generic slice
+// using a worker-local state. The state is created by the init function once per worker goroutine, for each entry
+// execute the delegate function allowing you to read the state of the worker, the values and coordinates, the delegate
+// return value will be set at the given coordinates. This changes will be applied to the passed two-dimentional slice
+// instance. The optional finalize function is executed with the state when the worker finishes. The columns are split
+// into chunks processed by a bounded number of worker goroutines.
+func ParallelMatrixReadWriteWithState[T any, S any](m [][]T, init func() S, d func(state S, x, y int, value T) T, finalize func(state S), opts ...Option) {
+	if m == nil {
+		panic("pimit: the provided matrix slice reference is nil")
+	}
+
+	width, height, ok := getMatrixSize(m)
+	if !ok {
+		panic("pimit: the provided matrix slice has inconsistent lengths")
+	}
+
+	if init == nil {
+		panic("pimit: the provided state init function is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
 	}
 
-	wg.Wait()
-	return errt.Err()
+	it := newOptions(opts).newIteration("ParallelMatrixReadWriteWithState", false)
+	defer it.cancel()
+
+	scheduleWithState(it, width, init, finalize, func(state S, xIndex int, p *cursor) {
+		var value T = *new(T)
+
+		p.x = xIndex
+		for yIndex := 0; yIndex < height; yIndex += 1 {
+			p.y = yIndex
+
+			value = m[xIndex][yIndex]
+			value = d(state, xIndex, yIndex, value)
+
+			m[xIndex][yIndex] = value
+		}
+	})
+
+	it.repanic()
 }
 
 func getMatrixSize[T any](m [][]T) (int, int, bool) {
@@ -2,6 +2,7 @@ package pimit
 
 import (
 	"errors"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -222,6 +223,51 @@ func TestParallelMatrixReadWriteEShouldReturnErrorOnAccessError(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestParallelMatrixReadWriteWithStateShouldPanicOnNilArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	init := func() int { return 0 }
+	d := func(_ int, x, y int, v int) int { return v }
+
+	assert.Panics(t, func() { ParallelMatrixReadWriteWithState[int](nil, init, d, nil) })
+	assert.Panics(t, func() { ParallelMatrixReadWriteWithState[int, int](mockCustomMatrix(3, 3, 0), nil, d, nil) })
+	assert.Panics(t, func() { ParallelMatrixReadWriteWithState[int](mockCustomMatrix(3, 3, 0), init, nil, nil) })
+}
+
+func TestParallelMatrixReadWriteWithStateShouldUseWorkerLocalState(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	width, height := 37, 23
+	matrix := mockCustomMatrix(width, height, 2)
+
+	var (
+		inits     int32 = 0
+		finalizes int32 = 0
+		visits    int64 = 0
+	)
+
+	ParallelMatrixReadWriteWithState(matrix, func() *int {
+		atomic.AddInt32(&inits, 1)
+		return new(int)
+	}, func(count *int, x, y int, v int) int {
+		*count += 1
+		return v * 3
+	}, func(count *int) {
+		atomic.AddInt32(&finalizes, 1)
+		atomic.AddInt64(&visits, int64(*count))
+	}, WithWorkers(4), WithChunkSize(2))
+
+	assert.Equal(t, int64(width*height), visits)
+	assert.Equal(t, inits, finalizes)
+	assert.LessOrEqual(t, inits, int32(4))
+
+	for x := 0; x < width; x += 1 {
+		for y := 0; y < height; y += 1 {
+			assert.Equal(t, 6, matrix[x][y])
+		}
+	}
+}
+
 func mockCustomMatrix[T any](width, height int, value T) [][]T {
 	matrix := make([][]T, width)
 
@@ -1,10 +1,8 @@
 package pimit
 
 import (
-	"context"
-	"fmt"
 	"image"
-	"sync"
+	"image/color"
 )
 
 type (
@@ -12,11 +10,16 @@ type (
 	NrgbaReadErrorableDelegate      = func(x, y int, r, g, b, a uint8) error
 	NrgbaReadWriteDelegate          = func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8)
 	NrgbaReadWriteErrorableDelegate = func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8, error)
+	NrgbaRowDelegate                = func(y int, pix []uint8)
+	NrgbaRowErrorableDelegate       = func(y int, pix []uint8) error
+	NrgbaRowNewDelegate             = func(y int, src, dst []uint8)
+	NrgbaRowNewErrorableDelegate    = func(y int, src, dst []uint8) error
 )
 
 // Perform a parallel iteration of the pixels of the provided NRGBA image. For each pixel, execute the delegate function
-// allowing you to read the color (R, G, B and A as uint8) and coordinates. Each row is iterated in a separate goroutine.
-func ParallelNrgbaRead(src *image.NRGBA, d NrgbaReadDelegate) {
+// allowing you to read the color (R, G, B and A as uint8) and coordinates. The rows are split into chunks processed by
+// a bounded number of worker goroutines.
+func ParallelNrgbaRead(src *image.NRGBA, d NrgbaReadDelegate, opts ...Option) {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -25,39 +28,94 @@ func ParallelNrgbaRead(src *image.NRGBA, d NrgbaReadDelegate) {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	wg := &sync.WaitGroup{}
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
 
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
+	it := o.newIteration("ParallelNrgbaRead", false)
+	defer it.cancel()
 
-			var (
-				baseIndex  int   = 4 * yIndex * srcWidth
-				r, g, b, a uint8 = 0, 0, 0, 0
-			)
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int   = bounds.Min.Y + i
+			baseIndex  int   = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint8 = 0, 0, 0, 0
+		)
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				r = src.Pix[baseIndex+0]
-				g = src.Pix[baseIndex+1]
-				b = src.Pix[baseIndex+2]
-				a = src.Pix[baseIndex+3]
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
 
-				d(xIndex, yIndex, r, g, b, a)
-				baseIndex += 4
-			}
-		}(y)
+			r = src.Pix[baseIndex+0]
+			g = src.Pix[baseIndex+1]
+			b = src.Pix[baseIndex+2]
+			a = src.Pix[baseIndex+3]
+
+			d(xIndex-originX, yIndex-originY, r, g, b, a)
+			baseIndex += 4
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided NRGBA image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as uint8) and coordinates. The rows are split into chunks processed by
+// a bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned.
+func ParallelNrgbaReadE(src *image.NRGBA, d NrgbaReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
 	}
 
-	wg.Wait()
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelNrgbaReadE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int   = bounds.Min.Y + i
+			baseIndex  int   = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint8 = 0, 0, 0, 0
+			err        error = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r = src.Pix[baseIndex+0]
+			g = src.Pix[baseIndex+1]
+			b = src.Pix[baseIndex+2]
+			a = src.Pix[baseIndex+3]
+
+			if err = d(xIndex-originX, yIndex-originY, r, g, b, a); err != nil && it.fail(p, err) {
+				return
+			}
+
+			baseIndex += 4
+		}
+	})
+
+	return it.err()
 }
 
 // Perform a parallel iteration of the pixels of the provided NRGBA image. For each pixel, execute the delegate function
-// allowing you to read the color (R, G, B and A as uint8) and coordinates. Each row is iterated in a separate goroutine.
-// The iteration will break after the first error occurs and the error will be returned.
-func ParallelNrgbaReadE(src *image.NRGBA, d NrgbaReadErrorableDelegate) error {
+// allowing you to read the color (R, G, B and A as uint8) and coordinates, the delegate return color will be set at the
+// given coordinates. This changes will be applied to the passed image instance. Consider using ParallelReadWriteNew if
+// you want to avoid changes to the original image at the expense of additional allocations. The rows are split into
+// chunks processed by a bounded number of worker goroutines.
+func ParallelNrgbaReadWrite(src *image.NRGBA, d NrgbaReadWriteDelegate, opts ...Option) {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -66,58 +124,120 @@ func ParallelNrgbaReadE(src *image.NRGBA, d NrgbaReadErrorableDelegate) error {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	wg := &sync.WaitGroup{}
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
 
-	errt := NewErrorTrap()
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	it := o.newIteration("ParallelNrgbaReadWrite", false)
+	defer it.cancel()
 
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int   = bounds.Min.Y + i
+			baseIndex  int   = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint8 = 0, 0, 0, 0
+		)
 
-			var (
-				baseIndex  int   = 4 * yIndex * srcWidth
-				r, g, b, a uint8 = 0, 0, 0, 0
-				err        error = nil
-			)
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-				}
+			r = src.Pix[baseIndex+0]
+			g = src.Pix[baseIndex+1]
+			b = src.Pix[baseIndex+2]
+			a = src.Pix[baseIndex+3]
 
-				r = src.Pix[baseIndex+0]
-				g = src.Pix[baseIndex+1]
-				b = src.Pix[baseIndex+2]
-				a = src.Pix[baseIndex+3]
+			r, g, b, a = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			src.Pix[baseIndex+0] = r
+			src.Pix[baseIndex+1] = g
+			src.Pix[baseIndex+2] = b
+			src.Pix[baseIndex+3] = a
+
+			baseIndex += 4
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided NRGBA image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as uint8) and coordinates, the delegate return color will be set at the
+// given coordinates. This changes will be applied to the passed image instance. Consider using ParallelReadWriteNewE if
+// you want to avoid changes to the original image at the expense of additional allocations. The rows are split into
+// chunks processed by a bounded number of worker goroutines. The iteration will break after the first error occurs and
+// the error will be returned.
+func ParallelNrgbaReadWriteE(src *image.NRGBA, d NrgbaReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelNrgbaReadWriteE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int   = bounds.Min.Y + i
+			baseIndex  int   = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint8 = 0, 0, 0, 0
+			err        error = nil
+		)
+
+		if o.atomic {
+			rowIndex, saved := baseIndex, append([]uint8(nil), src.Pix[baseIndex:baseIndex+4*bounds.Dx()]...)
+			it.record(func() {
+				copy(src.Pix[rowIndex:], saved)
+			})
+		}
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r = src.Pix[baseIndex+0]
+			g = src.Pix[baseIndex+1]
+			b = src.Pix[baseIndex+2]
+			a = src.Pix[baseIndex+3]
 
-				if err = d(xIndex, yIndex, r, g, b, a); err != nil {
-					errt.Set(fmt.Errorf("pimit: delegate function failed on x=%d y=%d with: %w", xIndex, yIndex, err))
-					cancel()
+			r, g, b, a, err = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			if err != nil {
+				if it.fail(p, err) {
 					return
 				}
 
 				baseIndex += 4
+				continue
 			}
-		}(y)
-	}
 
-	wg.Wait()
-	return errt.Err()
+			src.Pix[baseIndex+0] = r
+			src.Pix[baseIndex+1] = g
+			src.Pix[baseIndex+2] = b
+			src.Pix[baseIndex+3] = a
+
+			baseIndex += 4
+		}
+	})
+
+	return it.commit()
 }
 
 // Perform a parallel iteration of the pixels of the provided NRGBA image. For each pixel, execute the delegate function
 // allowing you to read the color (R, G, B and A as uint8) and coordinates, the delegate return color will be set at the
-// given coordinates. This changes will be applied to the passed image instance. Consider using ParallelReadWriteNew if
-// you want to avoid changes to the original image at the expense of additional allocations. Each row is iterated in a
-// separate goroutine.
-func ParallelNrgbaReadWrite(src *image.NRGBA, d NrgbaReadWriteDelegate) {
+// given coordinates. This changes will be applied to a new image instance which internaly uses the NRGBA color space
+// and is returned by the function. The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelNrgbaReadWriteNew(src *image.NRGBA, d NrgbaReadWriteDelegate, opts ...Option) *image.NRGBA {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -126,164 +246,333 @@ func ParallelNrgbaReadWrite(src *image.NRGBA, d NrgbaReadWriteDelegate) {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	wg := &sync.WaitGroup{}
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewNRGBA(bounds)
 
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
+	it := o.newIteration("ParallelNrgbaReadWriteNew", false)
+	defer it.cancel()
 
-			var (
-				baseIndex  int   = 4 * yIndex * srcWidth
-				r, g, b, a uint8 = 0, 0, 0, 0
-			)
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int   = bounds.Min.Y + i
+			srcIndex   int   = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int   = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint8 = 0, 0, 0, 0
+		)
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				r = src.Pix[baseIndex+0]
-				g = src.Pix[baseIndex+1]
-				b = src.Pix[baseIndex+2]
-				a = src.Pix[baseIndex+3]
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
 
-				r, g, b, a = d(xIndex, yIndex, r, g, b, a)
+			r = src.Pix[srcIndex+0]
+			g = src.Pix[srcIndex+1]
+			b = src.Pix[srcIndex+2]
+			a = src.Pix[srcIndex+3]
 
-				src.Pix[baseIndex+0] = r
-				src.Pix[baseIndex+1] = g
-				src.Pix[baseIndex+2] = b
-				src.Pix[baseIndex+3] = a
+			r, g, b, a = d(xIndex-originX, yIndex-originY, r, g, b, a)
 
-				baseIndex += 4
-			}
-		}(y)
-	}
+			dst.Pix[dstIndex+0] = r
+			dst.Pix[dstIndex+1] = g
+			dst.Pix[dstIndex+2] = b
+			dst.Pix[dstIndex+3] = a
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	it.repanic()
 
-	wg.Wait()
+	return dst
 }
 
 // Perform a parallel iteration of the pixels of the provided NRGBA image. For each pixel, execute the delegate function
 // allowing you to read the color (R, G, B and A as uint8) and coordinates, the delegate return color will be set at the
-// given coordinates. This changes will be applied to the passed image instance. Consider using ParallelReadWriteNewE if
-// you want to avoid changes to the original image at the expense of additional allocations. Each row is iterated in a
-// separate goroutine. The iteration will break after the first error occurs and the error will be returned.
-func ParallelNrgbaReadWriteE(src *image.NRGBA, d NrgbaReadWriteErrorableDelegate) error {
+// given coordinates. This changes will be applied to a new image instance which internaly uses the NRGBA color space
+// and is returned by the function. The rows are split into chunks processed by a bounded number of worker goroutines.
+// The iteration will break after the first error occurs and the error will be returned.
+func ParallelNrgbaReadWriteNewE(src *image.NRGBA, d NrgbaReadWriteErrorableDelegate, opts ...Option) (*image.NRGBA, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewNRGBA(bounds)
+
+	it := o.newIteration("ParallelNrgbaReadWriteNewE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int   = bounds.Min.Y + i
+			srcIndex   int   = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int   = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint8 = 0, 0, 0, 0
+			err        error = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r = src.Pix[srcIndex+0]
+			g = src.Pix[srcIndex+1]
+			b = src.Pix[srcIndex+2]
+			a = src.Pix[srcIndex+3]
+
+			r, g, b, a, err = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				srcIndex += 4
+				dstIndex += 4
+				continue
+			}
+
+			dst.Pix[dstIndex+0] = r
+			dst.Pix[dstIndex+1] = g
+			dst.Pix[dstIndex+2] = b
+			dst.Pix[dstIndex+3] = a
+
+			srcIndex += 4
+			dstIndex += 4
+		}
+	})
+
+	if err := it.err(); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
+
+// Perform a parallel reduction of the pixels of the provided NRGBA image. Every chunk of rows is accumulated into its
+// own accumulator created by the init function, for each pixel the delegate function receives the current accumulator,
+// the coordinates and the color (R, G, B and A as uint8) and returns the updated accumulator. The accumulators of the
+// chunks are merged using the combine function in the order of the chunks, so the result does not depend on the timing
+// of the workers. The chunks are derived only from the size of the image, unless the chunk size is specified explicitly
+// using the WithChunkSize option, so the result (e.g. a sum of floats) is reproducible regardless of the number of
+// workers and the machine. The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelNrgbaReduce[A any](src *image.NRGBA, init func() A, d func(acc A, x, y int, r, g, b, a uint8) A, combine func(a, b A) A, opts ...Option) A {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
 
+	if init == nil {
+		panic("pimit: the provided accumulator init function is nil")
+	}
+
 	if d == nil {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	wg := &sync.WaitGroup{}
+	if combine == nil {
+		panic("pimit: the provided accumulator combine function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	rd := newReduction[A](o, bounds.Dy())
 
-	errt := NewErrorTrap()
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	it := o.newIteration("ParallelNrgbaReduce", false)
+	defer it.cancel()
 
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
+	it.scheduleChunks(bounds.Dy(), func(chunk, lo, hi int, p *cursor) {
+		acc := init()
+		defer func() { rd.set(chunk, acc) }()
+
+		for i := lo; i < hi; i += 1 {
+			if it.interrupted(p) {
+				return
+			}
 
 			var (
-				baseIndex  int   = 4 * yIndex * srcWidth
+				yIndex     int   = bounds.Min.Y + i
+				baseIndex  int   = src.PixOffset(bounds.Min.X, yIndex)
 				r, g, b, a uint8 = 0, 0, 0, 0
-				err        error = nil
 			)
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-				}
+			p.y, p.unit = yIndex-originY, i
+			for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+				p.x = xIndex - originX
 
 				r = src.Pix[baseIndex+0]
 				g = src.Pix[baseIndex+1]
 				b = src.Pix[baseIndex+2]
 				a = src.Pix[baseIndex+3]
 
-				r, g, b, a, err = d(xIndex, yIndex, r, g, b, a)
+				acc = d(acc, xIndex-originX, yIndex-originY, r, g, b, a)
+				baseIndex += 4
+			}
+		}
+	})
 
-				if err != nil {
-					errt.Set(fmt.Errorf("pimit: delegate function failed on x=%d y=%d with: %w", xIndex, yIndex, err))
-					cancel()
-					return
-				}
+	it.repanic()
 
-				src.Pix[baseIndex+0] = r
-				src.Pix[baseIndex+1] = g
-				src.Pix[baseIndex+2] = b
-				src.Pix[baseIndex+3] = a
+	return rd.merge(init, combine)
+}
 
-				baseIndex += 4
-			}
-		}(y)
+// Perform a parallel iteration of the pixels of the provided NRGBA image using a worker-local state. The state is
+// created by the init function once per worker goroutine, for each pixel execute the delegate function allowing you to
+// read the state of the worker, the color (R, G, B and A as uint8) and coordinates. The optional finalize function is
+// executed with the state when the worker finishes. The state allows to reuse buffers or to accumulate partial results
+// without synchronization. The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelNrgbaReadWithState[S any](src *image.NRGBA, init func() S, d func(state S, x, y int, r, g, b, a uint8), finalize func(state S), opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if init == nil {
+		panic("pimit: the provided state init function is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
 	}
 
-	wg.Wait()
-	return errt.Err()
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelNrgbaReadWithState", false)
+	defer it.cancel()
+
+	scheduleWithState(it, bounds.Dy(), init, finalize, func(state S, i int, p *cursor) {
+		var (
+			yIndex     int   = bounds.Min.Y + i
+			baseIndex  int   = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint8 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			r = src.Pix[baseIndex+0]
+			g = src.Pix[baseIndex+1]
+			b = src.Pix[baseIndex+2]
+			a = src.Pix[baseIndex+3]
+
+			d(state, xIndex-originX, yIndex-originY, r, g, b, a)
+			baseIndex += 4
+		}
+	})
+
+	it.repanic()
 }
 
-// Perform a parallel iteration of the pixels of the provided NRGBA image. For each pixel, execute the delegate function
-// allowing you to read the color (R, G, B and A as uint8) and coordinates, the delegate return color will be set at the
-// given coordinates. This changes will be applied to a new image instance which internaly uses the NRGBA color space
-// and is returned by the function. Each row is iterated in a separate goroutine.
-func ParallelNrgbaReadWriteNew(src *image.NRGBA, d NrgbaReadWriteDelegate) *image.NRGBA {
+// Perform a parallel iteration of the pixels of the provided NRGBA image using a worker-local state. The state is
+// created by the init function once per worker goroutine, for each pixel execute the delegate function allowing you to
+// read the state of the worker, the color (R, G, B and A as uint8) and coordinates, the delegate return color will be
+// set at the given coordinates. This changes will be applied to the passed image instance. The optional finalize
+// function is executed with the state when the worker finishes. The rows are split into chunks processed by a bounded
+// number of worker goroutines.
+func ParallelNrgbaReadWriteWithState[S any](src *image.NRGBA, init func() S, d func(state S, x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8), finalize func(state S), opts ...Option) {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
 
+	if init == nil {
+		panic("pimit: the provided state init function is nil")
+	}
+
 	if d == nil {
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	dst := image.NewNRGBA(image.Rect(0, 0, srcWidth, srcHeight))
-	wg := &sync.WaitGroup{}
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
 
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
+	it := o.newIteration("ParallelNrgbaReadWriteWithState", false)
+	defer it.cancel()
 
-			var (
-				baseIndex  int   = 4 * yIndex * srcWidth
-				r, g, b, a uint8 = 0, 0, 0, 0
-			)
+	scheduleWithState(it, bounds.Dy(), init, finalize, func(state S, i int, p *cursor) {
+		var (
+			yIndex     int   = bounds.Min.Y + i
+			baseIndex  int   = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint8 = 0, 0, 0, 0
+		)
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				r = src.Pix[baseIndex+0]
-				g = src.Pix[baseIndex+1]
-				b = src.Pix[baseIndex+2]
-				a = src.Pix[baseIndex+3]
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
 
-				r, g, b, a = d(xIndex, yIndex, r, g, b, a)
+			r = src.Pix[baseIndex+0]
+			g = src.Pix[baseIndex+1]
+			b = src.Pix[baseIndex+2]
+			a = src.Pix[baseIndex+3]
 
-				dst.Pix[baseIndex+0] = r
-				dst.Pix[baseIndex+1] = g
-				dst.Pix[baseIndex+2] = b
-				dst.Pix[baseIndex+3] = a
+			r, g, b, a = d(state, xIndex-originX, yIndex-originY, r, g, b, a)
 
-				baseIndex += 4
-			}
-		}(y)
+			src.Pix[baseIndex+0] = r
+			src.Pix[baseIndex+1] = g
+			src.Pix[baseIndex+2] = b
+			src.Pix[baseIndex+3] = a
+
+			baseIndex += 4
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the rows of the provided NRGBA image. For each row, execute the delegate function
+// allowing you to access the y coordinate and the Pix sub-slice of the row, which contains the R, G, B and A values of
+// the pixels from the left to the right edge of the image bounds. The changes to the slice are applied to the passed
+// image instance. The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelNrgbaRows(src *image.NRGBA, d NrgbaRowDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
 	}
 
-	wg.Wait()
-	return dst
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	length := 4 * bounds.Dx()
+
+	it := o.newIteration("ParallelNrgbaRows", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int = bounds.Min.Y + i
+			baseIndex int = src.PixOffset(bounds.Min.X, yIndex)
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+
+		d(yIndex-originY, src.Pix[baseIndex:baseIndex+length:baseIndex+length])
+	})
+
+	it.repanic()
 }
 
-// Perform a parallel iteration of the pixels of the provided NRGBA image. For each pixel, execute the delegate function
-// allowing you to read the color (R, G, B and A as uint8) and coordinates, the delegate return color will be set at the
-// given coordinates. This changes will be applied to a new image instance which internaly uses the NRGBA color space
-// and is returned by the function. Each row is iterated in a separate goroutine. The iteration will break after the first
-// error occurs and the error will be returned.
-func ParallelNrgbaReadWriteNewE(src *image.NRGBA, d NrgbaReadWriteErrorableDelegate) (*image.NRGBA, error) {
+// Perform a parallel iteration of the rows of the provided NRGBA image. For each row, execute the delegate function
+// allowing you to access the y coordinate and the Pix sub-slice of the row, which contains the R, G, B and A values of
+// the pixels from the left to the right edge of the image bounds. The changes to the slice are applied to the passed
+// image instance. The rows are split into chunks processed by a bounded number of worker goroutines. The iteration will
+// break after the first error occurs and the error will be returned, the x coordinate of the error is the left edge.
+func ParallelNrgbaRowsE(src *image.NRGBA, d NrgbaRowErrorableDelegate, opts ...Option) error {
 	if src == nil {
 		panic("pimit: the provided image reference is nil")
 	}
@@ -292,61 +581,186 @@ func ParallelNrgbaReadWriteNewE(src *image.NRGBA, d NrgbaReadWriteErrorableDeleg
 		panic("pimit: the provided access delegate function is nil")
 	}
 
-	srcWidth := src.Bounds().Dx()
-	srcHeight := src.Bounds().Dy()
-	dst := image.NewNRGBA(image.Rect(0, 0, srcWidth, srcHeight))
-	wg := &sync.WaitGroup{}
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	length := 4 * bounds.Dx()
 
-	errt := NewErrorTrap()
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	it := o.newIteration("ParallelNrgbaRowsE", true)
+	defer it.cancel()
 
-	for y := 0; y < srcHeight; y += 1 {
-		wg.Add(1)
-		go func(yIndex int) {
-			defer wg.Done()
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int = bounds.Min.Y + i
+			baseIndex int = src.PixOffset(bounds.Min.X, yIndex)
+		)
 
-			var (
-				baseIndex  int   = 4 * yIndex * srcWidth
-				r, g, b, a uint8 = 0, 0, 0, 0
-				err        error = nil
-			)
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		if it.interrupted(p) {
+			return
+		}
 
-			for xIndex := 0; xIndex < srcWidth; xIndex += 1 {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-				}
+		if err := d(yIndex-originY, src.Pix[baseIndex:baseIndex+length:baseIndex+length]); err != nil {
+			it.fail(p, err)
+		}
+	})
 
-				r = src.Pix[baseIndex+0]
-				g = src.Pix[baseIndex+1]
-				b = src.Pix[baseIndex+2]
-				a = src.Pix[baseIndex+3]
+	return it.err()
+}
 
-				r, g, b, a, err = d(xIndex, yIndex, r, g, b, a)
+// Perform a parallel iteration of the rows of the provided NRGBA image. For each row, execute the delegate function
+// allowing you to access the y coordinate, the Pix sub-slice of the row of the provided image and the matching Pix
+// sub-slice of a new image instance which is returned by the function. The slices contain the R, G, B and A values of
+// the pixels from the left to the right edge of the image bounds. The rows are split into chunks processed by a
+// bounded number of worker goroutines.
+func ParallelNrgbaRowsNew(src *image.NRGBA, d NrgbaRowNewDelegate, opts ...Option) *image.NRGBA {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
 
-				if err != nil {
-					errt.Set(fmt.Errorf("pimit: delegate function failed on x=%d y=%d with: %w", xIndex, yIndex, err))
-					cancel()
-					return
-				}
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
 
-				dst.Pix[baseIndex+0] = r
-				dst.Pix[baseIndex+1] = g
-				dst.Pix[baseIndex+2] = b
-				dst.Pix[baseIndex+3] = a
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	length := 4 * bounds.Dx()
+	dst := image.NewNRGBA(bounds)
 
-				baseIndex += 4
-			}
-		}(y)
+	it := o.newIteration("ParallelNrgbaRowsNew", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int = bounds.Min.Y + i
+			srcIndex int = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int = dst.PixOffset(bounds.Min.X, yIndex)
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+
+		d(yIndex-originY, src.Pix[srcIndex:srcIndex+length:srcIndex+length], dst.Pix[dstIndex:dstIndex+length:dstIndex+length])
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel iteration of the rows of the provided NRGBA image. For each row, execute the delegate function
+// allowing you to access the y coordinate, the Pix sub-slice of the row of the provided image and the matching Pix
+// sub-slice of a new image instance which is returned by the function. The slices contain the R, G, B and A values of
+// the pixels from the left to the right edge of the image bounds. The rows are split into chunks processed by a
+// bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned, the x coordinate of the error is the left edge.
+func ParallelNrgbaRowsNewE(src *image.NRGBA, d NrgbaRowNewErrorableDelegate, opts ...Option) (*image.NRGBA, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
 	}
 
-	wg.Wait()
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
 
-	if err := errt.Err(); err != nil {
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	length := 4 * bounds.Dx()
+	dst := image.NewNRGBA(bounds)
+
+	it := o.newIteration("ParallelNrgbaRowsNewE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int = bounds.Min.Y + i
+			srcIndex int = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int = dst.PixOffset(bounds.Min.X, yIndex)
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		if it.interrupted(p) {
+			return
+		}
+
+		if err := d(yIndex-originY, src.Pix[srcIndex:srcIndex+length:srcIndex+length], dst.Pix[dstIndex:dstIndex+length:dstIndex+length]); err != nil {
+			it.fail(p, err)
+		}
+	})
+
+	if err := it.err(); err != nil {
 		return nil, err
 	} else {
 		return dst, nil
 	}
 }
+
+// Perform a parallel iteration of the pixels of the provided NRGBA image which are covered by the provided mask. For
+// each pixel with a non-zero mask coverage, execute the delegate function allowing you to read the color (R, G, B and A
+// as uint8) and coordinates, the delegate return color will be set at the given coordinates. The mask is sampled at the
+// actual image coordinates and the pixels outside of the mask bounds are skipped. Using the WithMaskBlending option,
+// the returned color is blended with the original color according to the mask coverage in the premultiplied color
+// space. This changes will be applied to the passed image instance. The rows are split into chunks processed by a
+// bounded number of worker goroutines.
+func ParallelNrgbaMaskedReadWrite(src *image.NRGBA, mask image.Image, d NrgbaReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if mask == nil {
+		panic("pimit: the provided mask image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	coverage := maskCoverage(mask)
+
+	it := o.newIteration("ParallelNrgbaMaskedReadWrite", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex         int    = bounds.Min.Y + i
+			baseIndex      int    = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a     uint8  = 0, 0, 0, 0
+			nr, ng, nb, na uint8  = 0, 0, 0, 0
+			m              uint32 = 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			if m = coverage(xIndex, yIndex); m == 0 {
+				baseIndex += 4
+				continue
+			}
+
+			r = src.Pix[baseIndex+0]
+			g = src.Pix[baseIndex+1]
+			b = src.Pix[baseIndex+2]
+			a = src.Pix[baseIndex+3]
+
+			nr, ng, nb, na = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			if o.blend && m != 0xffff {
+				nr, ng, nb, na = blendNrgba(color.NRGBA{r, g, b, a}, color.NRGBA{nr, ng, nb, na}, m)
+			}
+
+			src.Pix[baseIndex+0] = nr
+			src.Pix[baseIndex+1] = ng
+			src.Pix[baseIndex+2] = nb
+			src.Pix[baseIndex+3] = na
+
+			baseIndex += 4
+		}
+	})
+
+	it.repanic()
+}
@@ -0,0 +1,371 @@
+package pimit
+
+import "image"
+
+type (
+	Nrgba64ReadDelegate               = func(x, y int, r, g, b, a uint16)
+	Nrgba64ReadErrorableDelegate      = func(x, y int, r, g, b, a uint16) error
+	Nrgba64ReadWriteDelegate          = func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16)
+	Nrgba64ReadWriteErrorableDelegate = func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error)
+)
+
+// Perform a parallel iteration of the pixels of the provided NRGBA64 image. For each pixel, execute the delegate
+// function allowing you to read the color (R, G, B and A as uint16) and coordinates. The rows are split into chunks
+// processed by a bounded number of worker goroutines.
+func ParallelNrgba64Read(src *image.NRGBA64, d Nrgba64ReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelNrgba64Read", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			baseIndex  int    = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			r = uint16(src.Pix[baseIndex+0])<<8 | uint16(src.Pix[baseIndex+1])
+			g = uint16(src.Pix[baseIndex+2])<<8 | uint16(src.Pix[baseIndex+3])
+			b = uint16(src.Pix[baseIndex+4])<<8 | uint16(src.Pix[baseIndex+5])
+			a = uint16(src.Pix[baseIndex+6])<<8 | uint16(src.Pix[baseIndex+7])
+
+			d(xIndex-originX, yIndex-originY, r, g, b, a)
+			baseIndex += 8
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided NRGBA64 image. For each pixel, execute the delegate
+// function allowing you to read the color (R, G, B and A as uint16) and coordinates. The rows are split into chunks
+// processed by a bounded number of worker goroutines. The iteration will break after the first error occurs and the
+// error will be returned.
+func ParallelNrgba64ReadE(src *image.NRGBA64, d Nrgba64ReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelNrgba64ReadE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			baseIndex  int    = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+			err        error  = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r = uint16(src.Pix[baseIndex+0])<<8 | uint16(src.Pix[baseIndex+1])
+			g = uint16(src.Pix[baseIndex+2])<<8 | uint16(src.Pix[baseIndex+3])
+			b = uint16(src.Pix[baseIndex+4])<<8 | uint16(src.Pix[baseIndex+5])
+			a = uint16(src.Pix[baseIndex+6])<<8 | uint16(src.Pix[baseIndex+7])
+
+			if err = d(xIndex-originX, yIndex-originY, r, g, b, a); err != nil && it.fail(p, err) {
+				return
+			}
+
+			baseIndex += 8
+		}
+	})
+
+	return it.err()
+}
+
+// Perform a parallel iteration of the pixels of the provided NRGBA64 image. For each pixel, execute the delegate
+// function allowing you to read the color (R, G, B and A as uint16) and coordinates, the delegate return color will be
+// set at the given coordinates. This changes will be applied to the passed image instance. Consider using
+// ParallelReadWriteNew if you want to avoid changes to the original image at the expense of additional allocations. The
+// rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelNrgba64ReadWrite(src *image.NRGBA64, d Nrgba64ReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelNrgba64ReadWrite", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			baseIndex  int    = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			r = uint16(src.Pix[baseIndex+0])<<8 | uint16(src.Pix[baseIndex+1])
+			g = uint16(src.Pix[baseIndex+2])<<8 | uint16(src.Pix[baseIndex+3])
+			b = uint16(src.Pix[baseIndex+4])<<8 | uint16(src.Pix[baseIndex+5])
+			a = uint16(src.Pix[baseIndex+6])<<8 | uint16(src.Pix[baseIndex+7])
+
+			r, g, b, a = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			src.Pix[baseIndex+0] = uint8(r >> 8)
+			src.Pix[baseIndex+1] = uint8(r)
+			src.Pix[baseIndex+2] = uint8(g >> 8)
+			src.Pix[baseIndex+3] = uint8(g)
+			src.Pix[baseIndex+4] = uint8(b >> 8)
+			src.Pix[baseIndex+5] = uint8(b)
+			src.Pix[baseIndex+6] = uint8(a >> 8)
+			src.Pix[baseIndex+7] = uint8(a)
+
+			baseIndex += 8
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided NRGBA64 image. For each pixel, execute the delegate
+// function allowing you to read the color (R, G, B and A as uint16) and coordinates, the delegate return color will be
+// set at the given coordinates. This changes will be applied to the passed image instance. Consider using
+// ParallelReadWriteNewE if you want to avoid changes to the original image at the expense of additional allocations.
+// The rows are split into chunks processed by a bounded number of worker goroutines. The iteration will break after the
+// first error occurs and the error will be returned.
+func ParallelNrgba64ReadWriteE(src *image.NRGBA64, d Nrgba64ReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelNrgba64ReadWriteE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			baseIndex  int    = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+			err        error  = nil
+		)
+
+		if o.atomic {
+			rowIndex, saved := baseIndex, append([]uint8(nil), src.Pix[baseIndex:baseIndex+8*bounds.Dx()]...)
+			it.record(func() {
+				copy(src.Pix[rowIndex:], saved)
+			})
+		}
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r = uint16(src.Pix[baseIndex+0])<<8 | uint16(src.Pix[baseIndex+1])
+			g = uint16(src.Pix[baseIndex+2])<<8 | uint16(src.Pix[baseIndex+3])
+			b = uint16(src.Pix[baseIndex+4])<<8 | uint16(src.Pix[baseIndex+5])
+			a = uint16(src.Pix[baseIndex+6])<<8 | uint16(src.Pix[baseIndex+7])
+
+			r, g, b, a, err = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				baseIndex += 8
+				continue
+			}
+
+			src.Pix[baseIndex+0] = uint8(r >> 8)
+			src.Pix[baseIndex+1] = uint8(r)
+			src.Pix[baseIndex+2] = uint8(g >> 8)
+			src.Pix[baseIndex+3] = uint8(g)
+			src.Pix[baseIndex+4] = uint8(b >> 8)
+			src.Pix[baseIndex+5] = uint8(b)
+			src.Pix[baseIndex+6] = uint8(a >> 8)
+			src.Pix[baseIndex+7] = uint8(a)
+
+			baseIndex += 8
+		}
+	})
+
+	return it.commit()
+}
+
+// Perform a parallel iteration of the pixels of the provided NRGBA64 image. For each pixel, execute the delegate
+// function allowing you to read the color (R, G, B and A as uint16) and coordinates, the delegate return color will be
+// set at the given coordinates. This changes will be applied to a new image instance which internaly uses the NRGBA64
+// color space and is returned by the function. The rows are split into chunks processed by a bounded number of worker
+// goroutines.
+func ParallelNrgba64ReadWriteNew(src *image.NRGBA64, d Nrgba64ReadWriteDelegate, opts ...Option) *image.NRGBA64 {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewNRGBA64(bounds)
+
+	it := o.newIteration("ParallelNrgba64ReadWriteNew", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			srcIndex   int    = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int    = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			r = uint16(src.Pix[srcIndex+0])<<8 | uint16(src.Pix[srcIndex+1])
+			g = uint16(src.Pix[srcIndex+2])<<8 | uint16(src.Pix[srcIndex+3])
+			b = uint16(src.Pix[srcIndex+4])<<8 | uint16(src.Pix[srcIndex+5])
+			a = uint16(src.Pix[srcIndex+6])<<8 | uint16(src.Pix[srcIndex+7])
+
+			r, g, b, a = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			dst.Pix[dstIndex+0] = uint8(r >> 8)
+			dst.Pix[dstIndex+1] = uint8(r)
+			dst.Pix[dstIndex+2] = uint8(g >> 8)
+			dst.Pix[dstIndex+3] = uint8(g)
+			dst.Pix[dstIndex+4] = uint8(b >> 8)
+			dst.Pix[dstIndex+5] = uint8(b)
+			dst.Pix[dstIndex+6] = uint8(a >> 8)
+			dst.Pix[dstIndex+7] = uint8(a)
+
+			srcIndex += 8
+			dstIndex += 8
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel iteration of the pixels of the provided NRGBA64 image. For each pixel, execute the delegate
+// function allowing you to read the color (R, G, B and A as uint16) and coordinates, the delegate return color will be
+// set at the given coordinates. This changes will be applied to a new image instance which internaly uses the NRGBA64
+// color space and is returned by the function. The rows are split into chunks processed by a bounded number of worker
+// goroutines. The iteration will break after the first error occurs and the error will be returned.
+func ParallelNrgba64ReadWriteNewE(src *image.NRGBA64, d Nrgba64ReadWriteErrorableDelegate, opts ...Option) (*image.NRGBA64, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewNRGBA64(bounds)
+
+	it := o.newIteration("ParallelNrgba64ReadWriteNewE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			srcIndex   int    = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int    = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+			err        error  = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r = uint16(src.Pix[srcIndex+0])<<8 | uint16(src.Pix[srcIndex+1])
+			g = uint16(src.Pix[srcIndex+2])<<8 | uint16(src.Pix[srcIndex+3])
+			b = uint16(src.Pix[srcIndex+4])<<8 | uint16(src.Pix[srcIndex+5])
+			a = uint16(src.Pix[srcIndex+6])<<8 | uint16(src.Pix[srcIndex+7])
+
+			r, g, b, a, err = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				srcIndex += 8
+				dstIndex += 8
+				continue
+			}
+
+			dst.Pix[dstIndex+0] = uint8(r >> 8)
+			dst.Pix[dstIndex+1] = uint8(r)
+			dst.Pix[dstIndex+2] = uint8(g >> 8)
+			dst.Pix[dstIndex+3] = uint8(g)
+			dst.Pix[dstIndex+4] = uint8(b >> 8)
+			dst.Pix[dstIndex+5] = uint8(b)
+			dst.Pix[dstIndex+6] = uint8(a >> 8)
+			dst.Pix[dstIndex+7] = uint8(a)
+
+			srcIndex += 8
+			dstIndex += 8
+		}
+	})
+
+	if err := it.err(); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
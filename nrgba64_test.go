@@ -0,0 +1,533 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelNrgba64ReadShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelNrgba64Read(nil, func(x, y int, r, g, b, a uint16) {})
+	})
+}
+
+func TestParallelNrgba64ReadShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	assert.Panics(t, func() {
+		ParallelNrgba64Read(img, nil)
+	})
+}
+
+func TestParallelNrgba64ReadShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelNrgba64Read(img, func(xIndex int, yIndex int, acR, acG, acB, acA uint16) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+	})
+}
+
+func TestParallelNrgba64ReadEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelNrgba64ReadE(nil, func(x, y int, r, g, b, a uint16) error {
+			return nil
+		})
+	})
+}
+
+func TestParallelNrgba64ReadEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	assert.Panics(t, func() {
+		ParallelNrgba64ReadE(img, nil)
+	})
+}
+
+func TestParallelNrgba64ReadEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	err := ParallelNrgba64ReadE(img, func(x, y int, r, g, b, a uint16) error {
+		return errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelNrgba64ReadEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	err := ParallelNrgba64ReadE(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) error {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestParallelNrgba64ReadWriteShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelNrgba64ReadWrite(nil, func(x int, y int, r uint16, g uint16, b uint16, a uint16) (uint16, uint16, uint16, uint16) {
+			return r, g, b, a
+		})
+	})
+}
+
+func TestParallelNrgba64ReadWriteShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	assert.Panics(t, func() {
+		ParallelNrgba64ReadWrite(img, nil)
+	})
+}
+
+func TestParallelNrgba64ReadWriteShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelNrgba64ReadWrite(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535
+	})
+
+	expectedImage := mockBlackImageNrgba64()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.NRGBA64At(x, y), img.NRGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelNrgba64ReadWriteShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageNrgba64()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelNrgba64ReadWrite(image, func(x, y int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent
+	})
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := image.NRGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelNrgba64ReadWriteEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelNrgba64ReadWriteE(nil, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+			return r, g, b, a, nil
+		})
+	})
+}
+
+func TestParallelNrgba64ReadWriteEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	assert.Panics(t, func() {
+		ParallelNrgba64ReadWriteE(img, nil)
+	})
+}
+
+func TestParallelNrgba64ReadWriteEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	err := ParallelNrgba64ReadWriteE(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+		return r, g, b, a, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelNrgba64ReadWriteEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelNrgba64ReadWriteE(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535, nil
+	})
+
+	expectedImage := mockBlackImageNrgba64()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.NRGBA64At(x, y), img.NRGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelNrgba64ReadWriteEShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageNrgba64()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	err := ParallelNrgba64ReadWriteE(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16, error) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535, nil
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0, nil
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent, nil
+
+	})
+
+	assert.Nil(t, err)
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := image.NRGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelNrgba64ReadWriteNewShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelNrgba64ReadWriteNew(nil, func(x int, y int, r uint16, g uint16, b uint16, a uint16) (uint16, uint16, uint16, uint16) {
+			return r, g, b, a
+		})
+	})
+}
+
+func TestParallelNrgba64ReadWriteNewShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	assert.Panics(t, func() {
+		ParallelNrgba64ReadWriteNew(img, nil)
+	})
+}
+
+func TestParallelNrgba64ReadWriteNewShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage := ParallelNrgba64ReadWriteNew(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535
+	})
+
+	expectedImage := mockBlackImageNrgba64()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.NRGBA64At(x, y), actualImage.NRGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelNrgba64ReadWriteNewShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageNrgba64()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage := ParallelNrgba64ReadWriteNew(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent
+	})
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := actualImage.NRGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelNrgba64ReadWriteNewEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelNrgba64ReadWriteNewE(nil, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+			return r, g, b, a, nil
+		})
+	})
+}
+
+func TestParallelNrgba64ReadWriteNewEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	assert.Panics(t, func() {
+		ParallelNrgba64ReadWriteNewE(img, nil)
+	})
+}
+
+func TestParallelNrgba64ReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	modifiedImg, err := ParallelNrgba64ReadWriteNewE(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+		return r, g, b, a, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, modifiedImg)
+}
+
+func TestParallelNrgba64ReadWriteNewEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage, err := ParallelNrgba64ReadWriteNewE(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockBlackImageNrgba64()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.NRGBA64At(x, y), actualImage.NRGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelNrgba64ReadWriteENewShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageNrgba64()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage, err := ParallelNrgba64ReadWriteNewE(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16, error) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535, nil
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0, nil
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent, nil
+	})
+
+	assert.Nil(t, err)
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := actualImage.NRGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelNrgba64FunctionsShouldPreserveChannelPrecision(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := image.NewNRGBA64(image.Rect(0, 0, 3, 2))
+	img.SetNRGBA64(1, 1, color.NRGBA64{0x1234, 0x5678, 0x9abc, 0xdef0})
+
+	ParallelNrgba64Read(img, func(x, y int, r, g, b, a uint16) {
+		if x == 1 && y == 1 {
+			assert.Equal(t, []uint16{0x1234, 0x5678, 0x9abc, 0xdef0}, []uint16{r, g, b, a})
+		}
+	})
+
+	dst := ParallelNrgba64ReadWriteNew(img, func(_, _ int, r, g, b, a uint16) (uint16, uint16, uint16, uint16) {
+		return r + 1, g + 1, b + 1, a + 1
+	})
+
+	ParallelNrgba64ReadWrite(img, func(_, _ int, r, g, b, a uint16) (uint16, uint16, uint16, uint16) {
+		return r + 1, g + 1, b + 1, a + 1
+	})
+
+	assert.Equal(t, color.NRGBA64{0x1235, 0x5679, 0x9abd, 0xdef1}, img.NRGBA64At(1, 1))
+	assert.Equal(t, color.NRGBA64{0x0001, 0x0001, 0x0001, 0x0001}, img.NRGBA64At(0, 0))
+	assert.Equal(t, img.Pix, dst.Pix)
+}
+
+func mockWhiteImageNrgba64() *image.NRGBA64 {
+	width, height := 5, 6
+
+	img := image.NewNRGBA64(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	return img
+}
+
+func mockBlackImageNrgba64() *image.NRGBA64 {
+	width, height := 5, 6
+
+	img := image.NewNRGBA64(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Black)
+		}
+	}
+
+	return img
+}
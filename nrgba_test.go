@@ -1,9 +1,11 @@
 package pimit
 
 import (
+	"context"
 	"errors"
 	"image"
 	"image/color"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -481,6 +483,343 @@ func TestParallelNrgbaReadWriteENewShouldAccessPixelsOnce(t *testing.T) {
 	}
 }
 
+func TestParallelNrgbaReduceShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelNrgbaReduce(nil, func() int { return 0 }, func(acc int, x, y int, r, g, b, a uint8) int {
+			return acc
+		}, func(a, b int) int { return a + b })
+	})
+}
+
+func TestParallelNrgbaReduceShouldPanicOnNilFunctions(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageNrgba()
+
+	init := func() int { return 0 }
+	d := func(acc int, x, y int, r, g, b, a uint8) int { return acc }
+	combine := func(a, b int) int { return a + b }
+
+	assert.Panics(t, func() { ParallelNrgbaReduce(img, nil, d, combine) })
+	assert.Panics(t, func() { ParallelNrgbaReduce(img, init, nil, combine) })
+	assert.Panics(t, func() { ParallelNrgbaReduce(img, init, d, nil) })
+}
+
+func TestParallelNrgbaReduceShouldCorrectlyReduce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 4, 29, 41)
+	img := mockCoordinateImageNrgba(bounds)
+
+	expected := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			expected += int(img.NRGBAAt(x, y).B)
+		}
+	}
+
+	for _, workers := range []int{1, 4} {
+		actual := ParallelNrgbaReduce(img, func() int { return 0 }, func(acc int, x, y int, r, g, b, a uint8) int {
+			return acc + int(b)
+		}, func(a, b int) int { return a + b }, WithWorkers(workers))
+
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestParallelNrgbaReduceShouldProduceReproducibleFloatSums(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockCoordinateImageNrgba(image.Rect(-5, 2, 91, 307))
+
+	sum := func(workers int) float32 {
+		return ParallelNrgbaReduce(img, func() float32 { return 0 }, func(acc float32, x, y int, r, g, b, a uint8) float32 {
+			return acc + float32(r)*0.2126e-1 + float32(g)*0.7152e-1 + float32(b)*0.0722e-1
+		}, func(a, b float32) float32 {
+			return a + b
+		}, WithWorkers(workers))
+	}
+
+	expected := sum(1)
+	for _, workers := range []int{2, 3, 4, 7, 16, 64} {
+		assert.Equal(t, expected, sum(workers), "unexpected sum for %d workers", workers)
+	}
+}
+
+func TestParallelNrgbaReduceShouldMergeInDeterministicOrder(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 16, 64)
+	img := mockCoordinateImageNrgba(bounds)
+
+	expected := make([]image.Point, 0, bounds.Dx()*bounds.Dy())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			expected = append(expected, image.Pt(x, y))
+		}
+	}
+
+	for run := 0; run < 10; run += 1 {
+		actual := ParallelNrgbaReduce(img, func() []image.Point { return nil }, func(acc []image.Point, x, y int, _, _, _, _ uint8) []image.Point {
+			return append(acc, image.Pt(x, y))
+		}, func(a, b []image.Point) []image.Point {
+			return append(a, b...)
+		}, WithWorkers(4), WithChunkSize(3))
+
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestParallelNrgbaReduceShouldReturnInitValueForEmptyImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := image.NewNRGBA(image.Rect(0, 0, 0, 0))
+
+	actual := ParallelNrgbaReduce(img, func() int { return 7 }, func(acc int, _, _ int, _, _, _, _ uint8) int {
+		return acc + 1
+	}, func(a, b int) int { return a + b })
+
+	assert.Equal(t, 7, actual)
+}
+
+func TestParallelNrgbaReadWithStateShouldPanicOnNilArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	init := func() int { return 0 }
+	d := func(_ int, x, y int, r, g, b, a uint8) {}
+
+	assert.Panics(t, func() { ParallelNrgbaReadWithState(nil, init, d, nil) })
+	assert.Panics(t, func() { ParallelNrgbaReadWithState(mockWhiteImageNrgba(), nil, d, nil) })
+	assert.Panics(t, func() { ParallelNrgbaReadWithState[int](mockWhiteImageNrgba(), init, nil, nil) })
+}
+
+func TestParallelNrgbaReadWithStateShouldUseWorkerLocalState(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	type state struct {
+		busy int32
+		sum  int
+	}
+
+	bounds := image.Rect(-4, 2, 28, 45)
+	img := mockCoordinateImageNrgba(bounds)
+
+	expected := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			expected += int(img.NRGBAAt(x, y).R)
+		}
+	}
+
+	var (
+		inits      int32 = 0
+		finalizes  int32 = 0
+		sum        int64 = 0
+		contention int32 = 0
+	)
+
+	ParallelNrgbaReadWithState(img, func() *state {
+		atomic.AddInt32(&inits, 1)
+		return &state{}
+	}, func(s *state, x, y int, r, g, b, a uint8) {
+		if !atomic.CompareAndSwapInt32(&s.busy, 0, 1) {
+			atomic.AddInt32(&contention, 1)
+		}
+
+		s.sum += int(r)
+		atomic.StoreInt32(&s.busy, 0)
+	}, func(s *state) {
+		atomic.AddInt32(&finalizes, 1)
+		atomic.AddInt64(&sum, int64(s.sum))
+	}, WithWorkers(4), WithChunkSize(1))
+
+	assert.Equal(t, int64(expected), sum)
+	assert.Equal(t, inits, finalizes)
+	assert.LessOrEqual(t, inits, int32(4))
+	assert.Equal(t, int32(0), contention)
+}
+
+func TestParallelNrgbaReadWriteWithStateShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(3, -6, 19, 12)
+	img := mockCoordinateImageNrgba(bounds)
+
+	ParallelNrgbaReadWriteWithState(img, func() []uint8 {
+		return make([]uint8, 4)
+	}, func(buffer []uint8, x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		buffer[0], buffer[1], buffer[2], buffer[3] = ^r, ^g, ^b, a
+		return buffer[0], buffer[1], buffer[2], buffer[3]
+	}, nil, WithWorkers(3))
+
+	assertInvertedCoordinateImage(t, img, bounds)
+}
+
+func TestParallelNrgbaReadWriteWithStateShouldFinalizeOnPanic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var finalizes int32 = 0
+
+	assert.Panics(t, func() {
+		ParallelNrgbaReadWriteWithState(mockWhiteImageNrgba(), func() int {
+			return 0
+		}, func(_ int, x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+			panic("pimit-test: test panic")
+		}, func(_ int) {
+			atomic.AddInt32(&finalizes, 1)
+		}, WithWorkers(1))
+	})
+
+	assert.Equal(t, int32(1), finalizes)
+}
+
+func TestParallelNrgbaRowsShouldPanicOnNilArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() { ParallelNrgbaRows(nil, func(y int, pix []uint8) {}) })
+	assert.Panics(t, func() { ParallelNrgbaRows(mockWhiteImageNrgba(), nil) })
+	assert.Panics(t, func() { ParallelNrgbaRowsE(nil, func(y int, pix []uint8) error { return nil }) })
+	assert.Panics(t, func() { ParallelNrgbaRowsE(mockWhiteImageNrgba(), nil) })
+	assert.Panics(t, func() { ParallelNrgbaRowsNew(nil, func(y int, src, dst []uint8) {}) })
+	assert.Panics(t, func() { ParallelNrgbaRowsNew(mockWhiteImageNrgba(), nil) })
+	assert.Panics(t, func() { ParallelNrgbaRowsNewE(nil, func(y int, src, dst []uint8) error { return nil }) })
+	assert.Panics(t, func() { ParallelNrgbaRowsNewE(mockWhiteImageNrgba(), nil) })
+}
+
+func TestParallelNrgbaRowsShouldHonorSubImageStride(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	parent := mockCoordinateImageNrgba(image.Rect(-6, -4, 30, 26))
+	bounds := image.Rect(-2, 3, 17, 21)
+
+	for _, relative := range []bool{false, true} {
+		img := mockCoordinateImageNrgba(parent.Bounds())
+		sub := img.SubImage(bounds).(*image.NRGBA)
+
+		leftX := bounds.Min.X
+		if relative {
+			leftX = 0
+		}
+
+		v := newVisitTracker(t, image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+1, bounds.Max.Y), relative)
+		ParallelNrgbaRows(sub, func(y int, pix []uint8) {
+			v.visit(leftX, y, color.RGBA{pix[0], pix[1], pix[2], pix[3]})
+
+			assert.Len(t, pix, 4*bounds.Dx())
+			for offset := 0; offset < len(pix); offset += 4 {
+				pix[offset+0], pix[offset+1], pix[offset+2] = ^pix[offset+0], ^pix[offset+1], ^pix[offset+2]
+			}
+		}, boundsTestOptions(relative)...)
+		v.assertVisitedOnce()
+
+		assertInvertedCoordinateImage(t, img, bounds)
+		assertUntouchedCoordinateImage(t, img, bounds)
+	}
+}
+
+func TestParallelNrgbaRowsNewShouldWriteDestinationRows(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(5, -3, 23, 16)
+	img := mockCoordinateImageNrgba(bounds)
+
+	dst := ParallelNrgbaRowsNew(img, func(y int, src, dst []uint8) {
+		assert.Equal(t, len(src), len(dst))
+		for offset := 0; offset < len(src); offset += 4 {
+			dst[offset+0], dst[offset+1], dst[offset+2], dst[offset+3] = ^src[offset+0], ^src[offset+1], ^src[offset+2], src[offset+3]
+		}
+	}, WithWorkers(3))
+
+	assert.Equal(t, bounds, dst.Bounds())
+	assertInvertedCoordinateImage(t, dst, bounds)
+	assertUntouchedCoordinateImage(t, img, image.Rectangle{})
+
+	dst, err := ParallelNrgbaRowsNewE(img, func(y int, src, dst []uint8) error {
+		copy(dst, src)
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, img.Pix, dst.Pix)
+}
+
+func TestParallelNrgbaRowsEShouldReturnRowError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 2, 11, 19)
+	rowErr := errors.New("pimit-test: test error")
+
+	err := ParallelNrgbaRowsE(mockCoordinateImageNrgba(bounds), func(y int, pix []uint8) error {
+		if y == 9 {
+			return rowErr
+		}
+
+		return nil
+	}, WithWorkers(3))
+
+	var pe *PixelError
+
+	assert.ErrorIs(t, err, rowErr)
+	assert.ErrorAs(t, err, &pe)
+	assert.Equal(t, image.Pt(-3, 9), image.Pt(pe.X, pe.Y))
+
+	dst, err := ParallelNrgbaRowsNewE(mockCoordinateImageNrgba(bounds), func(y int, _, _ []uint8) error {
+		if y == 4 {
+			return rowErr
+		}
+
+		return nil
+	}, WithRelativeCoordinates())
+
+	assert.ErrorAs(t, err, &pe)
+	assert.Nil(t, dst)
+	assert.Equal(t, image.Pt(0, 4), image.Pt(pe.X, pe.Y))
+}
+
+func TestParallelNrgbaRowsShouldStopOnCancelledContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var visits int32 = 0
+	ParallelNrgbaRows(mockWhiteImageNrgba(), func(_ int, _ []uint8) {
+		atomic.AddInt32(&visits, 1)
+	}, WithContext(ctx))
+
+	err := ParallelNrgbaRowsE(mockWhiteImageNrgba(), func(_ int, _ []uint8) error {
+		atomic.AddInt32(&visits, 1)
+		return nil
+	}, WithContext(ctx))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(0), visits)
+}
+
+func BenchmarkNrgbaRowsComparedToPixelDelegate(b *testing.B) {
+	img := mockCoordinateImageNrgba(image.Rect(0, 0, 1920, 1080))
+
+	b.Run("pixel", func(b *testing.B) {
+		for i := 0; i < b.N; i += 1 {
+			ParallelNrgbaReadWrite(img, func(_, _ int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				return ^r, ^g, ^b, a
+			})
+		}
+	})
+
+	b.Run("rows", func(b *testing.B) {
+		for i := 0; i < b.N; i += 1 {
+			ParallelNrgbaRows(img, func(_ int, pix []uint8) {
+				for offset := 0; offset < len(pix); offset += 4 {
+					pix[offset+0], pix[offset+1], pix[offset+2] = ^pix[offset+0], ^pix[offset+1], ^pix[offset+2]
+				}
+			})
+		}
+	})
+}
+
 func mockWhiteImageNrgba() *image.NRGBA {
 	width, height := 5, 6
 
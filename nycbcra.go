@@ -0,0 +1,183 @@
+package pimit
+
+import "image"
+
+type (
+	NYCbCrAReadDelegate               = func(x, y int, luma, cb, cr, a uint8)
+	NYCbCrAReadErrorableDelegate      = func(x, y int, luma, cb, cr, a uint8) error
+	NYCbCrAReadWriteDelegate          = func(x, y int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8)
+	NYCbCrAReadWriteErrorableDelegate = func(x, y int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error)
+)
+
+// Perform a parallel iteration of the pixels of the provided NYCbCrA image. For each pixel, execute the delegate
+// function allowing you to read the color (Y, Cb, Cr and non-premultiplied A as uint8) and coordinates. The Cb and Cr
+// values are the values of the chroma sample covering the pixel according to the subsample ratio of the image. The
+// rows sharing the chroma samples are split into chunks processed by a bounded number of worker goroutines. Use the
+// ParallelYCbCrChromaRead function with the embedded YCbCr image to iterate the chroma planes at their own resolution.
+func ParallelNYCbCrARead(src *image.NYCbCrA, d NYCbCrAReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+
+	it := o.newIteration("ParallelNYCbCrARead", false)
+	defer it.cancel()
+
+	it.scheduleYCbCr(src, nil, func(x, y int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error) {
+		d(x, y, luma, cb, cr, a)
+		return 0, 0, 0, 0, nil
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided NYCbCrA image. For each pixel, execute the delegate
+// function allowing you to read the color (Y, Cb, Cr and non-premultiplied A as uint8) and coordinates. The Cb and Cr
+// values are the values of the chroma sample covering the pixel according to the subsample ratio of the image. The
+// rows sharing the chroma samples are split into chunks processed by a bounded number of worker goroutines. The
+// iteration will break after the first error occurs and the error will be returned.
+func ParallelNYCbCrAReadE(src *image.NYCbCrA, d NYCbCrAReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+
+	it := o.newIteration("ParallelNYCbCrAReadE", true)
+	defer it.cancel()
+
+	it.scheduleYCbCr(src, nil, func(x, y int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error) {
+		return 0, 0, 0, 0, d(x, y, luma, cb, cr, a)
+	})
+
+	return it.err()
+}
+
+// Perform a parallel iteration of the pixels of the provided NYCbCrA image. For each pixel, execute the delegate
+// function allowing you to read the color (Y, Cb, Cr and non-premultiplied A as uint8) and coordinates, the delegate
+// return color will be set at the given coordinates. The Y and A values are set for each pixel, while the Cb and Cr
+// values of a chroma sample are set to the average of the values returned for the pixels covered by the sample. If the
+// bounds of a sub-image are not aligned to the subsampling of the image, the chroma samples on the edges of the bounds
+// are shared with the pixels outside of the bounds, whose chroma is therefore also set to this average. This changes
+// will be applied to the passed image instance. The rows sharing the chroma samples are split into chunks processed by
+// a bounded number of worker goroutines.
+func ParallelNYCbCrAReadWrite(src *image.NYCbCrA, d NYCbCrAReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+
+	it := o.newIteration("ParallelNYCbCrAReadWrite", false)
+	defer it.cancel()
+
+	it.scheduleYCbCr(src, src, func(x, y int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error) {
+		luma, cb, cr, a = d(x, y, luma, cb, cr, a)
+		return luma, cb, cr, a, nil
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided NYCbCrA image. For each pixel, execute the delegate
+// function allowing you to read the color (Y, Cb, Cr and non-premultiplied A as uint8) and coordinates, the delegate
+// return color will be set at the given coordinates. The Y and A values are set for each pixel, while the Cb and Cr
+// values of a chroma sample are set to the average of the values returned for the pixels covered by the sample. If the
+// bounds of a sub-image are not aligned to the subsampling of the image, the chroma samples on the edges of the bounds
+// are shared with the pixels outside of the bounds, whose chroma is therefore also set to this average. This changes
+// will be applied to the passed image instance. The rows sharing the chroma samples are split into chunks processed by
+// a bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned.
+func ParallelNYCbCrAReadWriteE(src *image.NYCbCrA, d NYCbCrAReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+
+	it := o.newIteration("ParallelNYCbCrAReadWriteE", true)
+	defer it.cancel()
+
+	it.scheduleYCbCr(src, src, d)
+
+	return it.commit()
+}
+
+// Perform a parallel iteration of the pixels of the provided NYCbCrA image. For each pixel, execute the delegate
+// function allowing you to read the color (Y, Cb, Cr and non-premultiplied A as uint8) and coordinates, the delegate
+// return color will be set at the given coordinates. The Y and A values are set for each pixel, while the Cb and Cr
+// values of a chroma sample are set to the average of the values returned for the pixels covered by the sample. This
+// changes will be applied to a new image instance with the same subsample ratio, which is returned by the function.
+// The rows sharing the chroma samples are split into chunks processed by a bounded number of worker goroutines.
+func ParallelNYCbCrAReadWriteNew(src *image.NYCbCrA, d NYCbCrAReadWriteDelegate, opts ...Option) *image.NYCbCrA {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	dst := image.NewNYCbCrA(src.Rect, src.SubsampleRatio)
+
+	it := o.newIteration("ParallelNYCbCrAReadWriteNew", false)
+	defer it.cancel()
+
+	it.scheduleYCbCr(src, dst, func(x, y int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error) {
+		luma, cb, cr, a = d(x, y, luma, cb, cr, a)
+		return luma, cb, cr, a, nil
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel iteration of the pixels of the provided NYCbCrA image. For each pixel, execute the delegate
+// function allowing you to read the color (Y, Cb, Cr and non-premultiplied A as uint8) and coordinates, the delegate
+// return color will be set at the given coordinates. The Y and A values are set for each pixel, while the Cb and Cr
+// values of a chroma sample are set to the average of the values returned for the pixels covered by the sample. This
+// changes will be applied to a new image instance with the same subsample ratio, which is returned by the function.
+// The rows sharing the chroma samples are split into chunks processed by a bounded number of worker goroutines. The
+// iteration will break after the first error occurs and the error will be returned.
+func ParallelNYCbCrAReadWriteNewE(src *image.NYCbCrA, d NYCbCrAReadWriteErrorableDelegate, opts ...Option) (*image.NYCbCrA, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	dst := image.NewNYCbCrA(src.Rect, src.SubsampleRatio)
+
+	it := o.newIteration("ParallelNYCbCrAReadWriteNewE", true)
+	defer it.cancel()
+
+	it.scheduleYCbCr(src, dst, d)
+
+	if err := it.err(); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
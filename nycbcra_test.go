@@ -0,0 +1,122 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelNYCbCrAFunctionsShouldPanicOnNilArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockCoordinateImageNYCbCrA(image.Rect(0, 0, 4, 4), image.YCbCrSubsampleRatio420)
+
+	assert.Panics(t, func() { ParallelNYCbCrARead(nil, func(_, _ int, _, _, _, _ uint8) {}) })
+	assert.Panics(t, func() { ParallelNYCbCrARead(img, nil) })
+	assert.Panics(t, func() { ParallelNYCbCrAReadE(nil, func(_, _ int, _, _, _, _ uint8) error { return nil }) })
+	assert.Panics(t, func() { ParallelNYCbCrAReadE(img, nil) })
+	assert.Panics(t, func() {
+		ParallelNYCbCrAReadWrite(nil, func(_, _ int, l, cb, cr, a uint8) (uint8, uint8, uint8, uint8) { return l, cb, cr, a })
+	})
+	assert.Panics(t, func() { ParallelNYCbCrAReadWrite(img, nil) })
+	assert.Panics(t, func() {
+		ParallelNYCbCrAReadWriteE(nil, func(_, _ int, l, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error) { return l, cb, cr, a, nil })
+	})
+	assert.Panics(t, func() { ParallelNYCbCrAReadWriteE(img, nil) })
+	assert.Panics(t, func() {
+		ParallelNYCbCrAReadWriteNew(nil, func(_, _ int, l, cb, cr, a uint8) (uint8, uint8, uint8, uint8) { return l, cb, cr, a })
+	})
+	assert.Panics(t, func() { ParallelNYCbCrAReadWriteNew(img, nil) })
+	assert.Panics(t, func() {
+		ParallelNYCbCrAReadWriteNewE(nil, func(_, _ int, l, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error) { return l, cb, cr, a, nil })
+	})
+	assert.Panics(t, func() { ParallelNYCbCrAReadWriteNewE(img, nil) })
+}
+
+func TestParallelNYCbCrAReadShouldReadAlphaPlane(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, ratio := range mockSubsampleRatios() {
+		img := mockCoordinateImageNYCbCrA(image.Rect(2, 1, 15, 12), ratio)
+
+		ParallelNYCbCrARead(img, func(x, y int, luma, cb, cr, a uint8) {
+			assert.Equal(t, img.NYCbCrAAt(x, y), color.NYCbCrA{color.YCbCr{luma, cb, cr}, a}, ratio.String())
+		}, WithWorkers(3))
+
+		err := ParallelNYCbCrAReadE(img, func(x, y int, luma, cb, cr, a uint8) error {
+			assert.Equal(t, img.NYCbCrAAt(x, y), color.NYCbCrA{color.YCbCr{luma, cb, cr}, a}, ratio.String())
+			return nil
+		}, WithWorkers(3))
+
+		assert.Nil(t, err)
+	}
+}
+
+func TestParallelNYCbCrAReadWriteShouldWriteAlphaPlane(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, ratio := range mockSubsampleRatios() {
+		bounds := image.Rect(2, 1, 15, 12)
+		src := mockCoordinateImageNYCbCrA(bounds, ratio)
+
+		dst := ParallelNYCbCrAReadWriteNew(src, func(_, _ int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8) {
+			return luma, cb, cr, ^a
+		})
+
+		ParallelNYCbCrAReadWrite(src, func(_, _ int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8) {
+			return luma, cb, cr, ^a
+		})
+
+		expected := mockCoordinateImageNYCbCrA(bounds, ratio)
+		for index := range expected.A {
+			expected.A[index] = ^expected.A[index]
+		}
+
+		assert.Equal(t, expected, src, ratio.String())
+		assert.Equal(t, expected, dst, ratio.String())
+	}
+}
+
+func TestParallelNYCbCrAReadWriteEShouldLeaveImageUnchangedOnFailureWhenAtomic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, ratio := range mockSubsampleRatios() {
+		bounds := image.Rect(0, 0, 12, 12)
+		img := mockCoordinateImageNYCbCrA(bounds, ratio)
+
+		err := ParallelNYCbCrAReadWriteE(img, func(x, y int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error) {
+			if x == 7 && y == 8 {
+				return luma, cb, cr, a, errors.New("pimit-test: test error")
+			}
+
+			return ^luma, ^cb, ^cr, ^a, nil
+		}, WithAtomic(), WithWorkers(4), WithChunkSize(1))
+
+		assert.NotNil(t, err)
+		assert.Equal(t, mockCoordinateImageNYCbCrA(bounds, ratio), img, ratio.String())
+
+		dst, err := ParallelNYCbCrAReadWriteNewE(img, func(x, y int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error) {
+			return luma, cb, cr, a, errors.New("pimit-test: test error")
+		})
+
+		assert.NotNil(t, err)
+		assert.Nil(t, dst)
+	}
+}
+
+func mockCoordinateImageNYCbCrA(bounds image.Rectangle, ratio image.YCbCrSubsampleRatio) *image.NYCbCrA {
+	img := image.NewNYCbCrA(bounds, ratio)
+	img.YCbCr = *mockCoordinateImageYCbCr(bounds, ratio)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.A[img.AOffset(x, y)] = uint8(7*x + y)
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,134 @@
+package pimit
+
+import (
+	"context"
+	"image"
+	"image/draw"
+)
+
+// Option is a functional option which can be passed to the iteration functions in order to customize their behaviour.
+type Option func(*options)
+
+type options struct {
+	relative   bool
+	workers    int
+	chunkSize  int
+	executor   *Executor
+	ctx        context.Context
+	policy     ErrorPolicy
+	errorLimit int
+	atomic     bool
+	op         string
+	allocator  func(src image.Image) draw.Image
+	blend      bool
+	linear     bool
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		relative:   false,
+		workers:    0,
+		chunkSize:  0,
+		executor:   nil,
+		ctx:        context.Background(),
+		policy:     FailFast,
+		errorLimit: 0,
+		atomic:     false,
+		op:         "",
+		allocator:  nil,
+		blend:      false,
+		linear:     false,
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			panic("pimit: the provided option is nil")
+		}
+
+		opt(o)
+	}
+
+	return o
+}
+
+// Report the coordinates passed to the delegate function relative to the origin of the image bounds (Bounds().Min)
+// instead of the actual image coordinates. By default the delegate function receives the actual coordinates, which
+// for images with a non-zero bounds origin (e.g. results of SubImage) are not starting at (0, 0).
+func WithRelativeCoordinates() Option {
+	return func(o *options) {
+		o.relative = true
+	}
+}
+
+// Set the maximal number of worker goroutines used to process a single iteration. By default the number of workers is
+// equal to GOMAXPROCS or to the number of workers of the executor if the WithExecutor option is used.
+func WithWorkers(n int) Option {
+	if n <= 0 {
+		panic("pimit: the provided negative or zero worker count is invalid")
+	}
+
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
+// Set the number of consecutive units (rows, clusters or columns) which are claimed and processed by a worker at once.
+// By default the units are split into chunks in a way that every worker processes a few chunks.
+func WithChunkSize(n int) Option {
+	if n <= 0 {
+		panic("pimit: the provided negative or zero chunk size is invalid")
+	}
+
+	return func(o *options) {
+		o.chunkSize = n
+	}
+}
+
+// Bind the iteration to the provided context. The iteration stops promptly after the context is cancelled or its
+// deadline is exceeded. The errorable (E) variants of the functions return the context error wrapped with the
+// coordinates where the processing stopped, the remaining functions stop without reporting the cancellation, so the
+// caller should check the context error on its own.
+func WithContext(ctx context.Context) Option {
+	if ctx == nil {
+		panic("pimit: the provided context is nil")
+	}
+
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// Make the errorable (E) read-write variants of the functions, which modify the passed image (or matrix) instance,
+// atomic. The processed rows (or clusters and columns) are recorded before they are modified and restored if the
+// iteration fails or is stopped with ErrStop, so the image is either fully updated or left unchanged. The additional
+// memory usage is proportional to the processed part of the image. The option has no effect on the remaining functions.
+func WithAtomic() Option {
+	return func(o *options) {
+		o.atomic = true
+	}
+}
+
+// Return the offset which has to be subtracted from the actual image coordinates before passing them to the delegate.
+func (o *options) origin(bounds image.Rectangle) (int, int) {
+	if o.relative {
+		return bounds.Min.X, bounds.Min.Y
+	}
+
+	return 0, 0
+}
+
+// Override the name of the function reported by the errors of the iteration. This is used by the functions which are
+// dispatching the work to other functions of the package. The first provided name is kept, so the name of the outermost
+// function is reported if the work is dispatched through multiple functions.
+func withOperation(op string) Option {
+	return func(o *options) {
+		if o.op == "" {
+			o.op = op
+		}
+	}
+}
+
+// Return a new slice of options extended with the provided options. The provided options slice is not modified.
+func appendOptions(opts []Option, extra ...Option) []Option {
+	return append(opts[:len(opts):len(opts)], extra...)
+}
@@ -0,0 +1,178 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+)
+
+type PaletteTransformDelegate = func(index int, c color.Color) color.Color
+
+// Perform a parallel transformation of the colors of the provided palette. For each color, execute the delegate
+// function allowing you to read the index and the color, the delegate return color will be set at the given index of
+// a new palette instance, which is returned by the function. Transforming the palette of a Paletted image instead of
+// its pixels allows to apply color adjustments once per palette entry. The palette entries are split into chunks
+// processed by a bounded number of worker goroutines.
+func ParallelPaletteTransform(palette color.Palette, d PaletteTransformDelegate, opts ...Option) color.Palette {
+	if palette == nil {
+		panic("pimit: the provided palette reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	dst := make(color.Palette, len(palette))
+
+	it := newOptions(opts).newIteration("ParallelPaletteTransform", false)
+	defer it.cancel()
+
+	it.schedule(len(palette), func(i int, p *cursor) {
+		p.x, p.y = i, 0
+
+		c := d(i, palette[i])
+		if c == nil {
+			panic("pimit: the color returned by the delegate function is nil")
+		}
+
+		dst[i] = c
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel conversion of the provided image to a new Paletted image using the provided palette. Every pixel
+// is mapped to the index of the closest palette color in the same way as by the color.Palette Index method, but the
+// palette colors are converted only once and the consecutive pixels of the same color are mapped without searching
+// the palette again. The *image.RGBA and *image.NRGBA images are accessed directly, without the image.At calls. The
+// rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelRemap(src image.Image, palette color.Palette, opts ...Option) *image.Paletted {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if len(palette) == 0 || len(palette) > 256 {
+		panic("pimit: the provided palette is invalid")
+	}
+
+	entries := make([][4]uint32, len(palette))
+	for index, c := range palette {
+		r, g, b, a := c.RGBA()
+		entries[index] = [4]uint32{r, g, b, a}
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewPaletted(bounds, append(color.Palette(nil), palette...))
+	remap := remapRow(src, entries, originX)
+
+	it := o.newIteration("ParallelRemap", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		yIndex := bounds.Min.Y + i
+		dstIndex := dst.PixOffset(bounds.Min.X, yIndex)
+
+		p.y = yIndex - originY
+		remap(dst.Pix[dstIndex:dstIndex+bounds.Dx()], yIndex, p)
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Return the function mapping the pixels of the row of the provided image with the given y coordinate to the indices
+// of the closest palette entries, which are stored in the provided row of indices. The x coordinate of the cursor is
+// updated relative to the provided origin before every pixel is accessed. The *image.RGBA and *image.NRGBA images are
+// accessed directly, without the image.At calls. The type of the image is resolved once, not for every pixel.
+func remapRow(src image.Image, entries [][4]uint32, originX int) func(row []uint8, yIndex int, p *cursor) {
+	bounds := src.Bounds()
+
+	switch img := src.(type) {
+	case *image.RGBA:
+		return func(row []uint8, yIndex int, p *cursor) {
+			var (
+				srcIndex int          = img.PixOffset(bounds.Min.X, yIndex)
+				cache    paletteCache = paletteCache{entries: entries}
+			)
+
+			for x := range row {
+				p.x = bounds.Min.X + x - originX
+
+				r, g, b, a := color.RGBA{img.Pix[srcIndex+0], img.Pix[srcIndex+1], img.Pix[srcIndex+2], img.Pix[srcIndex+3]}.RGBA()
+				row[x] = cache.index([4]uint32{r, g, b, a})
+				srcIndex += 4
+			}
+		}
+	case *image.NRGBA:
+		return func(row []uint8, yIndex int, p *cursor) {
+			var (
+				srcIndex int          = img.PixOffset(bounds.Min.X, yIndex)
+				cache    paletteCache = paletteCache{entries: entries}
+			)
+
+			for x := range row {
+				p.x = bounds.Min.X + x - originX
+
+				r, g, b, a := color.NRGBA{img.Pix[srcIndex+0], img.Pix[srcIndex+1], img.Pix[srcIndex+2], img.Pix[srcIndex+3]}.RGBA()
+				row[x] = cache.index([4]uint32{r, g, b, a})
+				srcIndex += 4
+			}
+		}
+	default:
+		return func(row []uint8, yIndex int, p *cursor) {
+			cache := paletteCache{entries: entries}
+
+			for x := range row {
+				p.x = bounds.Min.X + x - originX
+
+				r, g, b, a := img.At(bounds.Min.X+x, yIndex).RGBA()
+				row[x] = cache.index([4]uint32{r, g, b, a})
+			}
+		}
+	}
+}
+
+// The palette cache remembers the index of the last mapped color, so the consecutive pixels of the same color are
+// mapped without searching the palette again.
+type paletteCache struct {
+	entries   [][4]uint32
+	last      [4]uint32
+	lastIndex uint8
+	valid     bool
+}
+
+// Return the index of the palette entry closest to the provided color.
+func (pc *paletteCache) index(c [4]uint32) uint8 {
+	if !pc.valid || c != pc.last {
+		pc.last, pc.lastIndex, pc.valid = c, paletteIndex(pc.entries, c), true
+	}
+
+	return pc.lastIndex
+}
+
+// Return the index of the palette entry closest to the provided color using the same metric as the color.Palette
+// Index method, which is the sum of the squared differences of the channels.
+func paletteIndex(entries [][4]uint32, c [4]uint32) uint8 {
+	index, best := 0, uint32(1<<32-1)
+	for i, e := range entries {
+		sum := uint32(0)
+		for channel := 0; channel < 4; channel += 1 {
+			diff := c[channel] - e[channel]
+			sum += (diff * diff) >> 2
+		}
+
+		if sum < best {
+			if sum == 0 {
+				return uint8(i)
+			}
+
+			index, best = i, sum
+		}
+	}
+
+	return uint8(index)
+}
@@ -0,0 +1,96 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelPaletteTransformShouldPanicOnInvalidArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelPaletteTransform(nil, func(_ int, c color.Color) color.Color { return c })
+	})
+
+	assert.Panics(t, func() {
+		ParallelPaletteTransform(palette.Plan9, nil)
+	})
+
+	assert.Panics(t, func() {
+		ParallelPaletteTransform(palette.Plan9, func(_ int, _ color.Color) color.Color { return nil })
+	})
+}
+
+func TestParallelPaletteTransformShouldTransformEveryColorOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	invert := func(_ int, c color.Color) color.Color {
+		rgba := color.RGBAModel.Convert(c).(color.RGBA)
+		return color.RGBA{^rgba.R, ^rgba.G, ^rgba.B, rgba.A}
+	}
+
+	for _, workers := range []int{1, 4} {
+		actual := ParallelPaletteTransform(palette.WebSafe, invert, WithWorkers(workers))
+
+		assert.Len(t, actual, len(palette.WebSafe))
+		for index, c := range palette.WebSafe {
+			assert.Equal(t, invert(index, c), actual[index])
+		}
+	}
+
+	assert.Empty(t, ParallelPaletteTransform(color.Palette{}, invert))
+}
+
+func TestParallelRemapShouldPanicOnInvalidArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRemap(nil, palette.Plan9)
+	})
+
+	assert.Panics(t, func() {
+		ParallelRemap(mockWhiteImageRgba(), nil)
+	})
+
+	assert.Panics(t, func() {
+		ParallelRemap(mockWhiteImageRgba(), make(color.Palette, 257))
+	})
+}
+
+func TestParallelRemapShouldMatchPaletteIndex(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 4, 33, 29)
+	random := rand.New(rand.NewSource(2024))
+
+	nrgba := image.NewNRGBA(bounds)
+	random.Read(nrgba.Pix)
+
+	rgba := image.NewRGBA(bounds)
+	gray := image.NewGray16(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			rgba.Set(x, y, nrgba.At(x, y))
+			gray.Set(x, y, nrgba.At(x, y))
+		}
+	}
+
+	for _, src := range []image.Image{rgba, nrgba, gray} {
+		for _, p := range []color.Palette{palette.Plan9, palette.WebSafe, {color.Black, color.White}} {
+			actual := ParallelRemap(src, p, WithWorkers(3))
+
+			assert.Equal(t, bounds, actual.Bounds())
+			for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+				for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+					assert.Equal(t, uint8(p.Index(src.At(x, y))), actual.ColorIndexAt(x, y))
+				}
+			}
+		}
+	}
+}
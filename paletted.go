@@ -0,0 +1,349 @@
+package pimit
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+)
+
+type (
+	PalettedReadDelegate               = func(x, y int, index uint8)
+	PalettedReadErrorableDelegate      = func(x, y int, index uint8) error
+	PalettedReadWriteDelegate          = func(x, y int, index uint8) uint8
+	PalettedReadWriteErrorableDelegate = func(x, y int, index uint8) (uint8, error)
+)
+
+// Perform a parallel iteration of the pixels of the provided Paletted image. For each pixel, execute the delegate
+// function allowing you to read the palette index (as uint8) and coordinates. The rows are split into chunks processed
+// by a bounded number of worker goroutines.
+func ParallelPalettedRead(src *image.Paletted, d PalettedReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelPalettedRead", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int   = bounds.Min.Y + i
+			baseIndex int   = src.PixOffset(bounds.Min.X, yIndex)
+			index     uint8 = 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			index = src.Pix[baseIndex]
+
+			d(xIndex-originX, yIndex-originY, index)
+			baseIndex += 1
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided Paletted image. For each pixel, execute the delegate
+// function allowing you to read the palette index (as uint8) and coordinates. The rows are split into chunks processed
+// by a bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned.
+func ParallelPalettedReadE(src *image.Paletted, d PalettedReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelPalettedReadE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int   = bounds.Min.Y + i
+			baseIndex int   = src.PixOffset(bounds.Min.X, yIndex)
+			index     uint8 = 0
+			err       error = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			index = src.Pix[baseIndex]
+
+			if err = d(xIndex-originX, yIndex-originY, index); err != nil && it.fail(p, err) {
+				return
+			}
+
+			baseIndex += 1
+		}
+	})
+
+	return it.err()
+}
+
+// Perform a parallel iteration of the pixels of the provided Paletted image. For each pixel, execute the delegate
+// function allowing you to read the palette index (as uint8) and coordinates, the delegate return palette index will be
+// set at the given coordinates, which must be a valid index of the palette of the image, otherwise the function panics.
+// This changes will be applied to the passed image instance. Consider using ParallelReadWriteNew if you want to avoid
+// changes to the original image at the expense of additional allocations. The rows are split into chunks processed by a
+// bounded number of worker goroutines.
+func ParallelPalettedReadWrite(src *image.Paletted, d PalettedReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelPalettedReadWrite", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int   = bounds.Min.Y + i
+			baseIndex int   = src.PixOffset(bounds.Min.X, yIndex)
+			index     uint8 = 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			index = src.Pix[baseIndex]
+
+			index = d(xIndex-originX, yIndex-originY, index)
+
+			if int(index) >= len(src.Palette) {
+				panic("pimit: the palette index returned by the delegate function is invalid")
+			}
+
+			src.Pix[baseIndex] = index
+
+			baseIndex += 1
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided Paletted image. For each pixel, execute the delegate
+// function allowing you to read the palette index (as uint8) and coordinates, the delegate return palette index will be
+// set at the given coordinates, which must be a valid index of the palette of the image, otherwise the pixel fails with
+// an error wrapping the ErrInvalidFormat error. This changes will be applied to the passed image instance. Consider
+// using ParallelReadWriteNewE if you want to avoid changes to the original image at the expense of additional
+// allocations. The rows are split into chunks processed by a bounded number of worker goroutines. The iteration will
+// break after the first error occurs and the error will be returned.
+func ParallelPalettedReadWriteE(src *image.Paletted, d PalettedReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelPalettedReadWriteE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int   = bounds.Min.Y + i
+			baseIndex int   = src.PixOffset(bounds.Min.X, yIndex)
+			index     uint8 = 0
+			err       error = nil
+		)
+
+		if o.atomic {
+			rowIndex, saved := baseIndex, append([]uint8(nil), src.Pix[baseIndex:baseIndex+bounds.Dx()]...)
+			it.record(func() {
+				copy(src.Pix[rowIndex:], saved)
+			})
+		}
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			index = src.Pix[baseIndex]
+
+			index, err = d(xIndex-originX, yIndex-originY, index)
+
+			if err == nil && int(index) >= len(src.Palette) {
+				err = fmt.Errorf("%w: the palette index %d returned by the delegate function is invalid", ErrInvalidFormat, index)
+			}
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				baseIndex += 1
+				continue
+			}
+
+			src.Pix[baseIndex] = index
+
+			baseIndex += 1
+		}
+	})
+
+	return it.commit()
+}
+
+// Perform a parallel iteration of the pixels of the provided Paletted image. For each pixel, execute the delegate
+// function allowing you to read the palette index (as uint8) and coordinates, the delegate return palette index will be
+// set at the given coordinates, which must be a valid index of the palette of the image, otherwise the function panics.
+// This changes will be applied to a new image instance which uses a copy of the palette of the provided image and is
+// returned by the function. The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelPalettedReadWriteNew(src *image.Paletted, d PalettedReadWriteDelegate, opts ...Option) *image.Paletted {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewPaletted(bounds, append(color.Palette(nil), src.Palette...))
+
+	it := o.newIteration("ParallelPalettedReadWriteNew", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int   = bounds.Min.Y + i
+			srcIndex int   = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int   = dst.PixOffset(bounds.Min.X, yIndex)
+			index    uint8 = 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			index = src.Pix[srcIndex]
+
+			index = d(xIndex-originX, yIndex-originY, index)
+
+			if int(index) >= len(dst.Palette) {
+				panic("pimit: the palette index returned by the delegate function is invalid")
+			}
+
+			dst.Pix[dstIndex] = index
+
+			srcIndex += 1
+			dstIndex += 1
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel iteration of the pixels of the provided Paletted image. For each pixel, execute the delegate
+// function allowing you to read the palette index (as uint8) and coordinates, the delegate return palette index will be
+// set at the given coordinates, which must be a valid index of the palette of the image, otherwise the pixel fails with
+// an error wrapping the ErrInvalidFormat error. This changes will be applied to a new image instance which uses a copy
+// of the palette of the provided image and is returned by the function. The rows are split into chunks processed by a
+// bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned.
+func ParallelPalettedReadWriteNewE(src *image.Paletted, d PalettedReadWriteErrorableDelegate, opts ...Option) (*image.Paletted, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := image.NewPaletted(bounds, append(color.Palette(nil), src.Palette...))
+
+	it := o.newIteration("ParallelPalettedReadWriteNewE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex   int   = bounds.Min.Y + i
+			srcIndex int   = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int   = dst.PixOffset(bounds.Min.X, yIndex)
+			index    uint8 = 0
+			err      error = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			index = src.Pix[srcIndex]
+
+			index, err = d(xIndex-originX, yIndex-originY, index)
+
+			if err == nil && int(index) >= len(dst.Palette) {
+				err = fmt.Errorf("%w: the palette index %d returned by the delegate function is invalid", ErrInvalidFormat, index)
+			}
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				srcIndex += 1
+				dstIndex += 1
+				continue
+			}
+
+			dst.Pix[dstIndex] = index
+
+			srcIndex += 1
+			dstIndex += 1
+		}
+	})
+
+	if err := it.err(); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
@@ -0,0 +1,436 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelPalettedReadShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelPalettedRead(nil, func(x, y int, v uint8) {})
+	})
+}
+
+func TestParallelPalettedReadShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	assert.Panics(t, func() {
+		ParallelPalettedRead(img, nil)
+	})
+}
+
+func TestParallelPalettedReadShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	exV := uint8(1)
+
+	ParallelPalettedRead(img, func(xIndex int, yIndex int, acV uint8) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+	})
+}
+
+func TestParallelPalettedReadEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelPalettedReadE(nil, func(x, y int, v uint8) error {
+			return nil
+		})
+	})
+}
+
+func TestParallelPalettedReadEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	assert.Panics(t, func() {
+		ParallelPalettedReadE(img, nil)
+	})
+}
+
+func TestParallelPalettedReadEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	err := ParallelPalettedReadE(img, func(x, y int, v uint8) error {
+		return errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelPalettedReadEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	exV := uint8(1)
+
+	err := ParallelPalettedReadE(img, func(xIndex, yIndex int, acV uint8) error {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestParallelPalettedReadWriteShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelPalettedReadWrite(nil, func(x, y int, v uint8) uint8 {
+			return v
+		})
+	})
+}
+
+func TestParallelPalettedReadWriteShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	assert.Panics(t, func() {
+		ParallelPalettedReadWrite(img, nil)
+	})
+}
+
+func TestParallelPalettedReadWriteShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	exV := uint8(1)
+
+	ParallelPalettedReadWrite(img, func(xIndex, yIndex int, acV uint8) uint8 {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0
+	})
+
+	expectedImage := mockBlackImagePaletted()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+	assert.Equal(t, expectedImage.Pix, img.Pix)
+}
+
+func TestParallelPalettedReadWriteEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelPalettedReadWriteE(nil, func(x, y int, v uint8) (uint8, error) {
+			return v, nil
+		})
+	})
+}
+
+func TestParallelPalettedReadWriteEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	assert.Panics(t, func() {
+		ParallelPalettedReadWriteE(img, nil)
+	})
+}
+
+func TestParallelPalettedReadWriteEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	err := ParallelPalettedReadWriteE(img, func(x, y int, v uint8) (uint8, error) {
+		return v, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelPalettedReadWriteEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	exV := uint8(1)
+
+	err := ParallelPalettedReadWriteE(img, func(xIndex, yIndex int, acV uint8) (uint8, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockBlackImagePaletted()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+	assert.Equal(t, expectedImage.Pix, img.Pix)
+}
+
+func TestParallelPalettedReadWriteEShouldLeaveImageUnchangedOnFailureWhenAtomic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	err := ParallelPalettedReadWriteE(img, func(x, y int, v uint8) (uint8, error) {
+		if x == 3 && y == 4 {
+			return v, errors.New("pimit-test: test errror")
+		}
+
+		return 0, nil
+	}, WithAtomic(), WithWorkers(2), WithChunkSize(1))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, mockWhiteImagePaletted().Pix, img.Pix)
+}
+
+func TestParallelPalettedReadWriteNewShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelPalettedReadWriteNew(nil, func(x, y int, v uint8) uint8 {
+			return v
+		})
+	})
+}
+
+func TestParallelPalettedReadWriteNewShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	assert.Panics(t, func() {
+		ParallelPalettedReadWriteNew(img, nil)
+	})
+}
+
+func TestParallelPalettedReadWriteNewShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	exV := uint8(1)
+
+	actualImage := ParallelPalettedReadWriteNew(img, func(xIndex, yIndex int, acV uint8) uint8 {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0
+	})
+
+	expectedImage := mockBlackImagePaletted()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+	assert.Equal(t, expectedImage.Pix, actualImage.Pix)
+	assert.Equal(t, mockWhiteImagePaletted().Pix, img.Pix)
+}
+
+func TestParallelPalettedReadWriteNewEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelPalettedReadWriteNewE(nil, func(x, y int, v uint8) (uint8, error) {
+			return v, nil
+		})
+	})
+}
+
+func TestParallelPalettedReadWriteNewEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	assert.Panics(t, func() {
+		ParallelPalettedReadWriteNewE(img, nil)
+	})
+}
+
+func TestParallelPalettedReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	modifiedImg, err := ParallelPalettedReadWriteNewE(img, func(x, y int, v uint8) (uint8, error) {
+		return v, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, modifiedImg)
+}
+
+func TestParallelPalettedReadWriteNewEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	exV := uint8(1)
+
+	actualImage, err := ParallelPalettedReadWriteNewE(img, func(xIndex, yIndex int, acV uint8) (uint8, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exV, acV)
+
+		return 0, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockBlackImagePaletted()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+	assert.Equal(t, expectedImage.Pix, actualImage.Pix)
+}
+
+func TestParallelPalettedFunctionsShouldPanicOnInvalidIndex(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.PanicsWithError(t, "pimit: ParallelPalettedReadWrite panicked on x=0 y=0 with: pimit: the palette index returned by the delegate function is invalid", func() {
+		ParallelPalettedReadWrite(mockWhiteImagePaletted(), func(_, _ int, index uint8) uint8 {
+			return 2
+		}, WithWorkers(1))
+	})
+
+	assert.PanicsWithError(t, "pimit: ParallelPalettedReadWriteNew panicked on x=0 y=0 with: pimit: the palette index returned by the delegate function is invalid", func() {
+		ParallelPalettedReadWriteNew(mockWhiteImagePaletted(), func(_, _ int, index uint8) uint8 {
+			return 2
+		}, WithWorkers(1))
+	})
+}
+
+func TestParallelPalettedErrorableFunctionsShouldReturnErrorOnInvalidIndex(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	err := ParallelPalettedReadWriteE(img, func(x, y int, index uint8) (uint8, error) {
+		if x == 1 && y == 2 {
+			return 2, nil
+		}
+
+		return index, nil
+	}, WithWorkers(1))
+
+	var pe *PixelError
+
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+	assert.ErrorAs(t, err, &pe)
+	assert.Equal(t, "ParallelPalettedReadWriteE", pe.Op)
+	assert.Equal(t, 1, pe.X)
+	assert.Equal(t, 2, pe.Y)
+	assert.Equal(t, mockWhiteImagePaletted().Pix, img.Pix)
+
+	dst, err := ParallelPalettedReadWriteNewE(img, func(_, _ int, index uint8) (uint8, error) {
+		return 255, nil
+	})
+
+	assert.Nil(t, dst)
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+	assert.ErrorAs(t, err, &pe)
+	assert.Equal(t, "ParallelPalettedReadWriteNewE", pe.Op)
+}
+
+func TestParallelPalettedReadWriteNewShouldCopyPalette(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImagePaletted()
+
+	dst := ParallelPalettedReadWriteNew(img, func(_, _ int, index uint8) uint8 {
+		return index
+	})
+
+	img.Palette[0] = color.White
+
+	assert.Equal(t, color.Palette{color.Black, color.White}, dst.Palette)
+	assert.Equal(t, img.Pix, dst.Pix)
+}
+
+func TestParallelPalettedReadShouldHonorSubImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	palette := make(color.Palette, 256)
+	for index := range palette {
+		palette[index] = color.Gray{uint8(index)}
+	}
+
+	parent := image.NewPaletted(image.Rect(0, 0, 16, 16), palette)
+	for index := range parent.Pix {
+		parent.Pix[index] = uint8(index)
+	}
+
+	sub := parent.SubImage(image.Rect(3, 5, 11, 13)).(*image.Paletted)
+
+	ParallelPalettedRead(sub, func(x, y int, index uint8) {
+		assert.Equal(t, parent.ColorIndexAt(x, y), index)
+	}, WithWorkers(3))
+}
+
+func mockWhiteImagePaletted() *image.Paletted {
+	width, height := 5, 6
+
+	img := image.NewPaletted(image.Rect(0, 0, width, height), color.Palette{color.Black, color.White})
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	return img
+}
+
+func mockBlackImagePaletted() *image.Paletted {
+	width, height := 5, 6
+
+	img := image.NewPaletted(image.Rect(0, 0, width, height), color.Palette{color.Black, color.White})
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Black)
+		}
+	}
+
+	return img
+}
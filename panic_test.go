@@ -0,0 +1,390 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestErrorableFunctionsShouldReturnPanicErrorOnDelegatePanic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 2, 9, 14)
+	panicX, panicY := 4, 9
+
+	for name, iterate := range mockErrorableIterations(bounds) {
+		for _, workers := range []int{1, 3} {
+			err := iterate(func(x, y int) error {
+				if x == panicX && y == panicY {
+					panic("pimit-test: test panic")
+				}
+
+				return nil
+			}, WithWorkers(workers))
+
+			var pe *PanicError
+
+			assert.ErrorAs(t, err, &pe, name)
+			assert.Equal(t, panicX, pe.X, name)
+			assert.Equal(t, panicY, pe.Y, name)
+			assert.Equal(t, "pimit-test: test panic", pe.Value, name)
+			assert.NotEmpty(t, pe.Stack, name)
+			assert.Equal(t, name, pe.Op, name)
+			assert.Contains(t, pe.Error(), "x=4 y=9", name)
+		}
+	}
+}
+
+func TestErrorableFunctionsShouldUnwrapPanicErrorValue(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	panicErr := errors.New("pimit-test: test error")
+
+	err := ParallelRgbaReadE(mockWhiteImageRgba(), func(_, _ int, _, _, _, _ uint8) error {
+		panic(panicErr)
+	})
+
+	assert.ErrorIs(t, err, panicErr)
+}
+
+func TestFunctionsShouldRepanicOnCallerGoroutine(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(2, -5, 12, 7)
+	panicX, panicY := 6, 1
+
+	for name, iterate := range mockIterations(bounds) {
+		for _, workers := range []int{1, 3} {
+			recovered := func() (r any) {
+				defer func() {
+					r = recover()
+				}()
+
+				iterate(func(x, y int) {
+					if x == panicX && y == panicY {
+						panic("pimit-test: test panic")
+					}
+				}, WithWorkers(workers))
+
+				return nil
+			}()
+
+			pe, ok := recovered.(*PanicError)
+
+			assert.True(t, ok, name)
+			if ok {
+				assert.Equal(t, panicX, pe.X, name)
+				assert.Equal(t, panicY, pe.Y, name)
+				assert.Equal(t, "pimit-test: test panic", pe.Value, name)
+			}
+		}
+	}
+}
+
+func TestExecutorShouldSurviveDelegatePanic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	e := NewExecutor(2)
+	defer e.Close()
+
+	assert.Panics(t, func() {
+		ParallelIndices(10, 10, func(_, _ int) {
+			panic("pimit-test: test panic")
+		}, WithExecutor(e))
+	})
+
+	err := ParallelReadE(mockWhiteImageImage(), func(_, _ int, _ color.Color) error {
+		return nil
+	}, WithExecutor(e))
+
+	assert.Nil(t, err)
+}
+
+// Create a set of iterations using every non-errorable function on a mock image (or matrix) with the provided bounds.
+// The visit function is executed by the delegate on every visited pixel (or matrix entry).
+func mockIterations(bounds image.Rectangle) map[string]func(visit func(x, y int), opts ...Option) {
+	return map[string]func(visit func(x, y int), opts ...Option){
+		"ParallelIndices": func(visit func(x, y int), opts ...Option) {
+			ParallelIndices(bounds.Max.X, bounds.Max.Y, func(x, y int) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelRead": func(visit func(x, y int), opts ...Option) {
+			ParallelRead(mockCoordinateImageRgba(bounds), func(x, y int, _ color.Color) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelReadWrite(mockCoordinateImageRgba(bounds), func(x, y int, c color.Color) color.Color {
+				visit(x, y)
+				return c
+			}, opts...)
+		},
+		"ParallelDistributedReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelDistributedReadWrite(mockCoordinateImageRgba(bounds), 5, func(x, y int, c color.Color) color.Color {
+				visit(x, y)
+				return c
+			}, opts...)
+		},
+		"ParallelReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelReadWriteNew(mockCoordinateImageRgba(bounds), func(x, y int, c color.Color) color.Color {
+				visit(x, y)
+				return c
+			}, opts...)
+		},
+		"ParallelRgbaRead": func(visit func(x, y int), opts ...Option) {
+			ParallelRgbaRead(mockCoordinateImageRgba(bounds), func(x, y int, _, _, _, _ uint8) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelRgbaReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelRgbaReadWrite(mockCoordinateImageRgba(bounds), func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelRgbaReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelRgbaReadWriteNew(mockCoordinateImageRgba(bounds), func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelNrgbaRead": func(visit func(x, y int), opts ...Option) {
+			ParallelNrgbaRead(mockCoordinateImageNrgba(bounds), func(x, y int, _, _, _, _ uint8) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelNrgbaReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelNrgbaReadWrite(mockCoordinateImageNrgba(bounds), func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelNrgbaReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelNrgbaReadWriteNew(mockCoordinateImageNrgba(bounds), func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelRgba64Read": func(visit func(x, y int), opts ...Option) {
+			ParallelRgba64Read(mockCoordinateImageRgba64(bounds), func(x, y int, _, _, _, _ uint16) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelRgba64ReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelRgba64ReadWrite(mockCoordinateImageRgba64(bounds), func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelRgba64ReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelRgba64ReadWriteNew(mockCoordinateImageRgba64(bounds), func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelNrgba64Read": func(visit func(x, y int), opts ...Option) {
+			ParallelNrgba64Read(mockCoordinateImageNrgba64(bounds), func(x, y int, _, _, _, _ uint16) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelNrgba64ReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelNrgba64ReadWrite(mockCoordinateImageNrgba64(bounds), func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelNrgba64ReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelNrgba64ReadWriteNew(mockCoordinateImageNrgba64(bounds), func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelGrayRead": func(visit func(x, y int), opts ...Option) {
+			ParallelGrayRead(mockCoordinateImageGray(bounds), func(x, y int, _ uint8) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelGrayReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelGrayReadWrite(mockCoordinateImageGray(bounds), func(x, y int, v uint8) uint8 {
+				visit(x, y)
+				return v
+			}, opts...)
+		},
+		"ParallelGrayReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelGrayReadWriteNew(mockCoordinateImageGray(bounds), func(x, y int, v uint8) uint8 {
+				visit(x, y)
+				return v
+			}, opts...)
+		},
+		"ParallelGray16Read": func(visit func(x, y int), opts ...Option) {
+			ParallelGray16Read(mockCoordinateImageGray16(bounds), func(x, y int, _ uint16) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelGray16ReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelGray16ReadWrite(mockCoordinateImageGray16(bounds), func(x, y int, v uint16) uint16 {
+				visit(x, y)
+				return v
+			}, opts...)
+		},
+		"ParallelGray16ReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelGray16ReadWriteNew(mockCoordinateImageGray16(bounds), func(x, y int, v uint16) uint16 {
+				visit(x, y)
+				return v
+			}, opts...)
+		},
+		"ParallelYCbCrRead": func(visit func(x, y int), opts ...Option) {
+			ParallelYCbCrRead(mockCoordinateImageYCbCr(bounds, image.YCbCrSubsampleRatio420), func(x, y int, _, _, _ uint8) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelYCbCrReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelYCbCrReadWrite(mockCoordinateImageYCbCr(bounds, image.YCbCrSubsampleRatio420), func(x, y int, luma, cb, cr uint8) (uint8, uint8, uint8) {
+				visit(x, y)
+				return luma, cb, cr
+			}, opts...)
+		},
+		"ParallelYCbCrReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelYCbCrReadWriteNew(mockCoordinateImageYCbCr(bounds, image.YCbCrSubsampleRatio420), func(x, y int, luma, cb, cr uint8) (uint8, uint8, uint8) {
+				visit(x, y)
+				return luma, cb, cr
+			}, opts...)
+		},
+		"ParallelNYCbCrARead": func(visit func(x, y int), opts ...Option) {
+			ParallelNYCbCrARead(mockCoordinateImageNYCbCrA(bounds, image.YCbCrSubsampleRatio422), func(x, y int, _, _, _, _ uint8) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelPalettedRead": func(visit func(x, y int), opts ...Option) {
+			ParallelPalettedRead(mockCoordinateImagePaletted(bounds), func(x, y int, _ uint8) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelPalettedReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelPalettedReadWrite(mockCoordinateImagePaletted(bounds), func(x, y int, index uint8) uint8 {
+				visit(x, y)
+				return index
+			}, opts...)
+		},
+		"ParallelPalettedReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelPalettedReadWriteNew(mockCoordinateImagePaletted(bounds), func(x, y int, index uint8) uint8 {
+				visit(x, y)
+				return index
+			}, opts...)
+		},
+		"ParallelCmykRead": func(visit func(x, y int), opts ...Option) {
+			ParallelCmykRead(mockCoordinateImageCmyk(bounds), func(x, y int, _, _, _, _ uint8) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelCmykReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelCmykReadWrite(mockCoordinateImageCmyk(bounds), func(x, y int, c, m, ye, k uint8) (uint8, uint8, uint8, uint8) {
+				visit(x, y)
+				return c, m, ye, k
+			}, opts...)
+		},
+		"ParallelCmykReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelCmykReadWriteNew(mockCoordinateImageCmyk(bounds), func(x, y int, c, m, ye, k uint8) (uint8, uint8, uint8, uint8) {
+				visit(x, y)
+				return c, m, ye, k
+			}, opts...)
+		},
+		"ParallelAlphaRead": func(visit func(x, y int), opts ...Option) {
+			ParallelAlphaRead(mockCoordinateImageAlpha(bounds), func(x, y int, _ uint8) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelAlphaReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelAlphaReadWrite(mockCoordinateImageAlpha(bounds), func(x, y int, v uint8) uint8 {
+				visit(x, y)
+				return v
+			}, opts...)
+		},
+		"ParallelAlphaReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelAlphaReadWriteNew(mockCoordinateImageAlpha(bounds), func(x, y int, v uint8) uint8 {
+				visit(x, y)
+				return v
+			}, opts...)
+		},
+		"ParallelAlpha16Read": func(visit func(x, y int), opts ...Option) {
+			ParallelAlpha16Read(mockCoordinateImageAlpha16(bounds), func(x, y int, _ uint16) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelAlpha16ReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelAlpha16ReadWrite(mockCoordinateImageAlpha16(bounds), func(x, y int, v uint16) uint16 {
+				visit(x, y)
+				return v
+			}, opts...)
+		},
+		"ParallelAlpha16ReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelAlpha16ReadWriteNew(mockCoordinateImageAlpha16(bounds), func(x, y int, v uint16) uint16 {
+				visit(x, y)
+				return v
+			}, opts...)
+		},
+		"ParallelMaskedReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelMaskedReadWrite(mockCoordinateImageGray(bounds), image.Opaque, func(x, y int, c color.Color) color.Color {
+				visit(x, y)
+				return c
+			}, opts...)
+		},
+		"ParallelRgbaMaskedReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelRgbaMaskedReadWrite(mockCoordinateImageRgba(bounds), image.Opaque, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelNrgbaMaskedReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelNrgbaMaskedReadWrite(mockCoordinateImageNrgba(bounds), image.Opaque, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelFloatRead": func(visit func(x, y int), opts ...Option) {
+			ParallelFloatRead(mockCoordinateImageFloat(bounds), func(x, y int, _, _, _, _ float32) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelFloatReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelFloatReadWrite(mockCoordinateImageFloat(bounds), func(x, y int, r, g, b, a float32) (float32, float32, float32, float32) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelFloatReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelFloatReadWriteNew(mockCoordinateImageFloat(bounds), func(x, y int, r, g, b, a float32) (float32, float32, float32, float32) {
+				visit(x, y)
+				return r, g, b, a
+			}, opts...)
+		},
+		"ParallelColorSpaceRead": func(visit func(x, y int), opts ...Option) {
+			ParallelColorSpaceRead(mockCoordinateImageRgba(bounds), ColorSpaceHsv, func(x, y int, _, _, _, _ float32) {
+				visit(x, y)
+			}, opts...)
+		},
+		"ParallelColorSpaceReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelColorSpaceReadWrite(mockCoordinateImageNrgba(bounds), ColorSpaceLab, func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32) {
+				visit(x, y)
+				return c1, c2, c3, a
+			}, opts...)
+		},
+		"ParallelColorSpaceReadWriteNew": func(visit func(x, y int), opts ...Option) {
+			ParallelColorSpaceReadWriteNew(mockCoordinateImageRgba64(bounds), ColorSpaceOklab, func(x, y int, c1, c2, c3, a float32) (float32, float32, float32, float32) {
+				visit(x, y)
+				return c1, c2, c3, a
+			}, opts...)
+		},
+		"ParallelMatrixReadWrite": func(visit func(x, y int), opts ...Option) {
+			ParallelMatrixReadWrite(mockCustomMatrix(bounds.Max.X, bounds.Max.Y, 0), func(x, y int, v int) int {
+				visit(x, y)
+				return v
+			}, opts...)
+		},
+	}
+}
@@ -0,0 +1,204 @@
+package pimit
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Perform a parallel decoding of the Portable FloatMap (PFM) image read from the provided reader to a new float image.
+// The whole input is read into memory and the scanlines, which are stored from the bottom to the top of the image, are
+// decoded in parallel. Both the color (PF) and the grayscale (Pf) variants are supported, the byte order is selected by
+// the sign of the scale factor and the alpha channel of the decoded pixels is set to one. The scanlines are split into
+// chunks processed by a bounded number of worker goroutines. The returned error wraps ErrInvalidFormat if the data is
+// not valid.
+func ParallelDecodePfm(r io.Reader, opts ...Option) (*FloatImage, error) {
+	if r == nil {
+		panic("pimit: the provided reader is nil")
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		fields [4]string
+		offset int = 0
+	)
+
+	for index := range fields {
+		if fields[index], offset = nextPfmField(data, offset); fields[index] == "" {
+			return nil, fmt.Errorf("%w: the pfm header is truncated", ErrInvalidFormat)
+		}
+	}
+
+	channels := 0
+	switch fields[0] {
+	case "PF":
+		channels = 3
+	case "Pf":
+		channels = 1
+	default:
+		return nil, fmt.Errorf("%w: the pfm signature is missing", ErrInvalidFormat)
+	}
+
+	width, errWidth := strconv.Atoi(fields[1])
+	height, errHeight := strconv.Atoi(fields[2])
+	if errWidth != nil || errHeight != nil || !validImageSize(width, height) {
+		return nil, fmt.Errorf("%w: the pfm size %sx%s is invalid", ErrInvalidFormat, fields[1], fields[2])
+	}
+
+	scale, err := strconv.ParseFloat(fields[3], 32)
+	if err != nil || scale == 0 || math.IsNaN(scale) {
+		return nil, fmt.Errorf("%w: the pfm scale %s is invalid", ErrInvalidFormat, fields[3])
+	}
+
+	var order binary.ByteOrder = binary.BigEndian
+	if scale < 0 {
+		order = binary.LittleEndian
+	}
+
+	stride := 4 * channels * width
+
+	// NOTE: The header is terminated with a single whitespace character, which is followed by the raster. The CRLF
+	// terminator written by some encoders is accepted as a whole, otherwise the raster would be shifted by one byte. The
+	// first byte of the raster can be equal to the LF character, so the terminator is recognized by the length of the
+	// remaining data, which is exactly one byte longer than the raster.
+	offset += 1
+	if rest := len(data) - offset; data[offset-1] == '\r' && rest%stride == 1 && rest/stride == height {
+		offset += 1
+	}
+
+	if (len(data)-offset)/(4*channels) < width || (len(data)-offset)/stride < height {
+		return nil, fmt.Errorf("%w: the pfm raster is truncated", ErrInvalidFormat)
+	}
+
+	o := newOptions(opts)
+	dst := NewFloatImage(image.Rect(0, 0, width, height))
+
+	it := o.newIteration("ParallelDecodePfm", true)
+	defer it.cancel()
+
+	it.schedule(height, func(i int, p *cursor) {
+		var (
+			srcIndex int = offset + (height-1-i)*stride
+			dstIndex int = dst.PixOffset(0, i)
+		)
+
+		p.x, p.y = 0, i
+		if it.interrupted(p) {
+			return
+		}
+
+		for x := 0; x < width; x += 1 {
+			if channels == 3 {
+				dst.Pix[dstIndex+0] = math.Float32frombits(order.Uint32(data[srcIndex+0:]))
+				dst.Pix[dstIndex+1] = math.Float32frombits(order.Uint32(data[srcIndex+4:]))
+				dst.Pix[dstIndex+2] = math.Float32frombits(order.Uint32(data[srcIndex+8:]))
+			} else {
+				v := math.Float32frombits(order.Uint32(data[srcIndex:]))
+				dst.Pix[dstIndex+0], dst.Pix[dstIndex+1], dst.Pix[dstIndex+2] = v, v, v
+			}
+
+			dst.Pix[dstIndex+3] = 1
+
+			srcIndex += 4 * channels
+			dstIndex += 4
+		}
+	})
+
+	if err := it.err(); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
+
+// Perform a parallel encoding of the provided float image to the color (PF) variant of the Portable FloatMap (PFM)
+// format using the little-endian byte order and write it to the provided writer. The scanlines are encoded in parallel
+// from the bottom to the top of the image and written after all of them are encoded. The alpha channel is discarded
+// and the values of the channels are stored without any conversion. The rows are split into chunks processed by a
+// bounded number of worker goroutines.
+func ParallelEncodePfm(w io.Writer, img *FloatImage, opts ...Option) error {
+	if w == nil {
+		panic("pimit: the provided writer is nil")
+	}
+
+	if img == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := img.Bounds()
+	originX, originY := o.origin(bounds)
+	width, height := bounds.Dx(), bounds.Dy()
+	if bounds.Empty() {
+		return errors.New("pimit: the provided image is empty and can not be encoded")
+	}
+
+	header := fmt.Sprintf("PF\n%d %d\n-1.0\n", width, height)
+	stride := 12 * width
+
+	data := make([]byte, len(header)+height*stride)
+	copy(data, header)
+
+	it := o.newIteration("ParallelEncodePfm", true)
+	defer it.cancel()
+
+	it.schedule(height, func(i int, p *cursor) {
+		var (
+			yIndex   int = bounds.Min.Y + i
+			srcIndex int = img.PixOffset(bounds.Min.X, yIndex)
+			dstIndex int = len(header) + (height-1-i)*stride
+		)
+
+		p.x, p.y = bounds.Min.X-originX, yIndex-originY
+		if it.interrupted(p) {
+			return
+		}
+
+		for x := 0; x < width; x += 1 {
+			binary.LittleEndian.PutUint32(data[dstIndex+0:], math.Float32bits(img.Pix[srcIndex+0]))
+			binary.LittleEndian.PutUint32(data[dstIndex+4:], math.Float32bits(img.Pix[srcIndex+1]))
+			binary.LittleEndian.PutUint32(data[dstIndex+8:], math.Float32bits(img.Pix[srcIndex+2]))
+
+			srcIndex += 4
+			dstIndex += 12
+		}
+	})
+
+	if err := it.err(); err != nil {
+		return err
+	}
+
+	_, err := w.Write(data)
+	return err
+}
+
+// Return the whitespace separated field of the PFM header starting at the provided offset and the offset of the
+// whitespace character which terminates it. An empty field is returned if the data ends before the terminator.
+func nextPfmField(data []byte, offset int) (string, int) {
+	for offset < len(data) && isPfmWhitespace(data[offset]) {
+		offset += 1
+	}
+
+	start := offset
+	for offset < len(data) && !isPfmWhitespace(data[offset]) {
+		offset += 1
+	}
+
+	if offset == len(data) {
+		return "", offset
+	}
+
+	return string(data[start:offset]), offset
+}
+
+func isPfmWhitespace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -0,0 +1,208 @@
+package pimit
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelDecodePfmShouldPanicOnNilReader(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelDecodePfm(nil)
+	})
+}
+
+func TestParallelDecodePfmShouldDecodeColorImages(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img, err := ParallelDecodePfm(bytes.NewReader(mockPfmData()), WithWorkers(2))
+
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 2, 2), img.Bounds())
+	assert.Equal(t, FloatColor{1, 2, 3, 1}, img.FloatAt(0, 0))
+	assert.Equal(t, FloatColor{-4, 0.5, 1e6, 1}, img.FloatAt(1, 0))
+	assert.Equal(t, FloatColor{0.25, 0, 0, 1}, img.FloatAt(0, 1))
+	assert.Equal(t, FloatColor{0, 0.125, 7, 1}, img.FloatAt(1, 1))
+}
+
+func TestParallelDecodePfmShouldDecodeGrayscaleBigEndianImages(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	data := []byte("Pf\n3 1\n2.5\n")
+	for _, v := range []float32{0.5, 1.5, 100} {
+		data = binary.BigEndian.AppendUint32(data, math.Float32bits(v))
+	}
+
+	img, err := ParallelDecodePfm(bytes.NewReader(data))
+
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 3, 1), img.Bounds())
+	assert.Equal(t, FloatColor{0.5, 0.5, 0.5, 1}, img.FloatAt(0, 0))
+	assert.Equal(t, FloatColor{1.5, 1.5, 1.5, 1}, img.FloatAt(1, 0))
+	assert.Equal(t, FloatColor{100, 100, 100, 1}, img.FloatAt(2, 0))
+}
+
+func TestParallelDecodePfmShouldAcceptCrlfHeaderTerminators(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	expected, err := ParallelDecodePfm(bytes.NewReader(mockPfmData()))
+	assert.Nil(t, err)
+
+	data := append([]byte("PF\r\n2 2\r\n-1.0\r\n"), mockPfmData()[12:]...)
+
+	img, err := ParallelDecodePfm(bytes.NewReader(data))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, img)
+}
+
+func TestParallelDecodePfmShouldNotSkipRasterLineFeedAfterCrTerminator(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	data := []byte("Pf\r1 2\r-1.0\r")
+	data = binary.LittleEndian.AppendUint32(data, 0x3f80000a)
+	data = binary.LittleEndian.AppendUint32(data, math.Float32bits(2))
+
+	assert.Equal(t, byte('\n'), data[12])
+
+	img, err := ParallelDecodePfm(bytes.NewReader(data))
+
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), img.Bounds())
+	assert.Equal(t, math.Float32frombits(0x3f80000a), img.FloatAt(0, 1).R)
+	assert.Equal(t, float32(2), img.FloatAt(0, 0).R)
+}
+
+func TestParallelDecodePfmShouldReturnErrorOnInvalidData(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	data := mockPfmData()
+
+	cases := map[string][]byte{
+		"missing signature": append([]byte("P6\n2 2\n-1.0\n"), data[12:]...),
+		"truncated header":  []byte("PF\n2 2\n"),
+		"invalid size":      append([]byte("PF\n2 -2\n-1.0\n"), data[12:]...),
+		"invalid scale":     append([]byte("PF\n2 2\n0.0\n"), data[12:]...),
+		"truncated raster":  data[:len(data)-1],
+		"truncated crlf":    append([]byte("PF\r\n2 2\r\n-1.0\r\n"), data[12:len(data)-2]...),
+	}
+
+	for name, data := range cases {
+		img, err := ParallelDecodePfm(bytes.NewReader(data))
+
+		assert.Nil(t, img, name)
+		assert.ErrorIs(t, err, ErrInvalidFormat, name)
+	}
+}
+
+func TestParallelDecodePfmShouldReturnErrorOnCancelledContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	img, err := ParallelDecodePfm(bytes.NewReader(mockPfmData()), WithContext(ctx))
+
+	assert.Nil(t, img)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParallelEncodePfmShouldPanicOnNilArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelEncodePfm(nil, NewFloatImage(image.Rect(0, 0, 1, 1)))
+	})
+
+	assert.Panics(t, func() {
+		ParallelEncodePfm(&bytes.Buffer{}, nil)
+	})
+}
+
+func TestParallelEncodePfmShouldReturnErrorOnEmptyImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.NotNil(t, ParallelEncodePfm(&bytes.Buffer{}, NewFloatImage(image.Rectangle{})))
+}
+
+func TestParallelEncodePfmShouldEncodeColorImages(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	expected := mockPfmData()
+
+	img := NewFloatImage(image.Rect(3, -1, 5, 1))
+	img.SetFloat(3, -1, FloatColor{1, 2, 3, 0.5})
+	img.SetFloat(4, -1, FloatColor{-4, 0.5, 1e6, 1})
+	img.SetFloat(3, 0, FloatColor{0.25, 0, 0, 0})
+	img.SetFloat(4, 0, FloatColor{0, 0.125, 7, 1})
+
+	actual := &bytes.Buffer{}
+	assert.Nil(t, ParallelEncodePfm(actual, img))
+	assert.Equal(t, expected, actual.Bytes())
+}
+
+func TestParallelEncodePfmShouldRoundTripValues(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-5, 2, 40, 31)
+
+	img := NewFloatImage(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.SetFloat(x, y, FloatColor{float32(x) / 3, float32(y) * 1e-7, float32(x*y) * 1e7, 1})
+		}
+	}
+
+	img.SetFloat(0, 10, FloatColor{float32(math.Inf(-1)), float32(math.SmallestNonzeroFloat32), math.MaxFloat32, 1})
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, ParallelEncodePfm(buffer, img, WithChunkSize(3)))
+
+	actual, err := ParallelDecodePfm(buffer, WithWorkers(4))
+	assert.Nil(t, err)
+
+	for y := 0; y < bounds.Dy(); y += 1 {
+		for x := 0; x < bounds.Dx(); x += 1 {
+			assert.Equal(t, img.FloatAt(bounds.Min.X+x, bounds.Min.Y+y), actual.FloatAt(x, y))
+		}
+	}
+}
+
+func TestParallelEncodePfmShouldReturnErrorOnCancelledContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	buffer := &bytes.Buffer{}
+	err := ParallelEncodePfm(buffer, NewFloatImage(image.Rect(0, 0, 4, 4)), WithContext(ctx))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, buffer.Len())
+}
+
+func TestParallelEncodePfmShouldReturnWriterError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	err := ParallelEncodePfm(&mockFailingWriter{}, NewFloatImage(image.Rect(0, 0, 4, 4)))
+
+	assert.ErrorIs(t, err, errMockWrite)
+}
+
+// The fixture of a little-endian color image with the size of 2x2. The scanlines are stored from the bottom to the top.
+func mockPfmData() []byte {
+	data := []byte("PF\n2 2\n-1.0\n")
+	for _, v := range []float32{0.25, 0, 0, 0, 0.125, 7, 1, 2, 3, -4, 0.5, 1e6} {
+		data = binary.LittleEndian.AppendUint32(data, math.Float32bits(v))
+	}
+
+	return data
+}
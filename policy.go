@@ -0,0 +1,44 @@
+package pimit
+
+// ErrorPolicy describes how the errorable (E) variants of the functions handle the errors returned by the delegate.
+type ErrorPolicy int
+
+const (
+	// Stop the whole iteration after the first error occurs and return it. The returned error depends on the timing
+	// of the worker goroutines if more than one error occurs. This is the default policy.
+	FailFast ErrorPolicy = iota
+
+	// Continue the iteration after an error occurs and return all errors joined using errors.Join. The failed pixels
+	// (or matrix entries) are not modified. The errors are ordered by the position of the failed elements in the
+	// iteration order. The number of collected errors can be limited using the WithErrorLimit option.
+	CollectAll
+
+	// Return the error which occurred first in the iteration order, the lowest (y, x) for images and the lowest (x, y)
+	// for matrices, regardless of the timing of the worker goroutines. The elements following the failed one are not
+	// processed, the preceding elements are processed in order to find a possible earlier error.
+	DeterministicFirst
+)
+
+// Set the policy used by the errorable (E) variants of the functions to handle the errors returned by the delegate.
+// By default the FailFast policy is used.
+func WithErrorPolicy(policy ErrorPolicy) Option {
+	if policy < FailFast || policy > DeterministicFirst {
+		panic("pimit: the provided error policy is invalid")
+	}
+
+	return func(o *options) {
+		o.policy = policy
+	}
+}
+
+// Set the maximal number of errors collected by the iteration using the CollectAll error policy. The iteration stops
+// after the limit is reached. By default the number of collected errors is not limited.
+func WithErrorLimit(n int) Option {
+	if n <= 0 {
+		panic("pimit: the provided negative or zero error limit is invalid")
+	}
+
+	return func(o *options) {
+		o.errorLimit = n
+	}
+}
@@ -0,0 +1,172 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestWithErrorPolicyShouldPanicOnInvalidPolicy(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		WithErrorPolicy(ErrorPolicy(-1))
+	})
+
+	assert.Panics(t, func() {
+		WithErrorPolicy(DeterministicFirst + 1)
+	})
+}
+
+func TestWithErrorLimitShouldPanicOnInvalidLimit(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		WithErrorLimit(0)
+	})
+
+	assert.Panics(t, func() {
+		WithErrorLimit(-2)
+	})
+}
+
+func TestErrorableFunctionsShouldCollectAllErrors(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 12, 12)
+	failing := func(x, y int) bool {
+		return (x+y)%5 == 0
+	}
+
+	expected := 0
+	for y := 0; y < bounds.Dy(); y += 1 {
+		for x := 0; x < bounds.Dx(); x += 1 {
+			if failing(x, y) {
+				expected += 1
+			}
+		}
+	}
+
+	for name, iterate := range mockErrorableIterations(bounds) {
+		for _, workers := range []int{1, 4} {
+			err := iterate(func(x, y int) error {
+				if failing(x, y) {
+					return errors.New("pimit-test: test error")
+				}
+
+				return nil
+			}, WithErrorPolicy(CollectAll), WithWorkers(workers), WithChunkSize(1))
+
+			joined, ok := err.(interface{ Unwrap() []error })
+			assert.True(t, ok, name)
+			if !ok {
+				continue
+			}
+
+			errs := joined.Unwrap()
+			assert.Len(t, errs, expected, name)
+
+			previous := -1
+			for _, e := range errs {
+				var pe *PixelError
+
+				assert.ErrorAs(t, e, &pe, name)
+				assert.True(t, failing(pe.X, pe.Y), name)
+
+				order := pe.Y*bounds.Dx() + pe.X
+				if name == "ParallelMatrixReadWriteE" {
+					order = pe.X*bounds.Dy() + pe.Y
+				}
+
+				assert.Greater(t, order, previous, name)
+				previous = order
+			}
+		}
+	}
+}
+
+func TestErrorableFunctionsShouldCollectLimitedNumberOfErrors(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 16, 16)
+
+	for name, iterate := range mockErrorableIterations(bounds) {
+		for _, workers := range []int{1, 4} {
+			err := iterate(func(_, _ int) error {
+				return errors.New("pimit-test: test error")
+			}, WithErrorPolicy(CollectAll), WithErrorLimit(5), WithWorkers(workers))
+
+			joined, ok := err.(interface{ Unwrap() []error })
+			assert.True(t, ok, name)
+			if ok {
+				assert.Len(t, joined.Unwrap(), 5, name)
+			}
+		}
+	}
+}
+
+func TestErrorableFunctionsShouldNotModifyFailedPixelsWhenCollectingErrors(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba()
+
+	err := ParallelRgbaReadWriteE(img, func(x, y int, _, _, _, _ uint8) (uint8, uint8, uint8, uint8, error) {
+		if x == y {
+			return 0, 0, 0, 0, errors.New("pimit-test: test error")
+		}
+
+		return 0, 0, 0, 0xff, nil
+	}, WithErrorPolicy(CollectAll))
+
+	assert.NotNil(t, err)
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			r, _, _, _ := img.At(x, y).RGBA()
+			if x == y {
+				assert.Equal(t, uint32(0xffff), r)
+			} else {
+				assert.Equal(t, uint32(0), r)
+			}
+		}
+	}
+}
+
+func TestErrorableFunctionsShouldReturnDeterministicFirstError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 24, 24)
+	failing := map[image.Point]bool{
+		image.Pt(20, 3):  true,
+		image.Pt(4, 7):   true,
+		image.Pt(9, 7):   true,
+		image.Pt(1, 15):  true,
+		image.Pt(23, 23): true,
+	}
+
+	for name, iterate := range mockErrorableIterations(bounds) {
+		expected := image.Pt(20, 3)
+		if name == "ParallelMatrixReadWriteE" {
+			expected = image.Pt(1, 15)
+		}
+
+		for run := 0; run < 10; run += 1 {
+			err := iterate(func(x, y int) error {
+				if failing[image.Pt(x, y)] {
+					return errors.New("pimit-test: test error")
+				}
+
+				return nil
+			}, WithErrorPolicy(DeterministicFirst), WithWorkers(4), WithChunkSize(1))
+
+			var pe *PixelError
+
+			assert.ErrorAs(t, err, &pe, name)
+			assert.Equal(t, expected, image.Pt(pe.X, pe.Y), name)
+		}
+	}
+}
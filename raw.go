@@ -0,0 +1,397 @@
+package pimit
+
+import "image"
+
+type (
+	RawReadDelegate               = func(x, y int, r, g, b, a uint16)
+	RawReadErrorableDelegate      = func(x, y int, r, g, b, a uint16) error
+	RawReadWriteDelegate          = func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16)
+	RawReadWriteErrorableDelegate = func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error)
+)
+
+// Perform a parallel iteration of the pixels of the provided raw image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as uint16 normalized to the full range) and coordinates. The rows are
+// split into chunks processed by a bounded number of worker goroutines.
+func ParallelRawRead(src *RawImage, d RawReadDelegate, opts ...Option) {
+	src.validate()
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	codec := rawCodecs[src.Format]
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelRawRead", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			baseIndex  int    = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			r, g, b, a = codec.decode(src.Pix[baseIndex:])
+
+			d(xIndex-originX, yIndex-originY, r, g, b, a)
+			baseIndex += codec.size
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided raw image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as uint16 normalized to the full range) and coordinates. The rows are
+// split into chunks processed by a bounded number of worker goroutines. The iteration will break after the first error
+// occurs and the error will be returned.
+func ParallelRawReadE(src *RawImage, d RawReadErrorableDelegate, opts ...Option) error {
+	src.validate()
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	codec := rawCodecs[src.Format]
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelRawReadE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			baseIndex  int    = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+			err        error  = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r, g, b, a = codec.decode(src.Pix[baseIndex:])
+
+			if err = d(xIndex-originX, yIndex-originY, r, g, b, a); err != nil && it.fail(p, err) {
+				return
+			}
+
+			baseIndex += codec.size
+		}
+	})
+
+	return it.err()
+}
+
+// Perform a parallel iteration of the pixels of the provided raw image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as uint16 normalized to the full range) and coordinates, the delegate
+// return color will be set at the given coordinates. This changes will be applied to the passed image instance.
+// Consider using ParallelReadWriteNew if you want to avoid changes to the original image at the expense of additional
+// allocations. The rows are split into chunks processed by a bounded number of worker goroutines.
+func ParallelRawReadWrite(src *RawImage, d RawReadWriteDelegate, opts ...Option) {
+	src.validate()
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	codec := rawCodecs[src.Format]
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelRawReadWrite", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			baseIndex  int    = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			r, g, b, a = codec.decode(src.Pix[baseIndex:])
+
+			r, g, b, a = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			codec.encode(src.Pix[baseIndex:], r, g, b, a)
+
+			baseIndex += codec.size
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided raw image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as uint16 normalized to the full range) and coordinates, the delegate
+// return color will be set at the given coordinates. This changes will be applied to the passed image instance.
+// Consider using ParallelReadWriteNewE if you want to avoid changes to the original image at the expense of additional
+// allocations. The rows are split into chunks processed by a bounded number of worker goroutines. The iteration will
+// break after the first error occurs and the error will be returned.
+func ParallelRawReadWriteE(src *RawImage, d RawReadWriteErrorableDelegate, opts ...Option) error {
+	src.validate()
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	codec := rawCodecs[src.Format]
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelRawReadWriteE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			baseIndex  int    = src.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+			err        error  = nil
+		)
+
+		if o.atomic {
+			rowIndex, saved := baseIndex, append([]uint8(nil), src.Pix[baseIndex:baseIndex+codec.size*bounds.Dx()]...)
+			it.record(func() {
+				copy(src.Pix[rowIndex:], saved)
+			})
+		}
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r, g, b, a = codec.decode(src.Pix[baseIndex:])
+
+			r, g, b, a, err = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				baseIndex += codec.size
+				continue
+			}
+
+			codec.encode(src.Pix[baseIndex:], r, g, b, a)
+
+			baseIndex += codec.size
+		}
+	})
+
+	return it.commit()
+}
+
+// Perform a parallel iteration of the pixels of the provided raw image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as uint16 normalized to the full range) and coordinates, the delegate
+// return color will be set at the given coordinates. This changes will be applied to a new image instance which uses
+// the pixel format of the source image and is returned by the function. The rows are split into chunks processed by a
+// bounded number of worker goroutines.
+func ParallelRawReadWriteNew(src *RawImage, d RawReadWriteDelegate, opts ...Option) *RawImage {
+	src.validate()
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	codec := rawCodecs[src.Format]
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := NewRawImage(src.Width, src.Height, src.Format)
+
+	it := o.newIteration("ParallelRawReadWriteNew", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			srcIndex   int    = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int    = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			r, g, b, a = codec.decode(src.Pix[srcIndex:])
+
+			r, g, b, a = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			codec.encode(dst.Pix[dstIndex:], r, g, b, a)
+
+			srcIndex += codec.size
+			dstIndex += codec.size
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
+
+// Perform a parallel iteration of the pixels of the provided raw image. For each pixel, execute the delegate function
+// allowing you to read the color (R, G, B and A as uint16 normalized to the full range) and coordinates, the delegate
+// return color will be set at the given coordinates. This changes will be applied to a new image instance which uses
+// the pixel format of the source image and is returned by the function. The rows are split into chunks processed by a
+// bounded number of worker goroutines. The iteration will break after the first error occurs and the error will be
+// returned.
+func ParallelRawReadWriteNewE(src *RawImage, d RawReadWriteErrorableDelegate, opts ...Option) (*RawImage, error) {
+	src.validate()
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	codec := rawCodecs[src.Format]
+	bounds := src.Bounds()
+	originX, originY := o.origin(bounds)
+	dst := NewRawImage(src.Width, src.Height, src.Format)
+
+	it := o.newIteration("ParallelRawReadWriteNewE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex     int    = bounds.Min.Y + i
+			srcIndex   int    = src.PixOffset(bounds.Min.X, yIndex)
+			dstIndex   int    = dst.PixOffset(bounds.Min.X, yIndex)
+			r, g, b, a uint16 = 0, 0, 0, 0
+			err        error  = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			r, g, b, a = codec.decode(src.Pix[srcIndex:])
+
+			r, g, b, a, err = d(xIndex-originX, yIndex-originY, r, g, b, a)
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				srcIndex += codec.size
+				dstIndex += codec.size
+				continue
+			}
+
+			codec.encode(dst.Pix[dstIndex:], r, g, b, a)
+
+			srcIndex += codec.size
+			dstIndex += codec.size
+		}
+	})
+
+	if err := it.err(); err != nil {
+		return nil, err
+	} else {
+		return dst, nil
+	}
+}
+
+// Perform a parallel conversion of the provided raw image to an RGBA image. The RawRgba8 images are not copied, the
+// returned image shares the buffer with the raw image. The pixels of the remaining formats are converted to a new
+// image, the channels are normalized in the same way as by the ParallelRaw* functions and truncated to 8 bits. The rows
+// are split into chunks processed by a bounded number of worker goroutines.
+func ParallelRawToRgba(src *RawImage, opts ...Option) *image.RGBA {
+	src.validate()
+
+	bounds := src.Bounds()
+	if src.Format == RawRgba8 {
+		return &image.RGBA{
+			Pix:    src.Pix[:(src.Height-1)*src.Stride+4*src.Width],
+			Stride: src.Stride,
+			Rect:   bounds,
+		}
+	}
+
+	o := newOptions(opts)
+	codec := rawCodecs[src.Format]
+	dst := image.NewRGBA(bounds)
+
+	it := o.newIteration("ParallelRawToRgba", false)
+	defer it.cancel()
+
+	it.schedule(src.Height, func(i int, p *cursor) {
+		var (
+			srcRow     []uint8 = src.Pix[src.PixOffset(0, i):]
+			dstRow     []uint8 = dst.Pix[dst.PixOffset(0, i):]
+			r, g, b, a uint16  = 0, 0, 0, 0
+		)
+
+		p.x, p.y = 0, i
+		switch src.Format {
+		case RawBgra8:
+			for srcIndex, dstIndex := 0, 0; dstIndex < 4*src.Width; srcIndex, dstIndex = srcIndex+4, dstIndex+4 {
+				dstRow[dstIndex+0] = srcRow[srcIndex+2]
+				dstRow[dstIndex+1] = srcRow[srcIndex+1]
+				dstRow[dstIndex+2] = srcRow[srcIndex+0]
+				dstRow[dstIndex+3] = srcRow[srcIndex+3]
+			}
+		case RawArgb8:
+			for srcIndex, dstIndex := 0, 0; dstIndex < 4*src.Width; srcIndex, dstIndex = srcIndex+4, dstIndex+4 {
+				dstRow[dstIndex+0] = srcRow[srcIndex+1]
+				dstRow[dstIndex+1] = srcRow[srcIndex+2]
+				dstRow[dstIndex+2] = srcRow[srcIndex+3]
+				dstRow[dstIndex+3] = srcRow[srcIndex+0]
+			}
+		case RawRgb24:
+			for srcIndex, dstIndex := 0, 0; dstIndex < 4*src.Width; srcIndex, dstIndex = srcIndex+3, dstIndex+4 {
+				dstRow[dstIndex+0] = srcRow[srcIndex+0]
+				dstRow[dstIndex+1] = srcRow[srcIndex+1]
+				dstRow[dstIndex+2] = srcRow[srcIndex+2]
+				dstRow[dstIndex+3] = 0xff
+			}
+		case RawBgr24:
+			for srcIndex, dstIndex := 0, 0; dstIndex < 4*src.Width; srcIndex, dstIndex = srcIndex+3, dstIndex+4 {
+				dstRow[dstIndex+0] = srcRow[srcIndex+2]
+				dstRow[dstIndex+1] = srcRow[srcIndex+1]
+				dstRow[dstIndex+2] = srcRow[srcIndex+0]
+				dstRow[dstIndex+3] = 0xff
+			}
+		default:
+			for srcIndex, dstIndex := 0, 0; dstIndex < 4*src.Width; srcIndex, dstIndex = srcIndex+codec.size, dstIndex+4 {
+				r, g, b, a = codec.decode(srcRow[srcIndex:])
+
+				dstRow[dstIndex+0] = uint8(r >> 8)
+				dstRow[dstIndex+1] = uint8(g >> 8)
+				dstRow[dstIndex+2] = uint8(b >> 8)
+				dstRow[dstIndex+3] = uint8(a >> 8)
+			}
+		}
+	})
+
+	it.repanic()
+
+	return dst
+}
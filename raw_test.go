@@ -0,0 +1,603 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelRawReadShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRawRead(nil, func(x, y int, r, g, b, a uint16) {})
+	})
+}
+
+func TestParallelRawReadShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	assert.Panics(t, func() {
+		ParallelRawRead(img, nil)
+	})
+}
+
+func TestParallelRawReadShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelRawRead(img, func(xIndex int, yIndex int, acR, acG, acB, acA uint16) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+	})
+}
+
+func TestParallelRawReadEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRawReadE(nil, func(x, y int, r, g, b, a uint16) error {
+			return nil
+		})
+	})
+}
+
+func TestParallelRawReadEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	assert.Panics(t, func() {
+		ParallelRawReadE(img, nil)
+	})
+}
+
+func TestParallelRawReadEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	err := ParallelRawReadE(img, func(x, y int, r, g, b, a uint16) error {
+		return errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelRawReadEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	err := ParallelRawReadE(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) error {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestParallelRawReadWriteShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRawReadWrite(nil, func(x int, y int, r uint16, g uint16, b uint16, a uint16) (uint16, uint16, uint16, uint16) {
+			return r, g, b, a
+		})
+	})
+}
+
+func TestParallelRawReadWriteShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	assert.Panics(t, func() {
+		ParallelRawReadWrite(img, nil)
+	})
+}
+
+func TestParallelRawReadWriteShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelRawReadWrite(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535
+	})
+
+	expectedImage := mockBlackImageRaw()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.RGBA64At(x, y), img.RGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelRawReadWriteShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageRaw()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelRawReadWrite(image, func(x, y int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent
+	})
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := image.RGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelRawReadWriteEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRawReadWriteE(nil, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+			return r, g, b, a, nil
+		})
+	})
+}
+
+func TestParallelRawReadWriteEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	assert.Panics(t, func() {
+		ParallelRawReadWriteE(img, nil)
+	})
+}
+
+func TestParallelRawReadWriteEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	err := ParallelRawReadWriteE(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+		return r, g, b, a, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelRawReadWriteEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelRawReadWriteE(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535, nil
+	})
+
+	expectedImage := mockBlackImageRaw()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.RGBA64At(x, y), img.RGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelRawReadWriteEShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageRaw()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	err := ParallelRawReadWriteE(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16, error) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535, nil
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0, nil
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent, nil
+
+	})
+
+	assert.Nil(t, err)
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := image.RGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelRawReadWriteNewShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRawReadWriteNew(nil, func(x int, y int, r uint16, g uint16, b uint16, a uint16) (uint16, uint16, uint16, uint16) {
+			return r, g, b, a
+		})
+	})
+}
+
+func TestParallelRawReadWriteNewShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	assert.Panics(t, func() {
+		ParallelRawReadWriteNew(img, nil)
+	})
+}
+
+func TestParallelRawReadWriteNewShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage := ParallelRawReadWriteNew(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535
+	})
+
+	expectedImage := mockBlackImageRaw()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.RGBA64At(x, y), actualImage.RGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelRawReadWriteNewShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageRaw()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage := ParallelRawReadWriteNew(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent
+	})
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := actualImage.RGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelRawReadWriteNewEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRawReadWriteNewE(nil, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+			return r, g, b, a, nil
+		})
+	})
+}
+
+func TestParallelRawReadWriteNewEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	assert.Panics(t, func() {
+		ParallelRawReadWriteNewE(img, nil)
+	})
+}
+
+func TestParallelRawReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	modifiedImg, err := ParallelRawReadWriteNewE(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+		return r, g, b, a, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, modifiedImg)
+}
+
+func TestParallelRawReadWriteNewEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage, err := ParallelRawReadWriteNewE(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockBlackImageRaw()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.RGBA64At(x, y), actualImage.RGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelRawReadWriteENewShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageRaw()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage, err := ParallelRawReadWriteNewE(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16, error) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535, nil
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0, nil
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent, nil
+	})
+
+	assert.Nil(t, err)
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := actualImage.RGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelRawFunctionsShouldPanicOnInvalidImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	invalid := []*RawImage{
+		{Pix: make([]byte, 16), Width: 2, Height: 2, Stride: 8, Format: RawFormat(42)},
+		{Pix: make([]byte, 16), Width: 0, Height: 2, Stride: 8, Format: RawBgra8},
+		{Pix: make([]byte, 16), Width: 2, Height: 2, Stride: 7, Format: RawBgra8},
+		{Pix: make([]byte, 15), Width: 2, Height: 2, Stride: 8, Format: RawBgra8},
+	}
+
+	for _, img := range invalid {
+		assert.Panics(t, func() {
+			ParallelRawRead(img, func(x, y int, r, g, b, a uint16) {})
+		})
+
+		assert.Panics(t, func() {
+			ParallelRawReadWriteNew(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16) {
+				return r, g, b, a
+			})
+		})
+	}
+}
+
+func TestParallelRawReadWriteShouldPreserveRowPadding(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := &RawImage{Pix: make([]byte, 4*49+46), Width: 23, Height: 5, Stride: 49, Format: RawRgb565Le}
+	for index := range img.Pix {
+		img.Pix[index] = 0xaa
+	}
+
+	ParallelRawReadWrite(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16) {
+		return 0xffff, 0xffff, 0xffff, 0xffff
+	}, WithChunkSize(1))
+
+	assert.Equal(t, image.Rect(0, 0, 23, 5), img.Bounds())
+
+	for y := 0; y < 5; y += 1 {
+		for x := 0; x < 23; x += 1 {
+			assert.Equal(t, color.RGBA64{0xffff, 0xffff, 0xffff, 0xffff}, img.RGBA64At(x, y))
+		}
+
+		if y < 4 {
+			assert.Equal(t, []byte{0xaa, 0xaa, 0xaa}, img.Pix[y*49+46:(y+1)*49])
+		}
+	}
+}
+
+func TestParallelRawReadWriteEShouldReportCoordinatesOfFailedPixel(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRaw()
+
+	err := ParallelRawReadWriteE(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+		if x == 3 && y == 4 {
+			return r, g, b, a, errors.New("pimit-test: test error")
+		}
+
+		return r, g, b, a, nil
+	})
+
+	var pe *PixelError
+
+	assert.ErrorAs(t, err, &pe)
+	assert.Equal(t, "ParallelRawReadWriteE", pe.Op)
+	assert.Equal(t, 3, pe.X)
+	assert.Equal(t, 4, pe.Y)
+}
+
+func TestParallelRawReadWriteEShouldLeaveImageUnchangedOnAtomicFailure(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for format := RawRgba8; format <= RawRgba16Be; format += 1 {
+		img := NewRawImage(17, 13, format)
+		for y := 0; y < 13; y += 1 {
+			for x := 0; x < 17; x += 1 {
+				img.Set(x, y, color.RGBA{uint8(x * 15), uint8(y * 19), 0, 255})
+			}
+		}
+
+		expected := append([]byte(nil), img.Pix...)
+
+		err := ParallelRawReadWriteE(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+			if x == 16 && y == 12 {
+				return r, g, b, a, errors.New("pimit-test: test error")
+			}
+
+			return 0, 0, 0xffff, 0xffff, nil
+		}, WithAtomic(), WithWorkers(4), WithChunkSize(1))
+
+		assert.NotNil(t, err)
+		assert.Equal(t, expected, img.Pix)
+	}
+}
+
+func mockWhiteImageRaw() *RawImage {
+	width, height := 5, 6
+
+	img := NewRawImage(width, height, RawBgra8)
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	return img
+}
+
+func mockBlackImageRaw() *RawImage {
+	width, height := 5, 6
+
+	img := NewRawImage(width, height, RawBgra8)
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Black)
+		}
+	}
+
+	return img
+}
@@ -0,0 +1,262 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// RawFormat describes the layout of a single pixel of a raw pixel buffer. The channels of the formats with the alpha
+// channel are expected to be premultiplied by the alpha, in the same way as the channels of the image.RGBA image. The
+// pixels of the formats without the alpha channel are read as opaque and the alpha of the written colors is discarded.
+type RawFormat int
+
+const (
+	// Four bytes per pixel in the R, G, B, A order. The layout is the same as the layout of the image.RGBA image.
+	RawRgba8 RawFormat = iota
+	// Four bytes per pixel in the B, G, R, A order.
+	RawBgra8
+	// Four bytes per pixel in the A, R, G, B order.
+	RawArgb8
+	// Three bytes per pixel in the R, G, B order. The pixels are opaque.
+	RawRgb24
+	// Three bytes per pixel in the B, G, R order. The pixels are opaque.
+	RawBgr24
+	// A little-endian 16-bit word per pixel with 5 bits of R, 6 bits of G and 5 bits of B, starting from the most
+	// significant bit. The pixels are opaque.
+	RawRgb565Le
+	// A big-endian 16-bit word per pixel with 5 bits of R, 6 bits of G and 5 bits of B, starting from the most
+	// significant bit. The pixels are opaque.
+	RawRgb565Be
+	// Four little-endian 16-bit words per pixel in the R, G, B, A order.
+	RawRgba16Le
+	// Four big-endian 16-bit words per pixel in the R, G, B, A order. The layout is the same as the layout of the
+	// image.RGBA64 image.
+	RawRgba16Be
+)
+
+// RawImage describes a raw pixel buffer, e.g. a frame received from a capture library or a framebuffer. The pixel at
+// (x, y) starts at Pix[y*Stride + x*Format.BytesPerPixel()] and the coordinates are always starting at (0, 0). The
+// image implements the draw.Image interface using the color.RGBA64Model, so it can also be accessed by the general
+// functions of the package.
+type RawImage struct {
+	Pix    []byte
+	Width  int
+	Height int
+	Stride int
+	Format RawFormat
+}
+
+// Return a new raw image with the provided size and pixel format. The rows of the image are not padded.
+func NewRawImage(width, height int, format RawFormat) *RawImage {
+	if !format.valid() {
+		panic("pimit: the provided raw pixel format is invalid")
+	}
+
+	size := format.BytesPerPixel()
+	if width <= 0 || height <= 0 || height > math.MaxInt/size/width {
+		panic("pimit: the provided raw image size is invalid")
+	}
+
+	return &RawImage{
+		Pix:    make([]byte, width*height*size),
+		Width:  width,
+		Height: height,
+		Stride: width * size,
+		Format: format,
+	}
+}
+
+// Return the index of the first byte of the pixel at (x, y).
+func (r *RawImage) PixOffset(x, y int) int {
+	return y*r.Stride + x*r.Format.BytesPerPixel()
+}
+
+func (r *RawImage) ColorModel() color.Model { return color.RGBA64Model }
+
+func (r *RawImage) Bounds() image.Rectangle { return image.Rect(0, 0, r.Width, r.Height) }
+
+func (r *RawImage) At(x, y int) color.Color {
+	return r.RGBA64At(x, y)
+}
+
+func (r *RawImage) RGBA64At(x, y int) color.RGBA64 {
+	if !(image.Point{x, y}.In(r.Bounds())) {
+		return color.RGBA64{}
+	}
+
+	red, green, blue, alpha := rawCodecs[r.Format].decode(r.Pix[r.PixOffset(x, y):])
+	return color.RGBA64{red, green, blue, alpha}
+}
+
+func (r *RawImage) Set(x, y int, c color.Color) {
+	red, green, blue, alpha := c.RGBA()
+	r.SetRGBA64(x, y, color.RGBA64{uint16(red), uint16(green), uint16(blue), uint16(alpha)})
+}
+
+func (r *RawImage) SetRGBA64(x, y int, c color.RGBA64) {
+	if !(image.Point{x, y}.In(r.Bounds())) {
+		return
+	}
+
+	rawCodecs[r.Format].encode(r.Pix[r.PixOffset(x, y):], c.R, c.G, c.B, c.A)
+}
+
+// Return the number of bytes used to store a single pixel.
+func (f RawFormat) BytesPerPixel() int {
+	if !f.valid() {
+		panic("pimit: the provided raw pixel format is invalid")
+	}
+
+	return rawCodecs[f].size
+}
+
+func (f RawFormat) valid() bool {
+	return f >= 0 && int(f) < len(rawCodecs)
+}
+
+// Panic if the raw image descriptor is not consistent, i.e. the size, the stride or the pixel format is invalid or the
+// buffer is too short to contain all the pixels.
+func (r *RawImage) validate() {
+	if r == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if !r.Format.valid() {
+		panic("pimit: the provided raw pixel format is invalid")
+	}
+
+	size := r.Format.BytesPerPixel()
+	if r.Width <= 0 || r.Height <= 0 || r.Stride/size < r.Width || len(r.Pix)/r.Stride < r.Height-1 {
+		panic("pimit: the provided raw image size is invalid")
+	}
+
+	if len(r.Pix)-(r.Height-1)*r.Stride < r.Width*size {
+		panic("pimit: the provided raw image buffer is too short")
+	}
+}
+
+// The conversion of a single pixel of a raw pixel format from and to the alpha-premultiplied 16-bit channels.
+type rawCodec struct {
+	size   int
+	decode func(pix []byte) (uint16, uint16, uint16, uint16)
+	encode func(pix []byte, r, g, b, a uint16)
+}
+
+var rawCodecs = [...]rawCodec{
+	RawRgba8: {
+		size: 4,
+		decode: func(pix []byte) (uint16, uint16, uint16, uint16) {
+			return uint16(pix[0]) * 0x101, uint16(pix[1]) * 0x101, uint16(pix[2]) * 0x101, uint16(pix[3]) * 0x101
+		},
+		encode: func(pix []byte, r, g, b, a uint16) {
+			pix[0], pix[1], pix[2], pix[3] = uint8(r>>8), uint8(g>>8), uint8(b>>8), uint8(a>>8)
+		},
+	},
+	RawBgra8: {
+		size: 4,
+		decode: func(pix []byte) (uint16, uint16, uint16, uint16) {
+			return uint16(pix[2]) * 0x101, uint16(pix[1]) * 0x101, uint16(pix[0]) * 0x101, uint16(pix[3]) * 0x101
+		},
+		encode: func(pix []byte, r, g, b, a uint16) {
+			pix[0], pix[1], pix[2], pix[3] = uint8(b>>8), uint8(g>>8), uint8(r>>8), uint8(a>>8)
+		},
+	},
+	RawArgb8: {
+		size: 4,
+		decode: func(pix []byte) (uint16, uint16, uint16, uint16) {
+			return uint16(pix[1]) * 0x101, uint16(pix[2]) * 0x101, uint16(pix[3]) * 0x101, uint16(pix[0]) * 0x101
+		},
+		encode: func(pix []byte, r, g, b, a uint16) {
+			pix[0], pix[1], pix[2], pix[3] = uint8(a>>8), uint8(r>>8), uint8(g>>8), uint8(b>>8)
+		},
+	},
+	RawRgb24: {
+		size: 3,
+		decode: func(pix []byte) (uint16, uint16, uint16, uint16) {
+			return uint16(pix[0]) * 0x101, uint16(pix[1]) * 0x101, uint16(pix[2]) * 0x101, 0xffff
+		},
+		encode: func(pix []byte, r, g, b, _ uint16) {
+			pix[0], pix[1], pix[2] = uint8(r>>8), uint8(g>>8), uint8(b>>8)
+		},
+	},
+	RawBgr24: {
+		size: 3,
+		decode: func(pix []byte) (uint16, uint16, uint16, uint16) {
+			return uint16(pix[2]) * 0x101, uint16(pix[1]) * 0x101, uint16(pix[0]) * 0x101, 0xffff
+		},
+		encode: func(pix []byte, r, g, b, _ uint16) {
+			pix[0], pix[1], pix[2] = uint8(b>>8), uint8(g>>8), uint8(r>>8)
+		},
+	},
+	RawRgb565Le: {
+		size: 2,
+		decode: func(pix []byte) (uint16, uint16, uint16, uint16) {
+			return decodeRgb565(uint16(pix[1])<<8 | uint16(pix[0]))
+		},
+		encode: func(pix []byte, r, g, b, _ uint16) {
+			v := encodeRgb565(r, g, b)
+			pix[0], pix[1] = uint8(v), uint8(v>>8)
+		},
+	},
+	RawRgb565Be: {
+		size: 2,
+		decode: func(pix []byte) (uint16, uint16, uint16, uint16) {
+			return decodeRgb565(uint16(pix[0])<<8 | uint16(pix[1]))
+		},
+		encode: func(pix []byte, r, g, b, _ uint16) {
+			v := encodeRgb565(r, g, b)
+			pix[0], pix[1] = uint8(v>>8), uint8(v)
+		},
+	},
+	RawRgba16Le: {
+		size: 8,
+		decode: func(pix []byte) (uint16, uint16, uint16, uint16) {
+			r := uint16(pix[1])<<8 | uint16(pix[0])
+			g := uint16(pix[3])<<8 | uint16(pix[2])
+			b := uint16(pix[5])<<8 | uint16(pix[4])
+			a := uint16(pix[7])<<8 | uint16(pix[6])
+			return r, g, b, a
+		},
+		encode: func(pix []byte, r, g, b, a uint16) {
+			pix[0], pix[1] = uint8(r), uint8(r>>8)
+			pix[2], pix[3] = uint8(g), uint8(g>>8)
+			pix[4], pix[5] = uint8(b), uint8(b>>8)
+			pix[6], pix[7] = uint8(a), uint8(a>>8)
+		},
+	},
+	RawRgba16Be: {
+		size: 8,
+		decode: func(pix []byte) (uint16, uint16, uint16, uint16) {
+			r := uint16(pix[0])<<8 | uint16(pix[1])
+			g := uint16(pix[2])<<8 | uint16(pix[3])
+			b := uint16(pix[4])<<8 | uint16(pix[5])
+			a := uint16(pix[6])<<8 | uint16(pix[7])
+			return r, g, b, a
+		},
+		encode: func(pix []byte, r, g, b, a uint16) {
+			pix[0], pix[1] = uint8(r>>8), uint8(r)
+			pix[2], pix[3] = uint8(g>>8), uint8(g)
+			pix[4], pix[5] = uint8(b>>8), uint8(b)
+			pix[6], pix[7] = uint8(a>>8), uint8(a)
+		},
+	},
+}
+
+// Expand the 5-bit R, 6-bit G and 5-bit B channels of the RGB565 word to the 16-bit channels of an opaque color.
+func decodeRgb565(v uint16) (uint16, uint16, uint16, uint16) {
+	r := (uint32(v>>11)*0xffff + 15) / 31
+	g := (uint32(v>>5&0x3f)*0xffff + 31) / 63
+	b := (uint32(v&0x1f)*0xffff + 15) / 31
+
+	return uint16(r), uint16(g), uint16(b), 0xffff
+}
+
+// Reduce the 16-bit channels to the 5-bit R, 6-bit G and 5-bit B channels of the RGB565 word.
+func encodeRgb565(r, g, b uint16) uint16 {
+	rv := (uint32(r)*31 + 0x7fff) / 0xffff
+	gv := (uint32(g)*63 + 0x7fff) / 0xffff
+	bv := (uint32(b)*31 + 0x7fff) / 0xffff
+
+	return uint16(rv<<11 | gv<<5 | bv)
+}
@@ -0,0 +1,150 @@
+package pimit
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestRawFormatsShouldDecodePixels(t *testing.T) {
+	cases := map[RawFormat][]byte{
+		RawRgba8:    {10, 20, 30, 40},
+		RawBgra8:    {30, 20, 10, 40},
+		RawArgb8:    {40, 10, 20, 30},
+		RawRgb24:    {10, 20, 30},
+		RawBgr24:    {30, 20, 10},
+		RawRgb565Le: {0x08, 0x84},
+		RawRgb565Be: {0x84, 0x08},
+		RawRgba16Le: {0x34, 0x12, 0x78, 0x56, 0xbc, 0x9a, 0xf0, 0xde},
+		RawRgba16Be: {0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0},
+	}
+
+	expected := map[RawFormat]color.RGBA64{
+		RawRgba8:    {0x0a0a, 0x1414, 0x1e1e, 0x2828},
+		RawBgra8:    {0x0a0a, 0x1414, 0x1e1e, 0x2828},
+		RawArgb8:    {0x0a0a, 0x1414, 0x1e1e, 0x2828},
+		RawRgb24:    {0x0a0a, 0x1414, 0x1e1e, 0xffff},
+		RawBgr24:    {0x0a0a, 0x1414, 0x1e1e, 0xffff},
+		RawRgb565Le: {33825, 33288, 16912, 0xffff},
+		RawRgb565Be: {33825, 33288, 16912, 0xffff},
+		RawRgba16Le: {0x1234, 0x5678, 0x9abc, 0xdef0},
+		RawRgba16Be: {0x1234, 0x5678, 0x9abc, 0xdef0},
+	}
+
+	for format, pix := range cases {
+		img := &RawImage{Pix: pix, Width: 1, Height: 1, Stride: len(pix), Format: format}
+
+		assert.Equal(t, len(pix), format.BytesPerPixel())
+		assert.Equal(t, expected[format], img.RGBA64At(0, 0), "unexpected color of format %d", format)
+
+		actual := NewRawImage(1, 1, format)
+		actual.SetRGBA64(0, 0, expected[format])
+
+		assert.Equal(t, pix, actual.Pix, "unexpected encoding of format %d", format)
+	}
+}
+
+func TestRawFormatsShouldRoundTripRgb565Words(t *testing.T) {
+	img := NewRawImage(1, 1, RawRgb565Be)
+
+	for word := 0; word <= 0xffff; word += 1 {
+		img.Pix[0], img.Pix[1] = uint8(word>>8), uint8(word)
+		img.Set(0, 0, img.At(0, 0))
+
+		assert.Equal(t, []byte{uint8(word >> 8), uint8(word)}, img.Pix)
+	}
+}
+
+func TestRawFormatsShouldDiscardAlphaOfOpaqueFormats(t *testing.T) {
+	for _, format := range []RawFormat{RawRgb24, RawBgr24, RawRgb565Le, RawRgb565Be} {
+		img := NewRawImage(2, 1, format)
+		img.Set(1, 0, color.RGBA64{0xffff, 0, 0, 0x8000})
+
+		assert.Equal(t, color.RGBA64{0xffff, 0, 0, 0xffff}, img.RGBA64At(1, 0))
+		assert.Equal(t, color.RGBA64{0, 0, 0, 0xffff}, img.RGBA64At(0, 0))
+	}
+}
+
+func TestRawImageShouldImplementDrawImage(t *testing.T) {
+	src := mockCoordinateImageRgba(image.Rect(0, 0, 9, 7))
+
+	img := &RawImage{Pix: make([]byte, 7*40), Width: 9, Height: 7, Stride: 40, Format: RawBgr24}
+	draw.Draw(img, img.Bounds(), src, image.Point{}, draw.Src)
+
+	assert.Equal(t, image.Rect(0, 0, 9, 7), img.Bounds())
+	assert.Equal(t, color.RGBA64Model, img.ColorModel())
+	assert.Equal(t, color.RGBA64{}, img.At(9, 0))
+	assertEqualImages(t, src, img)
+
+	for y := 0; y < 7; y += 1 {
+		assert.Equal(t, make([]byte, 40-27), img.Pix[y*40+27:(y+1)*40])
+	}
+}
+
+func TestNewRawImageShouldPanicOnInvalidArguments(t *testing.T) {
+	assert.Panics(t, func() { NewRawImage(0, 4, RawRgba8) })
+	assert.Panics(t, func() { NewRawImage(4, -1, RawRgba8) })
+	assert.Panics(t, func() { NewRawImage(4, 4, RawFormat(-1)) })
+	assert.Panics(t, func() { NewRawImage(4, 4, RawRgba16Be+1) })
+	assert.Panics(t, func() { RawFormat(100).BytesPerPixel() })
+
+	img := NewRawImage(3, 2, RawRgb565Le)
+
+	assert.Equal(t, 6, img.Stride)
+	assert.Len(t, img.Pix, 12)
+	assert.Equal(t, 8, img.PixOffset(1, 1))
+}
+
+func TestParallelRawToRgbaShouldPanicOnInvalidImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRawToRgba(nil)
+	})
+
+	assert.Panics(t, func() {
+		ParallelRawToRgba(&RawImage{Pix: make([]byte, 15), Width: 2, Height: 2, Stride: 8, Format: RawBgra8})
+	})
+}
+
+func TestParallelRawToRgbaShouldShareRgba8Buffer(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	raw := &RawImage{Pix: make([]byte, 3*20+12), Width: 3, Height: 4, Stride: 20, Format: RawRgba8}
+
+	img := ParallelRawToRgba(raw)
+	img.SetRGBA(2, 3, color.RGBA{1, 2, 3, 4})
+
+	assert.Equal(t, image.Rect(0, 0, 3, 4), img.Bounds())
+	assert.Equal(t, color.RGBA64{0x0101, 0x0202, 0x0303, 0x0404}, raw.RGBA64At(2, 3))
+	assert.Equal(t, len(raw.Pix), len(img.Pix))
+}
+
+func TestParallelRawToRgbaShouldConvertFormats(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	src := image.NewRGBA64(image.Rect(0, 0, 37, 23))
+	for y := 0; y < 23; y += 1 {
+		for x := 0; x < 37; x += 1 {
+			a := uint16(0xffff - x*y*31)
+			src.SetRGBA64(x, y, color.RGBA64{uint16(uint32(x*1789) % (uint32(a) + 1)), uint16(uint32(y*2741) % (uint32(a) + 1)), a, a})
+		}
+	}
+
+	for format := RawRgba8; format <= RawRgba16Be; format += 1 {
+		stride := 37*format.BytesPerPixel() + 5
+
+		raw := &RawImage{Pix: make([]byte, 23*stride), Width: 37, Height: 23, Stride: stride, Format: format}
+		draw.Draw(raw, raw.Bounds(), src, image.Point{}, draw.Src)
+
+		expected := image.NewRGBA(raw.Bounds())
+		draw.Draw(expected, expected.Bounds(), raw, image.Point{}, draw.Src)
+
+		actual := ParallelRawToRgba(raw, WithWorkers(3), WithChunkSize(2))
+		assertEqualImages(t, expected, actual)
+	}
+}
@@ -0,0 +1,65 @@
+package pimit
+
+// The reduction holds the accumulators of the chunks processed by the workers of a parallel reduction. Every chunk is
+// accumulated into its own accumulator, so the workers do not share any state, and the accumulators are merged in the
+// order of the chunks, which makes the result independent of the timing of the workers.
+type reduction[A any] struct {
+	accs []A
+	done []bool
+}
+
+// The number of chunks the units of a reduction are split into when the chunk size is not specified explicitly. The
+// chunk size derived from this count depends only on the number of units and not on the number of workers, so the
+// accumulated values are grouped in the same way regardless of the machine and the worker settings.
+const reductionChunks = 64
+
+// Create a new reduction for the iteration of n units. If the chunk size is not specified explicitly, it is fixed in
+// the provided options according to the reductionChunks count, so the iteration is partitioned in the same way.
+func newReduction[A any](o *options, n int) *reduction[A] {
+	count := 0
+	if n > 0 {
+		if o.chunkSize == 0 {
+			o.chunkSize = (n + reductionChunks - 1) / reductionChunks
+		}
+
+		count = (n + o.chunkSize - 1) / o.chunkSize
+	}
+
+	return &reduction[A]{
+		accs: make([]A, count),
+		done: make([]bool, count),
+	}
+}
+
+// Store the accumulator of the chunk with the provided index.
+func (r *reduction[A]) set(chunk int, acc A) {
+	r.accs[chunk] = acc
+	r.done[chunk] = true
+}
+
+// Merge the accumulators of the processed chunks in the order of the chunks using the combine function. The value
+// returned by the init function is used if no chunk has been processed.
+func (r *reduction[A]) merge(init func() A, combine func(a, b A) A) A {
+	var (
+		result A    = *new(A)
+		merged bool = false
+	)
+
+	for chunk, acc := range r.accs {
+		if !r.done[chunk] {
+			continue
+		}
+
+		if merged {
+			result = combine(result, acc)
+		} else {
+			result, merged = acc, true
+		}
+	}
+
+	if !merged {
+		return init()
+	}
+
+	return result
+}
@@ -0,0 +1,533 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelRgba64ReadShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRgba64Read(nil, func(x, y int, r, g, b, a uint16) {})
+	})
+}
+
+func TestParallelRgba64ReadShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	assert.Panics(t, func() {
+		ParallelRgba64Read(img, nil)
+	})
+}
+
+func TestParallelRgba64ReadShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelRgba64Read(img, func(xIndex int, yIndex int, acR, acG, acB, acA uint16) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+	})
+}
+
+func TestParallelRgba64ReadEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRgba64ReadE(nil, func(x, y int, r, g, b, a uint16) error {
+			return nil
+		})
+	})
+}
+
+func TestParallelRgba64ReadEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	assert.Panics(t, func() {
+		ParallelRgba64ReadE(img, nil)
+	})
+}
+
+func TestParallelRgba64ReadEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	err := ParallelRgba64ReadE(img, func(x, y int, r, g, b, a uint16) error {
+		return errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelRgba64ReadEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	err := ParallelRgba64ReadE(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) error {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+		return nil
+	})
+
+	assert.Nil(t, err)
+}
+
+func TestParallelRgba64ReadWriteShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRgba64ReadWrite(nil, func(x int, y int, r uint16, g uint16, b uint16, a uint16) (uint16, uint16, uint16, uint16) {
+			return r, g, b, a
+		})
+	})
+}
+
+func TestParallelRgba64ReadWriteShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	assert.Panics(t, func() {
+		ParallelRgba64ReadWrite(img, nil)
+	})
+}
+
+func TestParallelRgba64ReadWriteShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelRgba64ReadWrite(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535
+	})
+
+	expectedImage := mockBlackImageRgba64()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.RGBA64At(x, y), img.RGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelRgba64ReadWriteShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageRgba64()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelRgba64ReadWrite(image, func(x, y int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent
+	})
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := image.RGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelRgba64ReadWriteEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRgba64ReadWriteE(nil, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+			return r, g, b, a, nil
+		})
+	})
+}
+
+func TestParallelRgba64ReadWriteEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	assert.Panics(t, func() {
+		ParallelRgba64ReadWriteE(img, nil)
+	})
+}
+
+func TestParallelRgba64ReadWriteEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	err := ParallelRgba64ReadWriteE(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+		return r, g, b, a, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+}
+
+func TestParallelRgba64ReadWriteEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	ParallelRgba64ReadWriteE(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535, nil
+	})
+
+	expectedImage := mockBlackImageRgba64()
+
+	assert.Equal(t, expectedImage.Bounds(), img.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.RGBA64At(x, y), img.RGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelRgba64ReadWriteEShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageRgba64()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	err := ParallelRgba64ReadWriteE(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16, error) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535, nil
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0, nil
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent, nil
+
+	})
+
+	assert.Nil(t, err)
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := image.RGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelRgba64ReadWriteNewShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRgba64ReadWriteNew(nil, func(x int, y int, r uint16, g uint16, b uint16, a uint16) (uint16, uint16, uint16, uint16) {
+			return r, g, b, a
+		})
+	})
+}
+
+func TestParallelRgba64ReadWriteNewShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	assert.Panics(t, func() {
+		ParallelRgba64ReadWriteNew(img, nil)
+	})
+}
+
+func TestParallelRgba64ReadWriteNewShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage := ParallelRgba64ReadWriteNew(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535
+	})
+
+	expectedImage := mockBlackImageRgba64()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.RGBA64At(x, y), actualImage.RGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelRgba64ReadWriteNewShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageRgba64()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage := ParallelRgba64ReadWriteNew(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent
+	})
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := actualImage.RGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelRgba64ReadWriteNewEShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRgba64ReadWriteNewE(nil, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+			return r, g, b, a, nil
+		})
+	})
+}
+
+func TestParallelRgba64ReadWriteNewEShouldPanicOnNilAccessFunc(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	assert.Panics(t, func() {
+		ParallelRgba64ReadWriteNewE(img, nil)
+	})
+}
+
+func TestParallelRgba64ReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	modifiedImg, err := ParallelRgba64ReadWriteNewE(img, func(x, y int, r, g, b, a uint16) (uint16, uint16, uint16, uint16, error) {
+		return r, g, b, a, errors.New("pimit-test: test errror")
+	})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, modifiedImg)
+}
+
+func TestParallelRgba64ReadWriteNewEShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba64()
+
+	exR, exG, exB, exA := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage, err := ParallelRgba64ReadWriteNewE(img, func(xIndex, yIndex int, acR, acG, acB, acA uint16) (uint16, uint16, uint16, uint16, error) {
+		assert.GreaterOrEqual(t, xIndex, 0)
+		assert.Less(t, xIndex, img.Bounds().Dx())
+
+		assert.GreaterOrEqual(t, yIndex, 0)
+		assert.Less(t, yIndex, img.Bounds().Dy())
+
+		assert.Equal(t, exR, acR)
+		assert.Equal(t, exG, acG)
+		assert.Equal(t, exB, acB)
+		assert.Equal(t, exA, acA)
+
+		return 0, 0, 0, 65535, nil
+	})
+
+	assert.Nil(t, err)
+
+	expectedImage := mockBlackImageRgba64()
+
+	assert.Equal(t, expectedImage.Bounds(), actualImage.Bounds())
+
+	for x := 0; x < expectedImage.Bounds().Dx(); x += 1 {
+		for y := 0; y < expectedImage.Bounds().Dy(); y += 1 {
+			assert.Equal(t, expectedImage.RGBA64At(x, y), actualImage.RGBA64At(x, y))
+		}
+	}
+}
+
+func TestParallelRgba64ReadWriteENewShouldAccessPixelsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	image := mockWhiteImageRgba64()
+
+	rBlack, gBlack, bBlack, aBlack := uint16(0), uint16(0), uint16(0), uint16(0)
+	rWhite, gWhite, bWhite, aWhite := uint16(65535), uint16(65535), uint16(65535), uint16(65535)
+
+	actualImage, err := ParallelRgba64ReadWriteNewE(image, func(xIndex, yIndex int, rCurrent, gCurrent, bCurrent, aCurrent uint16) (uint16, uint16, uint16, uint16, error) {
+		if rCurrent == rBlack && gCurrent == gBlack && bCurrent == bBlack && aCurrent == aBlack {
+			return 65535, 65535, 65535, 65535, nil
+		}
+
+		if rCurrent == rWhite && gCurrent == gWhite && bCurrent == bWhite && aCurrent == aWhite {
+			return 0, 0, 0, 0, nil
+		}
+
+		assert.FailNow(t, "This should never happen")
+		return rCurrent, gCurrent, bCurrent, aCurrent, nil
+	})
+
+	assert.Nil(t, err)
+
+	for x := 0; x < image.Bounds().Dx(); x += 1 {
+		for y := 0; y < image.Bounds().Dy(); y += 1 {
+			c := actualImage.RGBA64At(x, y)
+
+			assert.Equal(t, rBlack, c.R)
+			assert.Equal(t, gBlack, c.G)
+			assert.Equal(t, bBlack, c.B)
+			assert.Equal(t, aBlack, c.A)
+		}
+	}
+}
+
+func TestParallelRgba64FunctionsShouldPreserveChannelPrecision(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := image.NewRGBA64(image.Rect(0, 0, 3, 2))
+	img.SetRGBA64(1, 1, color.RGBA64{0x1234, 0x5678, 0x9abc, 0xdef0})
+
+	ParallelRgba64Read(img, func(x, y int, r, g, b, a uint16) {
+		if x == 1 && y == 1 {
+			assert.Equal(t, []uint16{0x1234, 0x5678, 0x9abc, 0xdef0}, []uint16{r, g, b, a})
+		}
+	})
+
+	dst := ParallelRgba64ReadWriteNew(img, func(_, _ int, r, g, b, a uint16) (uint16, uint16, uint16, uint16) {
+		return r + 1, g + 1, b + 1, a + 1
+	})
+
+	ParallelRgba64ReadWrite(img, func(_, _ int, r, g, b, a uint16) (uint16, uint16, uint16, uint16) {
+		return r + 1, g + 1, b + 1, a + 1
+	})
+
+	assert.Equal(t, color.RGBA64{0x1235, 0x5679, 0x9abd, 0xdef1}, img.RGBA64At(1, 1))
+	assert.Equal(t, color.RGBA64{0x0001, 0x0001, 0x0001, 0x0001}, img.RGBA64At(0, 0))
+	assert.Equal(t, img.Pix, dst.Pix)
+}
+
+func mockWhiteImageRgba64() *image.RGBA64 {
+	width, height := 5, 6
+
+	img := image.NewRGBA64(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	return img
+}
+
+func mockBlackImageRgba64() *image.RGBA64 {
+	width, height := 5, 6
+
+	img := image.NewRGBA64(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y += 1 {
+		for x := 0; x < width; x += 1 {
+			img.Set(x, y, color.Black)
+		}
+	}
+
+	return img
+}
@@ -1,9 +1,11 @@
 package pimit
 
 import (
+	"context"
 	"errors"
 	"image"
 	"image/color"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -481,6 +483,343 @@ func TestParallelRgbaReadWriteENewShouldAccessPixelsOnce(t *testing.T) {
 	}
 }
 
+func TestParallelRgbaReduceShouldPanicOnNilImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		ParallelRgbaReduce(nil, func() int { return 0 }, func(acc int, x, y int, r, g, b, a uint8) int {
+			return acc
+		}, func(a, b int) int { return a + b })
+	})
+}
+
+func TestParallelRgbaReduceShouldPanicOnNilFunctions(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockWhiteImageRgba()
+
+	init := func() int { return 0 }
+	d := func(acc int, x, y int, r, g, b, a uint8) int { return acc }
+	combine := func(a, b int) int { return a + b }
+
+	assert.Panics(t, func() { ParallelRgbaReduce(img, nil, d, combine) })
+	assert.Panics(t, func() { ParallelRgbaReduce(img, init, nil, combine) })
+	assert.Panics(t, func() { ParallelRgbaReduce(img, init, d, nil) })
+}
+
+func TestParallelRgbaReduceShouldCorrectlyReduce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 4, 29, 41)
+	img := mockCoordinateImageRgba(bounds)
+
+	expected := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			expected += int(img.RGBAAt(x, y).B)
+		}
+	}
+
+	for _, workers := range []int{1, 4} {
+		actual := ParallelRgbaReduce(img, func() int { return 0 }, func(acc int, x, y int, r, g, b, a uint8) int {
+			return acc + int(b)
+		}, func(a, b int) int { return a + b }, WithWorkers(workers))
+
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestParallelRgbaReduceShouldProduceReproducibleFloatSums(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockCoordinateImageRgba(image.Rect(-5, 2, 91, 307))
+
+	sum := func(workers int) float32 {
+		return ParallelRgbaReduce(img, func() float32 { return 0 }, func(acc float32, x, y int, r, g, b, a uint8) float32 {
+			return acc + float32(r)*0.2126e-1 + float32(g)*0.7152e-1 + float32(b)*0.0722e-1
+		}, func(a, b float32) float32 {
+			return a + b
+		}, WithWorkers(workers))
+	}
+
+	expected := sum(1)
+	for _, workers := range []int{2, 3, 4, 7, 16, 64} {
+		assert.Equal(t, expected, sum(workers), "unexpected sum for %d workers", workers)
+	}
+}
+
+func TestParallelRgbaReduceShouldMergeInDeterministicOrder(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 16, 64)
+	img := mockCoordinateImageRgba(bounds)
+
+	expected := make([]image.Point, 0, bounds.Dx()*bounds.Dy())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			expected = append(expected, image.Pt(x, y))
+		}
+	}
+
+	for run := 0; run < 10; run += 1 {
+		actual := ParallelRgbaReduce(img, func() []image.Point { return nil }, func(acc []image.Point, x, y int, _, _, _, _ uint8) []image.Point {
+			return append(acc, image.Pt(x, y))
+		}, func(a, b []image.Point) []image.Point {
+			return append(a, b...)
+		}, WithWorkers(4), WithChunkSize(3))
+
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestParallelRgbaReduceShouldReturnInitValueForEmptyImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := image.NewRGBA(image.Rect(0, 0, 0, 0))
+
+	actual := ParallelRgbaReduce(img, func() int { return 7 }, func(acc int, _, _ int, _, _, _, _ uint8) int {
+		return acc + 1
+	}, func(a, b int) int { return a + b })
+
+	assert.Equal(t, 7, actual)
+}
+
+func TestParallelRgbaReadWithStateShouldPanicOnNilArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	init := func() int { return 0 }
+	d := func(_ int, x, y int, r, g, b, a uint8) {}
+
+	assert.Panics(t, func() { ParallelRgbaReadWithState(nil, init, d, nil) })
+	assert.Panics(t, func() { ParallelRgbaReadWithState(mockWhiteImageRgba(), nil, d, nil) })
+	assert.Panics(t, func() { ParallelRgbaReadWithState[int](mockWhiteImageRgba(), init, nil, nil) })
+}
+
+func TestParallelRgbaReadWithStateShouldUseWorkerLocalState(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	type state struct {
+		busy int32
+		sum  int
+	}
+
+	bounds := image.Rect(-4, 2, 28, 45)
+	img := mockCoordinateImageRgba(bounds)
+
+	expected := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			expected += int(img.RGBAAt(x, y).R)
+		}
+	}
+
+	var (
+		inits      int32 = 0
+		finalizes  int32 = 0
+		sum        int64 = 0
+		contention int32 = 0
+	)
+
+	ParallelRgbaReadWithState(img, func() *state {
+		atomic.AddInt32(&inits, 1)
+		return &state{}
+	}, func(s *state, x, y int, r, g, b, a uint8) {
+		if !atomic.CompareAndSwapInt32(&s.busy, 0, 1) {
+			atomic.AddInt32(&contention, 1)
+		}
+
+		s.sum += int(r)
+		atomic.StoreInt32(&s.busy, 0)
+	}, func(s *state) {
+		atomic.AddInt32(&finalizes, 1)
+		atomic.AddInt64(&sum, int64(s.sum))
+	}, WithWorkers(4), WithChunkSize(1))
+
+	assert.Equal(t, int64(expected), sum)
+	assert.Equal(t, inits, finalizes)
+	assert.LessOrEqual(t, inits, int32(4))
+	assert.Equal(t, int32(0), contention)
+}
+
+func TestParallelRgbaReadWriteWithStateShouldCorrectlyIterate(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(3, -6, 19, 12)
+	img := mockCoordinateImageRgba(bounds)
+
+	ParallelRgbaReadWriteWithState(img, func() []uint8 {
+		return make([]uint8, 4)
+	}, func(buffer []uint8, x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		buffer[0], buffer[1], buffer[2], buffer[3] = ^r, ^g, ^b, a
+		return buffer[0], buffer[1], buffer[2], buffer[3]
+	}, nil, WithWorkers(3))
+
+	assertInvertedCoordinateImage(t, img, bounds)
+}
+
+func TestParallelRgbaReadWriteWithStateShouldFinalizeOnPanic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var finalizes int32 = 0
+
+	assert.Panics(t, func() {
+		ParallelRgbaReadWriteWithState(mockWhiteImageRgba(), func() int {
+			return 0
+		}, func(_ int, x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+			panic("pimit-test: test panic")
+		}, func(_ int) {
+			atomic.AddInt32(&finalizes, 1)
+		}, WithWorkers(1))
+	})
+
+	assert.Equal(t, int32(1), finalizes)
+}
+
+func TestParallelRgbaRowsShouldPanicOnNilArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() { ParallelRgbaRows(nil, func(y int, pix []uint8) {}) })
+	assert.Panics(t, func() { ParallelRgbaRows(mockWhiteImageRgba(), nil) })
+	assert.Panics(t, func() { ParallelRgbaRowsE(nil, func(y int, pix []uint8) error { return nil }) })
+	assert.Panics(t, func() { ParallelRgbaRowsE(mockWhiteImageRgba(), nil) })
+	assert.Panics(t, func() { ParallelRgbaRowsNew(nil, func(y int, src, dst []uint8) {}) })
+	assert.Panics(t, func() { ParallelRgbaRowsNew(mockWhiteImageRgba(), nil) })
+	assert.Panics(t, func() { ParallelRgbaRowsNewE(nil, func(y int, src, dst []uint8) error { return nil }) })
+	assert.Panics(t, func() { ParallelRgbaRowsNewE(mockWhiteImageRgba(), nil) })
+}
+
+func TestParallelRgbaRowsShouldHonorSubImageStride(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	parent := mockCoordinateImageRgba(image.Rect(-6, -4, 30, 26))
+	bounds := image.Rect(-2, 3, 17, 21)
+
+	for _, relative := range []bool{false, true} {
+		img := mockCoordinateImageRgba(parent.Bounds())
+		sub := img.SubImage(bounds).(*image.RGBA)
+
+		leftX := bounds.Min.X
+		if relative {
+			leftX = 0
+		}
+
+		v := newVisitTracker(t, image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+1, bounds.Max.Y), relative)
+		ParallelRgbaRows(sub, func(y int, pix []uint8) {
+			v.visit(leftX, y, color.RGBA{pix[0], pix[1], pix[2], pix[3]})
+
+			assert.Len(t, pix, 4*bounds.Dx())
+			for offset := 0; offset < len(pix); offset += 4 {
+				pix[offset+0], pix[offset+1], pix[offset+2] = ^pix[offset+0], ^pix[offset+1], ^pix[offset+2]
+			}
+		}, boundsTestOptions(relative)...)
+		v.assertVisitedOnce()
+
+		assertInvertedCoordinateImage(t, img, bounds)
+		assertUntouchedCoordinateImage(t, img, bounds)
+	}
+}
+
+func TestParallelRgbaRowsNewShouldWriteDestinationRows(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(5, -3, 23, 16)
+	img := mockCoordinateImageRgba(bounds)
+
+	dst := ParallelRgbaRowsNew(img, func(y int, src, dst []uint8) {
+		assert.Equal(t, len(src), len(dst))
+		for offset := 0; offset < len(src); offset += 4 {
+			dst[offset+0], dst[offset+1], dst[offset+2], dst[offset+3] = ^src[offset+0], ^src[offset+1], ^src[offset+2], src[offset+3]
+		}
+	}, WithWorkers(3))
+
+	assert.Equal(t, bounds, dst.Bounds())
+	assertInvertedCoordinateImage(t, dst, bounds)
+	assertUntouchedCoordinateImage(t, img, image.Rectangle{})
+
+	dst, err := ParallelRgbaRowsNewE(img, func(y int, src, dst []uint8) error {
+		copy(dst, src)
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, img.Pix, dst.Pix)
+}
+
+func TestParallelRgbaRowsEShouldReturnRowError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(-3, 2, 11, 19)
+	rowErr := errors.New("pimit-test: test error")
+
+	err := ParallelRgbaRowsE(mockCoordinateImageRgba(bounds), func(y int, pix []uint8) error {
+		if y == 9 {
+			return rowErr
+		}
+
+		return nil
+	}, WithWorkers(3))
+
+	var pe *PixelError
+
+	assert.ErrorIs(t, err, rowErr)
+	assert.ErrorAs(t, err, &pe)
+	assert.Equal(t, image.Pt(-3, 9), image.Pt(pe.X, pe.Y))
+
+	dst, err := ParallelRgbaRowsNewE(mockCoordinateImageRgba(bounds), func(y int, _, _ []uint8) error {
+		if y == 4 {
+			return rowErr
+		}
+
+		return nil
+	}, WithRelativeCoordinates())
+
+	assert.ErrorAs(t, err, &pe)
+	assert.Nil(t, dst)
+	assert.Equal(t, image.Pt(0, 4), image.Pt(pe.X, pe.Y))
+}
+
+func TestParallelRgbaRowsShouldStopOnCancelledContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var visits int32 = 0
+	ParallelRgbaRows(mockWhiteImageRgba(), func(_ int, _ []uint8) {
+		atomic.AddInt32(&visits, 1)
+	}, WithContext(ctx))
+
+	err := ParallelRgbaRowsE(mockWhiteImageRgba(), func(_ int, _ []uint8) error {
+		atomic.AddInt32(&visits, 1)
+		return nil
+	}, WithContext(ctx))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(0), visits)
+}
+
+func BenchmarkRgbaRowsComparedToPixelDelegate(b *testing.B) {
+	img := mockCoordinateImageRgba(image.Rect(0, 0, 1920, 1080))
+
+	b.Run("pixel", func(b *testing.B) {
+		for i := 0; i < b.N; i += 1 {
+			ParallelRgbaReadWrite(img, func(_, _ int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				return ^r, ^g, ^b, a
+			})
+		}
+	})
+
+	b.Run("rows", func(b *testing.B) {
+		for i := 0; i < b.N; i += 1 {
+			ParallelRgbaRows(img, func(_ int, pix []uint8) {
+				for offset := 0; offset < len(pix); offset += 4 {
+					pix[offset+0], pix[offset+1], pix[offset+2] = ^pix[offset+0], ^pix[offset+1], ^pix[offset+2]
+				}
+			})
+		}
+	})
+}
+
 func mockWhiteImageRgba() *image.RGBA {
 	width, height := 5, 6
 
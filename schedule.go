@@ -0,0 +1,163 @@
+package pimit
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// The number of chunks created per worker when the chunk size is not specified explicitly. Creating more chunks than
+// workers allows to balance the load when some parts of the image are more expensive to process than others.
+const chunksPerWorker = 4
+
+// Perform a partitioned iteration of the units (e.g. rows or columns) in the range [0, n). The function f is executed
+// for each unit together with the cursor of the worker. The iteration stops claiming new units after it has been
+// interrupted, unless it is errorable, in which case the function f is expected to check for the interruption on its
+// own. See scheduleWorkers for the details of the partitioning.
+func (it *iteration) schedule(n int, f func(i int, p *cursor)) {
+	it.scheduleChunks(n, func(_, lo, hi int, p *cursor) {
+		it.units(lo, hi, p, f)
+	})
+}
+
+// Perform a partitioned iteration of the units (e.g. rows or columns) in the range [0, n) with a worker-local state.
+// The state is created by the init function once per worker and passed to the function f executed for each unit. The
+// optional finalize function is executed with the state when the worker finishes. See schedule for the details.
+func scheduleWithState[S any](it *iteration, n int, init func() S, finalize func(state S), f func(state S, i int, p *cursor)) {
+	it.scheduleWorkers(n, func() (func(chunk, lo, hi int, p *cursor), func()) {
+		state := init()
+
+		process := func(_, lo, hi int, p *cursor) {
+			it.units(lo, hi, p, func(i int, p *cursor) {
+				f(state, i, p)
+			})
+		}
+
+		done := func() {
+			if finalize != nil {
+				finalize(state)
+			}
+		}
+
+		return process, done
+	})
+}
+
+// Execute the function f for each unit in the range [lo, hi) updating the unit of the cursor. The non-errorable
+// iterations are checked for the interruption before each unit.
+func (it *iteration) units(lo, hi int, p *cursor, f func(i int, p *cursor)) {
+	for i := lo; i < hi; i += 1 {
+		if !it.errorable && it.interrupted(p) {
+			return
+		}
+
+		p.unit = i
+		f(i, p)
+	}
+}
+
+// Perform a partitioned iteration of the chunks of the units in the range [0, n). The function f is executed for each
+// chunk. See scheduleWorkers for the details.
+func (it *iteration) scheduleChunks(n int, f func(chunk, lo, hi int, p *cursor)) {
+	it.scheduleWorkers(n, func() (func(chunk, lo, hi int, p *cursor), func()) {
+		return f, nil
+	})
+}
+
+// Perform a partitioned iteration of the units (e.g. rows or columns) in the range [0, n). The units are grouped into
+// chunks of consecutive units which are claimed and processed by a bounded number of worker goroutines, which are
+// started for the iteration or borrowed from the executor. The worker function is executed once by every worker and
+// returns the function executed for each claimed chunk, together with the index of the chunk, the range [lo, hi) of
+// the units and the cursor of the worker, and the optional function executed when the worker finishes. The panics of
+// the functions are recovered and recorded by the iteration. If the executor is closed and rejects the workers before
+// all chunks are claimed, the ErrExecutorClosed error is recorded by the errorable iterations, while the remaining
+// iterations panic.
+func (it *iteration) scheduleWorkers(n int, newWorker func() (func(chunk, lo, hi int, p *cursor), func())) {
+	if n <= 0 {
+		return
+	}
+
+	var (
+		next int64 = 0
+		wg         = &sync.WaitGroup{}
+	)
+
+	workers, chunkSize := it.o.partition(n)
+
+	worker := func() {
+		defer wg.Done()
+
+		p := &cursor{x: 0, y: 0, unit: 0}
+		defer it.recover(p)
+
+		f, done := newWorker()
+		if done != nil {
+			defer done()
+		}
+
+		for {
+			hi := int(atomic.AddInt64(&next, int64(chunkSize)))
+			lo := hi - chunkSize
+			if lo >= n {
+				return
+			}
+
+			if hi > n {
+				hi = n
+			}
+
+			f(lo/chunkSize, lo, hi, p)
+		}
+	}
+
+	if workers == 1 && it.o.executor == nil {
+		wg.Add(1)
+		worker()
+		return
+	}
+
+	rejected := false
+	for w := 0; w < workers && !rejected; w += 1 {
+		wg.Add(1)
+		if it.o.executor == nil {
+			go worker()
+		} else if rejected = !it.o.executor.submit(worker); rejected {
+			wg.Done()
+		}
+	}
+
+	wg.Wait()
+
+	// NOTE: The accepted workers claim the chunks until none are left, so the rejection of the remaining workers
+	// matters only if no worker has been accepted.
+	if rejected && atomic.LoadInt64(&next) < int64(n) {
+		if !it.errorable {
+			panic(ErrExecutorClosed)
+		}
+
+		it.errt.Set(ErrExecutorClosed)
+	}
+}
+
+// Calculate the number of workers and the chunk size for the iteration of n units.
+func (o *options) partition(n int) (int, int) {
+	workers := o.workers
+	if workers == 0 {
+		if o.executor != nil {
+			workers = o.executor.Workers()
+		} else {
+			workers = runtime.GOMAXPROCS(0)
+		}
+	}
+
+	chunkSize := o.chunkSize
+	if chunkSize == 0 {
+		chunkSize = (n + workers*chunksPerWorker - 1) / (workers * chunksPerWorker)
+	}
+
+	if chunks := (n + chunkSize - 1) / chunkSize; workers > chunks {
+		workers = chunks
+	}
+
+	return workers, chunkSize
+}
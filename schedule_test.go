@@ -0,0 +1,231 @@
+package pimit
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestWithWorkersShouldPanicOnInvalidCount(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		WithWorkers(0)
+	})
+
+	assert.Panics(t, func() {
+		WithWorkers(-2)
+	})
+}
+
+func TestWithChunkSizeShouldPanicOnInvalidSize(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	assert.Panics(t, func() {
+		WithChunkSize(0)
+	})
+
+	assert.Panics(t, func() {
+		WithChunkSize(-2)
+	})
+}
+
+func TestPartitionShouldCalculateWorkersAndChunkSize(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	cases := []struct {
+		units             int
+		workers           int
+		chunkSize         int
+		expectedWorkers   int
+		expectedChunkSize int
+	}{
+		{100, 4, 0, 4, 7},
+		{16, 4, 0, 4, 1},
+		{3, 4, 0, 3, 1},
+		{1, 4, 0, 1, 1},
+		{100, 4, 50, 2, 50},
+		{100, 4, 30, 4, 30},
+		{100, 1, 0, 1, 25},
+	}
+
+	for _, c := range cases {
+		o := newOptions([]Option{WithWorkers(c.workers)})
+		if c.chunkSize != 0 {
+			o = newOptions([]Option{WithWorkers(c.workers), WithChunkSize(c.chunkSize)})
+		}
+
+		actualWorkers, actualChunkSize := o.partition(c.units)
+
+		assert.Equal(t, c.expectedWorkers, actualWorkers)
+		assert.Equal(t, c.expectedChunkSize, actualChunkSize)
+	}
+}
+
+func TestPartitionShouldDefaultToGomaxprocsWorkers(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	workers, _ := newOptions(nil).partition(1 << 20)
+
+	assert.Equal(t, runtime.GOMAXPROCS(0), workers)
+}
+
+func TestScheduleShouldProcessUnitsOnce(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, units := range []int{0, 1, 2, 7, 64, 1000} {
+		for _, workers := range []int{1, 2, 3, 16} {
+			for _, chunkSize := range []int{1, 3, 64} {
+				o := newOptions([]Option{WithWorkers(workers), WithChunkSize(chunkSize)})
+				visits := make([]int32, units)
+
+				o.newIteration("", false).schedule(units, func(i int, _ *cursor) {
+					atomic.AddInt32(&visits[i], 1)
+				})
+
+				for i := 0; i < units; i += 1 {
+					assert.Equal(t, int32(1), visits[i])
+				}
+			}
+		}
+	}
+}
+
+func TestScheduleShouldNotExceedWorkerCount(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	var (
+		workers int32 = 3
+		current int32 = 0
+		peak    int32 = 0
+		mu            = sync.Mutex{}
+	)
+
+	newOptions([]Option{WithWorkers(int(workers)), WithChunkSize(1)}).newIteration("", false).schedule(200, func(_ int, _ *cursor) {
+		c := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+
+		mu.Lock()
+		if c > peak {
+			peak = c
+		}
+		mu.Unlock()
+
+		runtime.Gosched()
+	})
+
+	assert.LessOrEqual(t, peak, workers)
+}
+
+func TestScheduleShouldStopOnInterruptedIteration(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var visits int32 = 0
+	newOptions([]Option{WithWorkers(4), WithContext(ctx)}).newIteration("", false).schedule(100, func(_ int, _ *cursor) {
+		atomic.AddInt32(&visits, 1)
+	})
+
+	assert.Equal(t, int32(0), visits)
+}
+
+func TestIteratorsShouldHonorWorkersAndChunkSizeOptions(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(2, 3, 19, 40)
+
+	for _, workers := range []int{1, 2, 5} {
+		for _, chunkSize := range []int{1, 4, 100} {
+			opts := []Option{WithWorkers(workers), WithChunkSize(chunkSize)}
+
+			img := mockCoordinateImageRgba(bounds)
+			v := newVisitTracker(t, bounds, false)
+			ParallelRgbaReadWrite(img, func(x, y int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+				return rgbaComponents(v.visitAndInvert(x, y, color.RGBA{r, g, b, a}))
+			}, opts...)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, img, bounds)
+
+			img = mockCoordinateImageRgba(bounds)
+			v = newVisitTracker(t, bounds, false)
+			ParallelDistributedReadWrite(img, 9, v.visitAndInvert, opts...)
+			v.assertVisitedOnce()
+			assertInvertedCoordinateImage(t, img, bounds)
+
+			var count int32 = 0
+			ParallelIndices(bounds.Dx(), bounds.Dy(), func(_, _ int) {
+				atomic.AddInt32(&count, 1)
+			}, opts...)
+			assert.Equal(t, int32(bounds.Dx()*bounds.Dy()), count)
+		}
+	}
+}
+
+func BenchmarkRowScheduling(b *testing.B) {
+	sizes := []struct {
+		name          string
+		width, height int
+	}{
+		{"small", 64, 64},
+		{"medium", 640, 480},
+		{"large", 4000, 4000},
+	}
+
+	delegate := func(_, _ int, r, g, b, a uint8) (uint8, uint8, uint8, uint8) {
+		return 255 - r, 255 - g, 255 - b, a
+	}
+
+	for _, size := range sizes {
+		img := image.NewRGBA(image.Rect(0, 0, size.width, size.height))
+
+		b.Run(fmt.Sprintf("%s/goroutine-per-row", size.name), func(b *testing.B) {
+			for n := 0; n < b.N; n += 1 {
+				goroutinePerRowRgbaReadWrite(img, delegate)
+			}
+		})
+
+		b.Run(fmt.Sprintf("%s/bounded-workers", size.name), func(b *testing.B) {
+			for n := 0; n < b.N; n += 1 {
+				ParallelRgbaReadWrite(img, delegate)
+			}
+		})
+	}
+}
+
+// Reference implementation of the scheduling strategy used before the introduction of the bounded worker pool, where
+// every row was iterated in a separate goroutine.
+func goroutinePerRowRgbaReadWrite(src *image.RGBA, d RgbaReadWriteDelegate) {
+	bounds := src.Bounds()
+	wg := &sync.WaitGroup{}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		wg.Add(1)
+		go func(yIndex int) {
+			defer wg.Done()
+
+			baseIndex := src.PixOffset(bounds.Min.X, yIndex)
+			for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+				r, g, b, a := d(xIndex, yIndex, src.Pix[baseIndex+0], src.Pix[baseIndex+1], src.Pix[baseIndex+2], src.Pix[baseIndex+3])
+
+				src.Pix[baseIndex+0] = r
+				src.Pix[baseIndex+1] = g
+				src.Pix[baseIndex+2] = b
+				src.Pix[baseIndex+3] = a
+
+				baseIndex += 4
+			}
+		}(y)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,576 @@
+package pimit
+
+import "image"
+
+type (
+	YCbCrReadDelegate                     = func(x, y int, luma, cb, cr uint8)
+	YCbCrReadErrorableDelegate            = func(x, y int, luma, cb, cr uint8) error
+	YCbCrReadWriteDelegate                = func(x, y int, luma, cb, cr uint8) (uint8, uint8, uint8)
+	YCbCrReadWriteErrorableDelegate       = func(x, y int, luma, cb, cr uint8) (uint8, uint8, uint8, error)
+	YCbCrChromaReadDelegate               = func(x, y int, cb, cr uint8)
+	YCbCrChromaReadErrorableDelegate      = func(x, y int, cb, cr uint8) error
+	YCbCrChromaReadWriteDelegate          = func(x, y int, cb, cr uint8) (uint8, uint8)
+	YCbCrChromaReadWriteErrorableDelegate = func(x, y int, cb, cr uint8) (uint8, uint8, error)
+)
+
+// The delegate function used internally by the iterations of the YCbCr and NYCbCrA images, which unifies the read and
+// read-write delegates of both image types.
+type ycbcrDelegate = func(x, y int, luma, cb, cr, a uint8) (uint8, uint8, uint8, uint8, error)
+
+// Perform a parallel iteration of the pixels of the provided YCbCr image. For each pixel, execute the delegate function
+// allowing you to read the color (Y, Cb and Cr as uint8) and coordinates. The Cb and Cr values are the values of the
+// chroma sample covering the pixel according to the subsample ratio of the image. The rows sharing the chroma samples
+// are split into chunks processed by a bounded number of worker goroutines.
+func ParallelYCbCrRead(src *image.YCbCr, d YCbCrReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+
+	it := o.newIteration("ParallelYCbCrRead", false)
+	defer it.cancel()
+
+	it.scheduleYCbCr(&image.NYCbCrA{YCbCr: *src}, nil, func(x, y int, luma, cb, cr, _ uint8) (uint8, uint8, uint8, uint8, error) {
+		d(x, y, luma, cb, cr)
+		return 0, 0, 0, 0, nil
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided YCbCr image. For each pixel, execute the delegate function
+// allowing you to read the color (Y, Cb and Cr as uint8) and coordinates. The Cb and Cr values are the values of the
+// chroma sample covering the pixel according to the subsample ratio of the image. The rows sharing the chroma samples
+// are split into chunks processed by a bounded number of worker goroutines. The iteration will break after the first
+// error occurs and the error will be returned.
+func ParallelYCbCrReadE(src *image.YCbCr, d YCbCrReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+
+	it := o.newIteration("ParallelYCbCrReadE", true)
+	defer it.cancel()
+
+	it.scheduleYCbCr(&image.NYCbCrA{YCbCr: *src}, nil, func(x, y int, luma, cb, cr, _ uint8) (uint8, uint8, uint8, uint8, error) {
+		return 0, 0, 0, 0, d(x, y, luma, cb, cr)
+	})
+
+	return it.err()
+}
+
+// Perform a parallel iteration of the pixels of the provided YCbCr image. For each pixel, execute the delegate function
+// allowing you to read the color (Y, Cb and Cr as uint8) and coordinates, the delegate return color will be set at the
+// given coordinates. The Y value is set for each pixel, while the Cb and Cr values of a chroma sample are set to the
+// average of the values returned for the pixels covered by the sample. If the bounds of a sub-image are not aligned to
+// the subsampling of the image, the chroma samples on the edges of the bounds are shared with the pixels outside of the
+// bounds, whose chroma is therefore also set to this average. This changes will be applied to the passed image
+// instance. The rows sharing the chroma samples are split into chunks processed by a bounded number of worker
+// goroutines.
+func ParallelYCbCrReadWrite(src *image.YCbCr, d YCbCrReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+
+	it := o.newIteration("ParallelYCbCrReadWrite", false)
+	defer it.cancel()
+
+	img := &image.NYCbCrA{YCbCr: *src}
+	it.scheduleYCbCr(img, img, func(x, y int, luma, cb, cr, _ uint8) (uint8, uint8, uint8, uint8, error) {
+		luma, cb, cr = d(x, y, luma, cb, cr)
+		return luma, cb, cr, 0, nil
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the pixels of the provided YCbCr image. For each pixel, execute the delegate function
+// allowing you to read the color (Y, Cb and Cr as uint8) and coordinates, the delegate return color will be set at the
+// given coordinates. The Y value is set for each pixel, while the Cb and Cr values of a chroma sample are set to the
+// average of the values returned for the pixels covered by the sample. If the bounds of a sub-image are not aligned to
+// the subsampling of the image, the chroma samples on the edges of the bounds are shared with the pixels outside of the
+// bounds, whose chroma is therefore also set to this average. This changes will be applied to the passed image
+// instance. The rows sharing the chroma samples are split into chunks processed by a bounded number of worker
+// goroutines. The iteration will break after the first error occurs and the error will be returned.
+func ParallelYCbCrReadWriteE(src *image.YCbCr, d YCbCrReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+
+	it := o.newIteration("ParallelYCbCrReadWriteE", true)
+	defer it.cancel()
+
+	img := &image.NYCbCrA{YCbCr: *src}
+	it.scheduleYCbCr(img, img, func(x, y int, luma, cb, cr, _ uint8) (uint8, uint8, uint8, uint8, error) {
+		luma, cb, cr, err := d(x, y, luma, cb, cr)
+		return luma, cb, cr, 0, err
+	})
+
+	return it.commit()
+}
+
+// Perform a parallel iteration of the pixels of the provided YCbCr image. For each pixel, execute the delegate function
+// allowing you to read the color (Y, Cb and Cr as uint8) and coordinates, the delegate return color will be set at the
+// given coordinates. The Y value is set for each pixel, while the Cb and Cr values of a chroma sample are set to the
+// average of the values returned for the pixels covered by the sample. This changes will be applied to a new image
+// instance with the same subsample ratio, which is returned by the function. The rows sharing the chroma samples are
+// split into chunks processed by a bounded number of worker goroutines.
+func ParallelYCbCrReadWriteNew(src *image.YCbCr, d YCbCrReadWriteDelegate, opts ...Option) *image.YCbCr {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	dst := &image.NYCbCrA{YCbCr: *image.NewYCbCr(src.Rect, src.SubsampleRatio)}
+
+	it := o.newIteration("ParallelYCbCrReadWriteNew", false)
+	defer it.cancel()
+
+	it.scheduleYCbCr(&image.NYCbCrA{YCbCr: *src}, dst, func(x, y int, luma, cb, cr, _ uint8) (uint8, uint8, uint8, uint8, error) {
+		luma, cb, cr = d(x, y, luma, cb, cr)
+		return luma, cb, cr, 0, nil
+	})
+
+	it.repanic()
+
+	return &dst.YCbCr
+}
+
+// Perform a parallel iteration of the pixels of the provided YCbCr image. For each pixel, execute the delegate function
+// allowing you to read the color (Y, Cb and Cr as uint8) and coordinates, the delegate return color will be set at the
+// given coordinates. The Y value is set for each pixel, while the Cb and Cr values of a chroma sample are set to the
+// average of the values returned for the pixels covered by the sample. This changes will be applied to a new image
+// instance with the same subsample ratio, which is returned by the function. The rows sharing the chroma samples are
+// split into chunks processed by a bounded number of worker goroutines. The iteration will break after the first error
+// occurs and the error will be returned.
+func ParallelYCbCrReadWriteNewE(src *image.YCbCr, d YCbCrReadWriteErrorableDelegate, opts ...Option) (*image.YCbCr, error) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	dst := &image.NYCbCrA{YCbCr: *image.NewYCbCr(src.Rect, src.SubsampleRatio)}
+
+	it := o.newIteration("ParallelYCbCrReadWriteNewE", true)
+	defer it.cancel()
+
+	it.scheduleYCbCr(&image.NYCbCrA{YCbCr: *src}, dst, func(x, y int, luma, cb, cr, _ uint8) (uint8, uint8, uint8, uint8, error) {
+		luma, cb, cr, err := d(x, y, luma, cb, cr)
+		return luma, cb, cr, 0, err
+	})
+
+	if err := it.err(); err != nil {
+		return nil, err
+	} else {
+		return &dst.YCbCr, nil
+	}
+}
+
+// Perform a parallel iteration of the chroma samples of the provided YCbCr image at the resolution of the chroma
+// planes. For each sample, execute the delegate function allowing you to read the chroma (Cb and Cr as uint8) and the
+// coordinates of the sample, which are the coordinates of the covered pixels divided by the subsampling factors of the
+// image. The rows of the chroma planes are split into chunks processed by a bounded number of worker goroutines.
+func ParallelYCbCrChromaRead(src *image.YCbCr, d YCbCrChromaReadDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := chromaBounds(src)
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelYCbCrChromaRead", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int = bounds.Min.Y + i
+			baseIndex int = i * src.CStride
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			d(xIndex-originX, yIndex-originY, src.Cb[baseIndex], src.Cr[baseIndex])
+			baseIndex += 1
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the chroma samples of the provided YCbCr image at the resolution of the chroma
+// planes. For each sample, execute the delegate function allowing you to read the chroma (Cb and Cr as uint8) and the
+// coordinates of the sample, which are the coordinates of the covered pixels divided by the subsampling factors of the
+// image. The rows of the chroma planes are split into chunks processed by a bounded number of worker goroutines. The
+// iteration will break after the first error occurs and the error will be returned.
+func ParallelYCbCrChromaReadE(src *image.YCbCr, d YCbCrChromaReadErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := chromaBounds(src)
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelYCbCrChromaReadE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int   = bounds.Min.Y + i
+			baseIndex int   = i * src.CStride
+			err       error = nil
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			if err = d(xIndex-originX, yIndex-originY, src.Cb[baseIndex], src.Cr[baseIndex]); err != nil && it.fail(p, err) {
+				return
+			}
+
+			baseIndex += 1
+		}
+	})
+
+	return it.err()
+}
+
+// Perform a parallel iteration of the chroma samples of the provided YCbCr image at the resolution of the chroma
+// planes. For each sample, execute the delegate function allowing you to read the chroma (Cb and Cr as uint8) and the
+// coordinates of the sample, which are the coordinates of the covered pixels divided by the subsampling factors of the
+// image, the delegate return chroma will be set for the sample. This changes will be applied to the passed image
+// instance. The rows of the chroma planes are split into chunks processed by a bounded number of worker goroutines.
+func ParallelYCbCrChromaReadWrite(src *image.YCbCr, d YCbCrChromaReadWriteDelegate, opts ...Option) {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := chromaBounds(src)
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelYCbCrChromaReadWrite", false)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int   = bounds.Min.Y + i
+			baseIndex int   = i * src.CStride
+			cb, cr    uint8 = 0, 0
+		)
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+
+			cb, cr = d(xIndex-originX, yIndex-originY, src.Cb[baseIndex], src.Cr[baseIndex])
+
+			src.Cb[baseIndex] = cb
+			src.Cr[baseIndex] = cr
+
+			baseIndex += 1
+		}
+	})
+
+	it.repanic()
+}
+
+// Perform a parallel iteration of the chroma samples of the provided YCbCr image at the resolution of the chroma
+// planes. For each sample, execute the delegate function allowing you to read the chroma (Cb and Cr as uint8) and the
+// coordinates of the sample, which are the coordinates of the covered pixels divided by the subsampling factors of the
+// image, the delegate return chroma will be set for the sample. This changes will be applied to the passed image
+// instance. The rows of the chroma planes are split into chunks processed by a bounded number of worker goroutines.
+// The iteration will break after the first error occurs and the error will be returned.
+func ParallelYCbCrChromaReadWriteE(src *image.YCbCr, d YCbCrChromaReadWriteErrorableDelegate, opts ...Option) error {
+	if src == nil {
+		panic("pimit: the provided image reference is nil")
+	}
+
+	if d == nil {
+		panic("pimit: the provided access delegate function is nil")
+	}
+
+	o := newOptions(opts)
+	bounds := chromaBounds(src)
+	originX, originY := o.origin(bounds)
+
+	it := o.newIteration("ParallelYCbCrChromaReadWriteE", true)
+	defer it.cancel()
+
+	it.schedule(bounds.Dy(), func(i int, p *cursor) {
+		var (
+			yIndex    int   = bounds.Min.Y + i
+			baseIndex int   = i * src.CStride
+			cb, cr    uint8 = 0, 0
+			err       error = nil
+		)
+
+		if o.atomic {
+			rowIndex, length := baseIndex, bounds.Dx()
+			savedCb := append([]uint8(nil), src.Cb[rowIndex:rowIndex+length]...)
+			savedCr := append([]uint8(nil), src.Cr[rowIndex:rowIndex+length]...)
+			it.record(func() {
+				copy(src.Cb[rowIndex:], savedCb)
+				copy(src.Cr[rowIndex:], savedCr)
+			})
+		}
+
+		p.y = yIndex - originY
+		for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+			p.x = xIndex - originX
+			if it.interrupted(p) {
+				return
+			}
+
+			cb, cr, err = d(xIndex-originX, yIndex-originY, src.Cb[baseIndex], src.Cr[baseIndex])
+
+			if err != nil {
+				if it.fail(p, err) {
+					return
+				}
+
+				baseIndex += 1
+				continue
+			}
+
+			src.Cb[baseIndex] = cb
+			src.Cr[baseIndex] = cr
+
+			baseIndex += 1
+		}
+	})
+
+	return it.commit()
+}
+
+// Perform a partitioned iteration of the pixels of the provided source image. The rows sharing the chroma samples are
+// grouped into units, so every chroma sample is accessed by a single worker. If the destination image is provided, the
+// values returned by the delegate are written to it, where the Cb and Cr values of the chroma samples are set to the
+// rounded average of the values returned for the covered pixels after the whole unit is processed. The chroma samples
+// shared with the pixels outside of the bounds of a sub-image are set in the same way, because the chroma of these
+// pixels can not be stored separately. The alpha plane is accessed only if it is present in both images. The
+// destination image can be the source image itself.
+func (it *iteration) scheduleYCbCr(src, dst *image.NYCbCrA, d ycbcrDelegate) {
+	bounds := src.Rect
+	originX, originY := it.o.origin(bounds)
+	hs, vs := subsampleFactors(src.SubsampleRatio)
+	groups := chromaRowGroups(bounds, vs)
+	width := chromaBounds(&src.YCbCr).Dx()
+
+	it.schedule(len(groups)-1, func(i int, p *cursor) {
+		var (
+			cBase           int      = src.COffset(bounds.Min.X, groups[i])
+			sums            []uint32 = nil
+			counts          []uint32 = nil
+			luma, cb, cr, a uint8    = 0, 0, 0, 0xff
+			err             error    = nil
+			written         bool     = dst != nil
+		)
+
+		if written {
+			sums, counts = make([]uint32, 2*width), make([]uint32, width)
+
+			if it.errorable && it.o.atomic && dst == src {
+				it.recordYCbCr(src, groups[i], groups[i+1], cBase, width)
+			}
+		}
+
+		defer func() {
+			if written {
+				dBase := dst.COffset(bounds.Min.X, groups[i])
+				for index, count := range counts {
+					if count > 0 {
+						dst.Cb[dBase+index] = uint8((sums[2*index+0] + count/2) / count)
+						dst.Cr[dBase+index] = uint8((sums[2*index+1] + count/2) / count)
+					}
+				}
+			}
+		}()
+
+		for yIndex := groups[i]; yIndex < groups[i+1]; yIndex += 1 {
+			var (
+				yOffset int = src.YOffset(bounds.Min.X, yIndex)
+				aOffset int = 0
+			)
+
+			if src.A != nil {
+				aOffset = src.AOffset(bounds.Min.X, yIndex)
+			}
+
+			p.y = yIndex - originY
+			for xIndex := bounds.Min.X; xIndex < bounds.Max.X; xIndex += 1 {
+				p.x = xIndex - originX
+				if it.errorable && it.interrupted(p) {
+					return
+				}
+
+				index := xIndex/hs - bounds.Min.X/hs
+				if src.A != nil {
+					a = src.A[aOffset]
+				} else {
+					a = 0xff
+				}
+
+				luma, cb, cr, a, err = d(xIndex-originX, yIndex-originY, src.Y[yOffset], src.Cb[cBase+index], src.Cr[cBase+index], a)
+				if err != nil {
+					if it.fail(p, err) {
+						return
+					}
+
+					yOffset += 1
+					aOffset += 1
+					continue
+				}
+
+				if written {
+					dst.Y[dst.YOffset(xIndex, yIndex)] = luma
+					if src.A != nil && dst.A != nil {
+						dst.A[dst.AOffset(xIndex, yIndex)] = a
+					}
+
+					sums[2*index+0] += uint32(cb)
+					sums[2*index+1] += uint32(cr)
+					counts[index] += 1
+				}
+
+				yOffset += 1
+				aOffset += 1
+			}
+		}
+	})
+}
+
+// Record the function restoring the original state of the rows in the range [minY, maxY) of the provided image, which
+// share the chroma samples starting at the provided offset.
+func (it *iteration) recordYCbCr(img *image.NYCbCrA, minY, maxY, cBase, width int) {
+	var (
+		bounds = img.Rect
+		length = bounds.Dx()
+		yBase  = img.YOffset(bounds.Min.X, minY)
+		aBase  = 0
+	)
+
+	savedY := make([][]uint8, 0, maxY-minY)
+	for yIndex := minY; yIndex < maxY; yIndex += 1 {
+		offset := img.YOffset(bounds.Min.X, yIndex)
+		savedY = append(savedY, append([]uint8(nil), img.Y[offset:offset+length]...))
+	}
+
+	savedA := make([][]uint8, 0, maxY-minY)
+	if img.A != nil {
+		aBase = img.AOffset(bounds.Min.X, minY)
+		for yIndex := minY; yIndex < maxY; yIndex += 1 {
+			offset := img.AOffset(bounds.Min.X, yIndex)
+			savedA = append(savedA, append([]uint8(nil), img.A[offset:offset+length]...))
+		}
+	}
+
+	savedCb := append([]uint8(nil), img.Cb[cBase:cBase+width]...)
+	savedCr := append([]uint8(nil), img.Cr[cBase:cBase+width]...)
+
+	it.record(func() {
+		for index, row := range savedY {
+			copy(img.Y[yBase+index*img.YStride:], row)
+		}
+
+		for index, row := range savedA {
+			copy(img.A[aBase+index*img.AStride:], row)
+		}
+
+		copy(img.Cb[cBase:], savedCb)
+		copy(img.Cr[cBase:], savedCr)
+	})
+}
+
+// Return the horizontal and vertical subsampling factors of the chroma planes for the provided subsample ratio.
+func subsampleFactors(ratio image.YCbCrSubsampleRatio) (int, int) {
+	switch ratio {
+	case image.YCbCrSubsampleRatio444:
+		return 1, 1
+	case image.YCbCrSubsampleRatio422:
+		return 2, 1
+	case image.YCbCrSubsampleRatio420:
+		return 2, 2
+	case image.YCbCrSubsampleRatio440:
+		return 1, 2
+	case image.YCbCrSubsampleRatio411:
+		return 4, 1
+	case image.YCbCrSubsampleRatio410:
+		return 4, 2
+	default:
+		panic("pimit: the provided image subsample ratio is invalid")
+	}
+}
+
+// Return the bounds of the chroma planes of the provided image, which are the bounds of the image divided by the
+// subsampling factors, in the same way as by the image.YCbCr COffset method.
+func chromaBounds(img *image.YCbCr) image.Rectangle {
+	hs, vs := subsampleFactors(img.SubsampleRatio)
+	if img.Rect.Empty() {
+		return image.Rectangle{}
+	}
+
+	return image.Rect(img.Rect.Min.X/hs, img.Rect.Min.Y/vs, (img.Rect.Max.X-1)/hs+1, (img.Rect.Max.Y-1)/vs+1)
+}
+
+// Return the first rows of the groups of the consecutive rows sharing the same row of the chroma planes, followed by
+// the bottom edge of the provided bounds.
+func chromaRowGroups(bounds image.Rectangle, vs int) []int {
+	groups := make([]int, 0, bounds.Dy()/vs+2)
+	for yIndex := bounds.Min.Y; yIndex < bounds.Max.Y; yIndex += 1 {
+		if yIndex == bounds.Min.Y || yIndex/vs != (yIndex-1)/vs {
+			groups = append(groups, yIndex)
+		}
+	}
+
+	return append(groups, bounds.Max.Y)
+}
@@ -0,0 +1,299 @@
+package pimit
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelYCbCrFunctionsShouldPanicOnNilArguments(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockCoordinateImageYCbCr(image.Rect(0, 0, 4, 4), image.YCbCrSubsampleRatio420)
+
+	assert.Panics(t, func() { ParallelYCbCrRead(nil, func(_, _ int, _, _, _ uint8) {}) })
+	assert.Panics(t, func() { ParallelYCbCrRead(img, nil) })
+	assert.Panics(t, func() { ParallelYCbCrReadE(nil, func(_, _ int, _, _, _ uint8) error { return nil }) })
+	assert.Panics(t, func() { ParallelYCbCrReadE(img, nil) })
+	assert.Panics(t, func() {
+		ParallelYCbCrReadWrite(nil, func(_, _ int, l, cb, cr uint8) (uint8, uint8, uint8) { return l, cb, cr })
+	})
+	assert.Panics(t, func() { ParallelYCbCrReadWrite(img, nil) })
+	assert.Panics(t, func() {
+		ParallelYCbCrReadWriteE(nil, func(_, _ int, l, cb, cr uint8) (uint8, uint8, uint8, error) { return l, cb, cr, nil })
+	})
+	assert.Panics(t, func() { ParallelYCbCrReadWriteE(img, nil) })
+	assert.Panics(t, func() {
+		ParallelYCbCrReadWriteNew(nil, func(_, _ int, l, cb, cr uint8) (uint8, uint8, uint8) { return l, cb, cr })
+	})
+	assert.Panics(t, func() { ParallelYCbCrReadWriteNew(img, nil) })
+	assert.Panics(t, func() {
+		ParallelYCbCrReadWriteNewE(nil, func(_, _ int, l, cb, cr uint8) (uint8, uint8, uint8, error) { return l, cb, cr, nil })
+	})
+	assert.Panics(t, func() { ParallelYCbCrReadWriteNewE(img, nil) })
+	assert.Panics(t, func() { ParallelYCbCrChromaRead(nil, func(_, _ int, _, _ uint8) {}) })
+	assert.Panics(t, func() { ParallelYCbCrChromaRead(img, nil) })
+	assert.Panics(t, func() { ParallelYCbCrChromaReadE(nil, func(_, _ int, _, _ uint8) error { return nil }) })
+	assert.Panics(t, func() { ParallelYCbCrChromaReadE(img, nil) })
+	assert.Panics(t, func() {
+		ParallelYCbCrChromaReadWrite(nil, func(_, _ int, cb, cr uint8) (uint8, uint8) { return cb, cr })
+	})
+	assert.Panics(t, func() { ParallelYCbCrChromaReadWrite(img, nil) })
+	assert.Panics(t, func() {
+		ParallelYCbCrChromaReadWriteE(nil, func(_, _ int, cb, cr uint8) (uint8, uint8, error) { return cb, cr, nil })
+	})
+	assert.Panics(t, func() { ParallelYCbCrChromaReadWriteE(img, nil) })
+}
+
+func TestParallelYCbCrFunctionsShouldPanicOnInvalidSubsampleRatio(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockCoordinateImageYCbCr(image.Rect(0, 0, 4, 4), image.YCbCrSubsampleRatio444)
+	img.SubsampleRatio = image.YCbCrSubsampleRatio(-1)
+
+	assert.Panics(t, func() { ParallelYCbCrRead(img, func(_, _ int, _, _, _ uint8) {}) })
+	assert.Panics(t, func() { ParallelYCbCrChromaRead(img, func(_, _ int, _, _ uint8) {}) })
+}
+
+func TestParallelYCbCrReadShouldReadSubsampledChroma(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, ratio := range mockSubsampleRatios() {
+		for _, bounds := range []image.Rectangle{image.Rect(0, 0, 13, 11), image.Rect(3, 5, 20, 18)} {
+			img := mockCoordinateImageYCbCr(bounds, ratio)
+
+			for _, workers := range []int{1, 4} {
+				mu := sync.Mutex{}
+				visits := make(map[image.Point]int)
+
+				ParallelYCbCrRead(img, func(x, y int, luma, cb, cr uint8) {
+					mu.Lock()
+					defer mu.Unlock()
+
+					visits[image.Pt(x, y)] += 1
+					assert.Equal(t, img.YCbCrAt(x, y), color.YCbCr{luma, cb, cr}, ratio.String())
+				}, WithWorkers(workers), WithChunkSize(1))
+
+				assert.Len(t, visits, bounds.Dx()*bounds.Dy(), ratio.String())
+				for _, count := range visits {
+					assert.Equal(t, 1, count, ratio.String())
+				}
+			}
+		}
+	}
+}
+
+func TestParallelYCbCrReadShouldHonorSubImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, ratio := range mockSubsampleRatios() {
+		parent := mockCoordinateImageYCbCr(image.Rect(0, 0, 24, 20), ratio)
+		sub := parent.SubImage(image.Rect(5, 3, 17, 16)).(*image.YCbCr)
+
+		err := ParallelYCbCrReadE(sub, func(x, y int, luma, cb, cr uint8) error {
+			assert.Equal(t, parent.YCbCrAt(x, y), color.YCbCr{luma, cb, cr}, ratio.String())
+			return nil
+		}, WithWorkers(3))
+
+		assert.Nil(t, err)
+
+		ParallelYCbCrRead(sub, func(x, y int, _, _, _ uint8) {
+			assert.True(t, image.Pt(x, y).In(image.Rect(0, 0, 12, 13)))
+		}, WithRelativeCoordinates())
+	}
+}
+
+func TestParallelYCbCrReadWriteShouldPreserveImageOnIdentity(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, ratio := range mockSubsampleRatios() {
+		bounds := image.Rect(1, 3, 18, 14)
+		expected := mockCoordinateImageYCbCr(bounds, ratio)
+
+		img := mockCoordinateImageYCbCr(bounds, ratio)
+		ParallelYCbCrReadWrite(img, func(_, _ int, luma, cb, cr uint8) (uint8, uint8, uint8) {
+			return luma, cb, cr
+		}, WithWorkers(4), WithChunkSize(1))
+
+		assert.Equal(t, expected, img, ratio.String())
+
+		dst := ParallelYCbCrReadWriteNew(expected, func(_, _ int, luma, cb, cr uint8) (uint8, uint8, uint8) {
+			return luma, cb, cr
+		}, WithWorkers(4), WithChunkSize(1))
+
+		assert.Equal(t, ratio, dst.SubsampleRatio)
+		assertEqualImages(t, expected, dst)
+
+		dst, err := ParallelYCbCrReadWriteNewE(expected, func(_, _ int, luma, cb, cr uint8) (uint8, uint8, uint8, error) {
+			return luma, cb, cr, nil
+		})
+
+		assert.Nil(t, err)
+		assertEqualImages(t, expected, dst)
+	}
+}
+
+func TestParallelYCbCrReadWriteShouldAverageChromaOfCoveredPixels(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockCoordinateImageYCbCr(image.Rect(0, 0, 4, 4), image.YCbCrSubsampleRatio420)
+
+	err := ParallelYCbCrReadWriteE(img, func(x, y int, _, cb, cr uint8) (uint8, uint8, uint8, error) {
+		return uint8(10 * y), uint8(10*x + 40*y), cr, nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []uint8{0, 0, 0, 0, 10, 10, 10, 10, 20, 20, 20, 20, 30, 30, 30, 30}, img.Y)
+	assert.Equal(t, []uint8{25, 45, 105, 125}, img.Cb)
+}
+
+func TestParallelYCbCrReadWriteShouldSetSharedChromaOfUnalignedSubImage(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	parent := mockCoordinateImageYCbCr(image.Rect(0, 0, 4, 4), image.YCbCrSubsampleRatio420)
+	expected := mockCoordinateImageYCbCr(image.Rect(0, 0, 4, 4), image.YCbCrSubsampleRatio420)
+
+	ParallelYCbCrReadWrite(parent.SubImage(image.Rect(2, 2, 4, 4)).(*image.YCbCr), func(_, _ int, luma, _, _ uint8) (uint8, uint8, uint8) {
+		return luma, 200, 100
+	})
+
+	assert.Equal(t, expected.Y, parent.Y)
+	assert.Equal(t, []uint8{expected.Cb[0], expected.Cb[1], expected.Cb[2], 200}, parent.Cb)
+	assert.Equal(t, []uint8{expected.Cr[0], expected.Cr[1], expected.Cr[2], 100}, parent.Cr)
+
+	parent = mockCoordinateImageYCbCr(image.Rect(0, 0, 4, 4), image.YCbCrSubsampleRatio420)
+
+	ParallelYCbCrReadWrite(parent.SubImage(image.Rect(1, 1, 3, 3)).(*image.YCbCr), func(_, _ int, luma, _, _ uint8) (uint8, uint8, uint8) {
+		return luma, 200, 100
+	})
+
+	assert.Equal(t, expected.Y, parent.Y)
+	assert.Equal(t, []uint8{200, 200, 200, 200}, parent.Cb)
+	assert.Equal(t, []uint8{100, 100, 100, 100}, parent.Cr)
+	assert.Equal(t, color.YCbCr{0, 200, 100}, parent.YCbCrAt(0, 0))
+}
+
+func TestParallelYCbCrReadWriteEShouldLeaveImageUnchangedOnFailureWhenAtomic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, ratio := range mockSubsampleRatios() {
+		bounds := image.Rect(-2, 1, 15, 16)
+		img := mockCoordinateImageYCbCr(bounds, ratio)
+
+		err := ParallelYCbCrReadWriteE(img, func(x, y int, luma, cb, cr uint8) (uint8, uint8, uint8, error) {
+			if x == 9 && y == 11 {
+				return luma, cb, cr, errors.New("pimit-test: test error")
+			}
+
+			return ^luma, ^cb, ^cr, nil
+		}, WithAtomic(), WithWorkers(4), WithChunkSize(1))
+
+		assert.NotNil(t, err)
+		assert.Equal(t, mockCoordinateImageYCbCr(bounds, ratio), img, ratio.String())
+	}
+}
+
+func TestParallelYCbCrReadWriteNewEShouldReturnErrorOnAccessError(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	img := mockCoordinateImageYCbCr(image.Rect(0, 0, 6, 6), image.YCbCrSubsampleRatio422)
+
+	dst, err := ParallelYCbCrReadWriteNewE(img, func(_, _ int, luma, cb, cr uint8) (uint8, uint8, uint8, error) {
+		return luma, cb, cr, errors.New("pimit-test: test error")
+	})
+
+	assert.NotNil(t, err)
+	assert.Nil(t, dst)
+}
+
+func TestParallelYCbCrChromaFunctionsShouldIterateChromaPlanes(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	for _, ratio := range mockSubsampleRatios() {
+		for _, bounds := range []image.Rectangle{image.Rect(0, 0, 13, 11), image.Rect(3, 5, 20, 18)} {
+			img := mockCoordinateImageYCbCr(bounds, ratio)
+			hs, vs := subsampleFactors(ratio)
+
+			mu := sync.Mutex{}
+			visits := make(map[image.Point]int)
+
+			err := ParallelYCbCrChromaReadE(img, func(x, y int, cb, cr uint8) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				visits[image.Pt(x, y)] += 1
+
+				px, py := x*hs, y*vs
+				if px < bounds.Min.X {
+					px = bounds.Min.X
+				}
+
+				if py < bounds.Min.Y {
+					py = bounds.Min.Y
+				}
+
+				c := img.YCbCrAt(px, py)
+				assert.Equal(t, []uint8{c.Cb, c.Cr}, []uint8{cb, cr}, ratio.String())
+				return nil
+			}, WithWorkers(3))
+
+			assert.Nil(t, err)
+			assert.Len(t, visits, len(img.Cb), ratio.String())
+
+			ParallelYCbCrChromaReadWrite(img, func(x, y int, cb, cr uint8) (uint8, uint8) {
+				return uint8(x), uint8(y)
+			}, WithRelativeCoordinates())
+
+			ParallelYCbCrRead(img, func(x, y int, _, cb, cr uint8) {
+				assert.Equal(t, []uint8{uint8(x/hs - bounds.Min.X/hs), uint8(y/vs - bounds.Min.Y/vs)}, []uint8{cb, cr}, ratio.String())
+			})
+		}
+	}
+}
+
+func TestParallelYCbCrChromaReadWriteEShouldLeaveImageUnchangedOnFailureWhenAtomic(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	bounds := image.Rect(0, 0, 16, 16)
+	img := mockCoordinateImageYCbCr(bounds, image.YCbCrSubsampleRatio420)
+
+	err := ParallelYCbCrChromaReadWriteE(img, func(x, y int, cb, cr uint8) (uint8, uint8, error) {
+		if x == 5 && y == 6 {
+			return cb, cr, errors.New("pimit-test: test error")
+		}
+
+		return ^cb, ^cr, nil
+	}, WithAtomic(), WithWorkers(2), WithChunkSize(1))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, mockCoordinateImageYCbCr(bounds, image.YCbCrSubsampleRatio420), img)
+}
+
+func mockSubsampleRatios() []image.YCbCrSubsampleRatio {
+	return []image.YCbCrSubsampleRatio{
+		image.YCbCrSubsampleRatio444,
+		image.YCbCrSubsampleRatio422,
+		image.YCbCrSubsampleRatio420,
+		image.YCbCrSubsampleRatio440,
+		image.YCbCrSubsampleRatio411,
+		image.YCbCrSubsampleRatio410,
+	}
+}
+
+func mockCoordinateImageYCbCr(bounds image.Rectangle, ratio image.YCbCrSubsampleRatio) *image.YCbCr {
+	img := image.NewYCbCr(bounds, ratio)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 1 {
+		for x := bounds.Min.X; x < bounds.Max.X; x += 1 {
+			img.Y[img.YOffset(x, y)] = uint8(x + 2*y)
+			img.Cb[img.COffset(x, y)] = uint8(3 * x)
+			img.Cr[img.COffset(x, y)] = uint8(5 * y)
+		}
+	}
+
+	return img
+}